@@ -84,6 +84,7 @@ func startService(configFile string, remote bool) error {
 	clientManager := service.NewClientManager(&service.DefaultClientFactory{})
 	scheduler := service.NewScheduler(ctx)
 	backupHandlers := make(service.BackupHandlerHolder)
+	backupRuns := service.NewBackupRuns()
 
 	configApplier := service.NewDefaultConfigApplier(
 		scheduler,
@@ -91,6 +92,7 @@ func startService(configFile string, remote bool) error {
 		backends,
 		clientManager,
 		&backupHandlers,
+		backupRuns,
 	)
 
 	err = configApplier.ApplyNewConfig()
@@ -101,6 +103,9 @@ func startService(configFile string, remote bool) error {
 	var restoreJobs = service.NewRestoreJobsHolder()
 	service.NewMetricsCollector(backupHandlers, restoreJobs).Start(ctx, 1*time.Second)
 
+	garbageCollector := service.NewBackupGarbageCollector(config.ServiceConfig.GarbageCollector, backends)
+	garbageCollector.Start(ctx)
+
 	restoreMgr := service.NewRestoreManager(backends, config, service.NewRestore(), clientManager, restoreJobs)
 
 	httpService := handlers.NewService(
@@ -110,6 +115,8 @@ func startService(configFile string, remote bool) error {
 		restoreMgr,
 		backends,
 		backupHandlers,
+		backupRuns,
+		garbageCollector,
 		configurationManager,
 		appLogger,
 	)
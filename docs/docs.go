@@ -96,16 +96,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/backups/currentBackup/{name}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+        "/v1/backups/cancel/{name}": {
+            "post": {
+                "description": "Cancels running full and incremental backups of the routine and waits for them to stop.\nPartial output of the cancelled backups is deleted.",
                 "tags": [
                     "Backup"
                 ],
-                "summary": "Get current backup statistics.",
-                "operationId": "getCurrentBackup",
+                "summary": "Cancel running backups of the routine.",
+                "operationId": "CancelBackup",
                 "parameters": [
                     {
                         "type": "string",
@@ -116,11 +114,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Current backup statistics",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CurrentBackups"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -143,47 +138,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/backups/full": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+        "/v1/backups/catalog/{name}": {
+            "post": {
+                "description": "The backups are listed from the catalog of the routine, which is updated on every backup write and\ndelete. Rebuilds the catalog from the metadata files of the backups, if it is out of date.",
                 "tags": [
                     "Backup"
                 ],
-                "summary": "Get available full backups.",
-                "operationId": "getFullBackups",
+                "summary": "Rebuild the backup catalog of a routine.",
+                "operationId": "RebuildBackupCatalog",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Lower bound timestamp filter",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Upper bound timestamp filter",
-                        "name": "to",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Full backups by routine",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/dto.BackupDetails"
-                                }
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
@@ -197,7 +174,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/backups/full/{name}": {
+        "/v1/backups/currentBackup/{name}": {
             "get": {
                 "produces": [
                     "application/json"
@@ -205,8 +182,8 @@ const docTemplate = `{
                 "tags": [
                     "Backup"
                 ],
-                "summary": "Get available full backups for routine.",
-                "operationId": "GetFullBackupsForRoutine",
+                "summary": "Get current backup statistics.",
+                "operationId": "getCurrentBackup",
                 "parameters": [
                     {
                         "type": "string",
@@ -214,30 +191,13 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Lower bound timestamp filter",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Upper bound timestamp filter",
-                        "name": "to",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Full backups for routine",
+                        "description": "Current backup statistics",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.BackupDetails"
-                            }
+                            "$ref": "#/definitions/dto.CurrentBackups"
                         }
                     },
                     "400": {
@@ -246,6 +206,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -255,7 +221,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/backups/incremental": {
+        "/v1/backups/full": {
             "get": {
                 "produces": [
                     "application/json"
@@ -263,8 +229,8 @@ const docTemplate = `{
                 "tags": [
                     "Backup"
                 ],
-                "summary": "Get available incremental backups.",
-                "operationId": "getIncrementalBackups",
+                "summary": "Get available full backups.",
+                "operationId": "getFullBackups",
                 "parameters": [
                     {
                         "type": "integer",
@@ -279,11 +245,63 @@ const docTemplate = `{
                         "description": "Upper bound timestamp filter",
                         "name": "to",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace filter",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "full",
+                            "incremental"
+                        ],
+                        "type": "string",
+                        "description": "Backup type filter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ad-hoc backup filter",
+                        "name": "ad-hoc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source cluster label or name filter",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the set",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the bin",
+                        "name": "bin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compression mode filter",
+                        "name": "compression",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encryption mode filter",
+                        "name": "encryption",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Incremental backups by routine",
+                        "description": "Full backups by routine",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -309,7 +327,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/backups/incremental/{name}": {
+        "/v1/backups/full/{name}": {
             "get": {
                 "produces": [
                     "application/json"
@@ -317,8 +335,8 @@ const docTemplate = `{
                 "tags": [
                     "Backup"
                 ],
-                "summary": "Get incremental backups for routine.",
-                "operationId": "GetIncrementalBackupsForRoutine",
+                "summary": "Get available full backups for routine.",
+                "operationId": "GetFullBackupsForRoutine",
                 "parameters": [
                     {
                         "type": "string",
@@ -340,11 +358,63 @@ const docTemplate = `{
                         "description": "Upper bound timestamp filter",
                         "name": "to",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace filter",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "full",
+                            "incremental"
+                        ],
+                        "type": "string",
+                        "description": "Backup type filter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ad-hoc backup filter",
+                        "name": "ad-hoc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source cluster label or name filter",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the set",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the bin",
+                        "name": "bin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compression mode filter",
+                        "name": "compression",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encryption mode filter",
+                        "name": "encryption",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Incremental backups for routine",
+                        "description": "Full backups for routine",
                         "schema": {
                             "type": "array",
                             "items": {
@@ -367,13 +437,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/backups/schedule/{name}": {
-            "post": {
+        "/v1/backups/full/{name}/{timestamp}": {
+            "delete": {
                 "tags": [
                     "Backup"
                 ],
-                "summary": "Schedule a full backup once per routine name.",
-                "operationId": "ScheduleFullBackup",
+                "summary": "Delete a full backup.",
+                "operationId": "DeleteFullBackup",
                 "parameters": [
                     {
                         "type": "string",
@@ -384,14 +454,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Delay interval in milliseconds",
-                        "name": "delay",
+                        "format": "int64",
+                        "description": "Backup timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent incremental backups",
+                        "name": "cascade",
                         "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -405,31 +483,11 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/config": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Configuration"
-                ],
-                "summary": "Returns the configuration for the service.",
-                "operationId": "readConfig",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Config"
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -438,56 +496,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
+            }
+        },
+        "/v1/backups/incomplete": {
+            "post": {
+                "description": "Removes the backup folders without metadata, left by failed backups or service crashes,\nthat are older than the garbage collector min-age. The folders of running backups are skipped.\nWith dry-run, the incomplete backups are only reported.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Backup"
                 ],
-                "summary": "Updates the configuration for the service.",
-                "operationId": "updateConfig",
+                "summary": "Remove incomplete backups.",
+                "operationId": "RemoveIncompleteBackups",
                 "parameters": [
                     {
-                        "description": "Configuration details",
-                        "name": "config",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.Config"
-                        }
+                        "type": "boolean",
+                        "description": "Report the incomplete backups without removing them",
+                        "name": "dry-run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "The removed (or found, with dry-run) incomplete backups",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.IncompleteBackup"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/config/apply": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Configuration"
-                ],
-                "summary": "Reloads the configuration from the config file.",
-                "operationId": "applyConfig",
-                "responses": {
-                    "200": {
-                        "description": "OK"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -495,7 +542,734 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/config/clusters": {
+        "/v1/backups/incremental": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Get available incremental backups.",
+                "operationId": "getIncrementalBackups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Lower bound timestamp filter",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Upper bound timestamp filter",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace filter",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "full",
+                            "incremental"
+                        ],
+                        "type": "string",
+                        "description": "Backup type filter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ad-hoc backup filter",
+                        "name": "ad-hoc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source cluster label or name filter",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the set",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the bin",
+                        "name": "bin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compression mode filter",
+                        "name": "compression",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encryption mode filter",
+                        "name": "encryption",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Incremental backups by routine",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/dto.BackupDetails"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/incremental/{name}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Get incremental backups for routine.",
+                "operationId": "GetIncrementalBackupsForRoutine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Lower bound timestamp filter",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Upper bound timestamp filter",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace filter",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "full",
+                            "incremental"
+                        ],
+                        "type": "string",
+                        "description": "Backup type filter",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ad-hoc backup filter",
+                        "name": "ad-hoc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source cluster label or name filter",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the set",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter of the backups including the bin",
+                        "name": "bin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compression mode filter",
+                        "name": "compression",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encryption mode filter",
+                        "name": "encryption",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Incremental backups for routine",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BackupDetails"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/incremental/{name}/{timestamp}": {
+            "delete": {
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Delete an incremental backup.",
+                "operationId": "DeleteIncrementalBackup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Backup timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/jobs/{id}": {
+            "get": {
+                "description": "The status of a finished job is kept for 24 hours.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Retrieve status of an ad-hoc backup job.",
+                "operationId": "getBackupJobStatus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Job ID to retrieve the status",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backup job status details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackupJobStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/migrate/{name}": {
+            "post": {
+                "description": "When the storage of a routine is changed, the previous storage is kept as a read-only historical\nstorage, and its backups are still listed and restorable. The migration runs after the storage\nswitch, while the new backups are already written to the routine storage: it copies the backups of\nthe historical storages to the routine storage in the background, and removes the migrated\nhistorical storages from the routine configuration.",
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Migrate the backups of the historical storages of a routine.",
+                "operationId": "MigrateHistoricalBackups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/replication/{name}/{timestamp}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Get the replication status of a backup.",
+                "operationId": "GetBackupReplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Backup timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The replication status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackupReplication"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/schedule/{name}": {
+            "post": {
+                "description": "Schedules a one-off full (default) or incremental backup.\nThe optional request body overrides the routine parameters for this incremental run only.\nFull backups are run with the routine parameters, as they are used to restore the routine.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Schedule a backup once per routine name.",
+                "operationId": "ScheduleFullBackup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delay interval in milliseconds",
+                        "name": "delay",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "full",
+                            "incremental"
+                        ],
+                        "type": "string",
+                        "description": "Backup type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Backup parameters overrides",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackupOverrides"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Backup job id",
+                        "schema": {
+                            "type": "int64"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/verify/{name}": {
+            "post": {
+                "description": "Reads the latest full backup of the routine back, decrypting and decompressing it with the routine\npolicy, and compares the number of records and bytes with the backup metadata.\nThe verification runs in the background, its results are stored next to the backup metadata.",
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Verify the latest full backup.",
+                "operationId": "VerifyLatestBackup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "The timestamp of the verified backup",
+                        "schema": {
+                            "type": "int64"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/backups/verify/{name}/{timestamp}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Get the verification results of a backup.",
+                "operationId": "GetBackupVerification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Backup timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The verification results",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackupVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Reads the full or incremental backup back, decrypting and decompressing it with the routine policy,\nand compares the number of records and bytes with the backup metadata.\nThe verification runs in the background, its results are stored next to the backup metadata.",
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Verify a backup.",
+                "operationId": "VerifyBackup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Backup timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "The timestamp of the verified backup",
+                        "schema": {
+                            "type": "int64"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Returns the configuration for the service.",
+                "operationId": "readConfig",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Config"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Updates the configuration for the service.",
+                "operationId": "updateConfig",
+                "parameters": [
+                    {
+                        "description": "Configuration details",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.Config"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/apply": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Reloads the configuration from the config file.",
+                "operationId": "applyConfig",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/clusters": {
             "get": {
                 "produces": [
                     "application/json"
@@ -894,6 +1668,7 @@ const docTemplate = `{
         },
         "/v1/config/routines/{name}": {
             "get": {
+                "description": "The response includes the next scheduled backup times of the routine.",
                 "produces": [
                     "application/json"
                 ],
@@ -915,7 +1690,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.BackupRoutine"
+                            "$ref": "#/definitions/dto.BackupRoutineWithSchedule"
                         }
                     },
                     "400": {
@@ -1038,6 +1813,127 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/config/routines/{name}/pause": {
+            "post": {
+                "description": "Disables the routine and unschedules its backups.\nExisting backups of the routine are still available for listing and restore.",
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Pauses a backup routine.",
+                "operationId": "pauseRoutine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/routines/{name}/rename": {
+            "post": {
+                "description": "The backups of the routine keep their paths in the storages, which are derived from the original\nroutine name, and the routine keeps its backup history and state. The routine cannot be renamed\nwhile its backups are running, new backups of the routine wait until the rename is applied.\nThe new name follows the same rules as the name of a new routine.",
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Renames a backup routine.",
+                "operationId": "renameRoutine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The new routine name",
+                        "name": "new-name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/routines/{name}/resume": {
+            "post": {
+                "description": "Enables the routine and schedules its backups again.",
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Resumes a paused backup routine.",
+                "operationId": "resumeRoutine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup routine name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/config/storage": {
             "get": {
                 "produces": [
@@ -1219,6 +2115,65 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/config/storage/{name}/test": {
+            "post": {
+                "description": "Writes, lists, reads and deletes a probe object in the storage, and reports the latency of each\noperation and the provider error of the failed one. The storage definition in the request body is\nchecked if given, so that it can be checked before it is saved, otherwise the configured storage.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Checks the access to a storage.",
+                "operationId": "testStorage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup storage name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Unsaved backup storage details",
+                        "name": "storage",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Storage"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorageCheck"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The specified storage could not be found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/restore/full": {
             "post": {
                 "consumes": [
@@ -1434,6 +2389,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/schedule": {
+            "get": {
+                "description": "Returns per routine the next fire times of the full and incremental backup triggers,\nthe currently running backups, the last run results and whether a catch-up\nfull backup is pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Get the upcoming backup schedule of all routines.",
+                "operationId": "getSchedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The number of next fire times per trigger (default 5, max 100)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/dto.RoutineSchedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/version": {
             "get": {
                 "tags": [
@@ -1547,23 +2546,87 @@ const docTemplate = `{
             "description": "BackupDetails contains information about a backup.",
             "type": "object",
             "properties": {
+                "ad-hoc": {
+                    "description": "Whether the backup was run on demand rather than on schedule.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "bin-list": {
+                    "description": "The backed up bins, empty if all bins were backed up.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dataBin"
+                    ]
+                },
                 "byte-count": {
                     "description": "The size of the backup in bytes.",
                     "type": "integer",
                     "format": "int64",
                     "example": 2000
                 },
+                "bytes-per-second": {
+                    "description": "The number of bytes written per second.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 20000
+                },
+                "checksum": {
+                    "description": "The SHA-256 checksum of the backup metadata.",
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "cluster-label": {
+                    "description": "The label of the source cluster in the service configuration.",
+                    "type": "string",
+                    "example": "cluster1"
+                },
+                "cluster-name": {
+                    "description": "The name of the source cluster reported by the Aerospike server.",
+                    "type": "string",
+                    "example": "prod"
+                },
+                "compression": {
+                    "description": "The compression mode of the backup files.",
+                    "type": "string",
+                    "example": "ZSTD"
+                },
                 "created": {
                     "description": "The backup time in the ISO 8601 format.",
                     "type": "string",
                     "example": "2023-03-20T14:50:00Z"
                 },
+                "duration": {
+                    "description": "The duration of the namespace backup in milliseconds.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 299000
+                },
+                "encryption": {
+                    "description": "The encryption mode of the backup files.",
+                    "type": "string",
+                    "example": "AES256"
+                },
                 "file-count": {
                     "description": "The number of backup files created.",
                     "type": "integer",
                     "format": "int64",
                     "example": 1
                 },
+                "files": {
+                    "description": "The manifest of the backup data files.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupFile"
+                    }
+                },
+                "finished": {
+                    "description": "The end time of the namespace backup in the ISO 8601 format.",
+                    "type": "string",
+                    "example": "2023-03-20T14:55:00Z"
+                },
                 "from": {
                     "description": "The lower time bound of backup entities in the ISO 8601 format (for incremental backups).",
                     "type": "string",
@@ -1579,26 +2642,259 @@ const docTemplate = `{
                     "type": "string",
                     "example": "testNamespace"
                 },
+                "parent": {
+                    "description": "The key of the full backup the incremental backup is based on.",
+                    "type": "string",
+                    "example": "daily/backup/1707915600000/source-ns1"
+                },
+                "partition-list": {
+                    "description": "The partition filters of a backup, empty if all partitions were backed up.",
+                    "type": "string",
+                    "example": "0-1000"
+                },
                 "record-count": {
                     "description": "The total number of records backed up.",
                     "type": "integer",
                     "format": "int64",
                     "example": 100
                 },
+                "records-per-second": {
+                    "description": "The number of records backed up per second.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1000
+                },
+                "routine": {
+                    "description": "The name of the backup routine.",
+                    "type": "string",
+                    "example": "daily"
+                },
                 "secondary-index-count": {
                     "description": "The number of secondary indexes backed up.",
                     "type": "integer",
                     "format": "int64",
                     "example": 5
                 },
+                "service-version": {
+                    "description": "The version of the backup service which created the backup.",
+                    "type": "string",
+                    "example": "2.0.0"
+                },
+                "set-list": {
+                    "description": "The backed up sets, empty if all sets were backed up.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "set1"
+                    ]
+                },
+                "started": {
+                    "description": "The start time of the namespace backup in the ISO 8601 format.",
+                    "type": "string",
+                    "example": "2023-03-20T14:50:01Z"
+                },
                 "storage": {
                     "$ref": "#/definitions/dto.Storage"
                 },
-                "udf-count": {
-                    "description": "The number of UDF files backed up.",
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 2
+                "to": {
+                    "description": "The upper time bound of backup entities in the ISO 8601 format.",
+                    "type": "string",
+                    "example": "2023-03-20T14:50:00Z"
+                },
+                "type": {
+                    "description": "The type of the backup.",
+                    "type": "string",
+                    "enum": [
+                        "full",
+                        "incremental"
+                    ]
+                },
+                "udf-count": {
+                    "description": "The number of UDF files backed up.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2
+                }
+            }
+        },
+        "dto.BackupFile": {
+            "description": "BackupFile is an entry of the backup file manifest.",
+            "type": "object",
+            "properties": {
+                "path": {
+                    "description": "The path of the file relative to the backup folder.",
+                    "type": "string",
+                    "example": "source-ns1_1.asb"
+                },
+                "sha256": {
+                    "description": "The hex encoded SHA-256 checksum of the file.",
+                    "type": "string",
+                    "example": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+                },
+                "size": {
+                    "description": "The size of the file in bytes.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2000
+                }
+            }
+        },
+        "dto.BackupJobStats": {
+            "description": "BackupJobStats represents the statistics of a namespace backup.",
+            "type": "object",
+            "properties": {
+                "byte-count": {
+                    "description": "The number of bytes written.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2000
+                },
+                "file-count": {
+                    "description": "The number of backup files created.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
+                },
+                "record-count": {
+                    "description": "The number of records backed up.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
+                },
+                "secondary-index-count": {
+                    "description": "The number of secondary indexes backed up.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 5
+                },
+                "total-records": {
+                    "description": "The estimated total number of records to back up.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
+                },
+                "udf-count": {
+                    "description": "The number of UDF files backed up.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2
+                }
+            }
+        },
+        "dto.BackupJobStatus": {
+            "description": "BackupJobStatus represents the status of an ad-hoc backup job.",
+            "type": "object",
+            "properties": {
+                "created-time": {
+                    "description": "The time the job was scheduled.",
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "end-time": {
+                    "description": "The time the job finished, absent if it has not finished yet.",
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "errors": {
+                    "description": "The error chain of a failed job, from the outermost error to the root cause.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keys": {
+                    "description": "The keys of the created backups, available when the job is done.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "daily/backup/1707915600000/data/source-ns1"
+                    ]
+                },
+                "namespaces": {
+                    "description": "Backup statistics by namespace.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.BackupJobStats"
+                    }
+                },
+                "routine": {
+                    "description": "The backup routine name.",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "start-time": {
+                    "description": "The time the job started, absent if it has not started yet.",
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "status": {
+                    "enum": [
+                        "Queued",
+                        "Running",
+                        "Skipped",
+                        "Done",
+                        "Failed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.JobStatus"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "The backup type.",
+                    "type": "string",
+                    "enum": [
+                        "full",
+                        "incremental"
+                    ],
+                    "example": "full"
+                }
+            }
+        },
+        "dto.BackupOverrides": {
+            "description": "BackupOverrides are the parameters of a single ad-hoc incremental backup run, which override the backup routine configuration.",
+            "type": "object",
+            "properties": {
+                "bin-list": {
+                    "description": "The list of backup bin names (optional, the routine bin list by default).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dataBin"
+                    ]
+                },
+                "from": {
+                    "description": "Back up only records modified after this epoch time in milliseconds\n(optional, the time of the last backup by default).",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1739538000000
+                },
+                "namespaces": {
+                    "description": "The list of the namespaces to back up (optional, the routine namespaces by default).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "source-ns1"
+                    ]
+                },
+                "set-list": {
+                    "description": "The list of backup set names (optional, the routine set list by default).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "set1"
+                    ]
                 }
             }
         },
@@ -1637,6 +2933,28 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 3
                 },
+                "namespace-order": {
+                    "description": "The order in which the namespaces are backed up: config (default) keeps the configured order,\nsize backs up the largest namespaces first.",
+                    "enum": [
+                        "config",
+                        "size"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.NamespaceOrder"
+                        }
+                    ]
+                },
+                "namespace-priority": {
+                    "description": "The namespaces backed up first, in the given order, before the other namespaces in the namespace-order.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "source-ns1"
+                    ]
+                },
                 "no-indexes": {
                     "description": "Do not back up any secondary index definitions.",
                     "type": "boolean"
@@ -1654,6 +2972,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "parallel-namespaces": {
+                    "description": "Maximum number of namespaces backed up in parallel, 1 backs them up sequentially.\nAll namespaces are backed up in parallel by default.",
+                    "type": "integer",
+                    "example": 2
+                },
                 "records-per-second": {
                     "description": "Limit total returned records per second (RPS). If RPS is zero (the default),\nthe records-per-second limit is not applied.",
                     "type": "integer",
@@ -1672,11 +2995,29 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "retention": {
+                    "description": "Retention policy for full and incremental backups (default: keep all).\nCannot be combined with remove-files RemoveAll.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RetentionPolicy"
+                        }
+                    ]
+                },
                 "retry-delay": {
                     "description": "RetryDelay defines the delay in milliseconds before retrying a failed operation.",
                     "type": "integer",
                     "example": 500
                 },
+                "retry-max-delay": {
+                    "description": "RetryMaxDelay defines the maximum delay in milliseconds between the retries (default: 10 minutes).",
+                    "type": "integer",
+                    "example": 600000
+                },
+                "retry-multiplier": {
+                    "description": "RetryMultiplier defines the factor the retry delay is multiplied by after\neach retry, 1 keeps the delay fixed (default: 2).",
+                    "type": "number",
+                    "example": 2
+                },
                 "sealed": {
                     "description": "Sealed determines whether backup should include keys updated during the backup process.\nWhen true, the backup contains only records that last modified before backup started.\nWhen false (default), records updated during backup might be included in the backup, but it's not guaranteed.",
                     "type": "boolean"
@@ -1693,6 +3034,24 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.BackupReplication": {
+            "description": "BackupReplication contains the replication status of a backup.",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "The creation time of the backup.",
+                    "type": "string",
+                    "example": "2023-03-20T14:50:00Z"
+                },
+                "replicas": {
+                    "description": "The status of the copies to the replica storages.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReplicaStatus"
+                    }
+                }
+            }
+        },
         "dto.BackupRoutine": {
             "description": "BackupRoutine represents a scheduled backup operation routine.",
             "type": "object",
@@ -1718,6 +3077,164 @@ const docTemplate = `{
                         "dataBin"
                     ]
                 },
+                "blackout-windows": {
+                    "description": "Scheduled backups of the routine are not run during the blackout windows.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BlackoutWindow"
+                    }
+                },
+                "disabled": {
+                    "description": "Disabled routines are not scheduled, their backups are still available for listing and restore.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "historical-storages": {
+                    "description": "The storages the backups of the routine were stored in before, read-only.\nThe previous storage is added when the storage of the routine is changed,\nand removed once its backups are migrated.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Storage"
+                    }
+                },
+                "incr-interval-cron": {
+                    "description": "The interval for incremental backup as a cron expression string (optional).",
+                    "type": "string",
+                    "example": "*/10 * * * * *"
+                },
+                "interval-cron": {
+                    "description": "The interval for full backup as a cron expression string.",
+                    "type": "string",
+                    "example": "0 0 * * * *"
+                },
+                "namespaces": {
+                    "description": "The list of the namespaces to back up (optional, empty list implies backup up whole cluster).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "source-ns1"
+                    ]
+                },
+                "partition-list": {
+                    "description": "Back up list of partition filters. Partition filters can be ranges, individual partitions,\nor records after a specific digest within a single partition.\nDefault number of partitions to back up: 0 to 4095: all partitions.",
+                    "type": "string",
+                    "example": "0-1000"
+                },
+                "prefer-racks": {
+                    "description": "A list of Aerospike Server rack IDs to prefer when reading records for a backup.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0
+                    ]
+                },
+                "replicate-to": {
+                    "description": "The names of the secondary storages the backups are copied to (optional).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gcp"
+                    ]
+                },
+                "secret-agent": {
+                    "description": "The Secret Agent configuration for the routine (optional).",
+                    "type": "string",
+                    "example": "sa"
+                },
+                "set-list": {
+                    "description": "The list of backup set names (optional, an empty list implies backing up all sets).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "set1"
+                    ]
+                },
+                "source-cluster": {
+                    "description": "The name of the corresponding source cluster.",
+                    "type": "string",
+                    "example": "testCluster"
+                },
+                "storage": {
+                    "description": "The name of the corresponding storage provider configuration.",
+                    "type": "string",
+                    "example": "aws"
+                },
+                "storage-prefix": {
+                    "description": "The prefix of the backup paths in the storages, the routine name if empty.\nIt is set when the routine is renamed, so that its backups keep their paths.",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "tiering": {
+                    "description": "Moves the aged backups to another storage (optional).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.TieringPolicy"
+                        }
+                    ]
+                },
+                "time-zone": {
+                    "description": "The IANA time zone of the cron expressions (optional, UTC by default).",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "verify-interval-cron": {
+                    "description": "The interval for verification of the latest full backup as a cron expression string (optional).",
+                    "type": "string",
+                    "example": "0 0 3 ? * SUN"
+                }
+            }
+        },
+        "dto.BackupRoutineWithSchedule": {
+            "description": "BackupRoutineWithSchedule is a backup routine with its next scheduled backup times.",
+            "type": "object",
+            "required": [
+                "backup-policy",
+                "interval-cron",
+                "source-cluster",
+                "storage"
+            ],
+            "properties": {
+                "backup-policy": {
+                    "description": "The name of the corresponding backup policy.",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "bin-list": {
+                    "description": "The list of backup bin names (optional, an empty list implies backing up all bins).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dataBin"
+                    ]
+                },
+                "blackout-windows": {
+                    "description": "Scheduled backups of the routine are not run during the blackout windows.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BlackoutWindow"
+                    }
+                },
+                "disabled": {
+                    "description": "Disabled routines are not scheduled, their backups are still available for listing and restore.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "historical-storages": {
+                    "description": "The storages the backups of the routine were stored in before, read-only.\nThe previous storage is added when the storage of the routine is changed,\nand removed once its backups are migrated.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Storage"
+                    }
+                },
                 "incr-interval-cron": {
                     "description": "The interval for incremental backup as a cron expression string (optional).",
                     "type": "string",
@@ -1738,6 +3255,16 @@ const docTemplate = `{
                         "source-ns1"
                     ]
                 },
+                "next-full-backup": {
+                    "description": "The next scheduled full backup time, empty if the routine is disabled.",
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "next-incremental-backup": {
+                    "description": "The next scheduled incremental backup time, empty if incremental backups are not scheduled.",
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
                 "partition-list": {
                     "description": "Back up list of partition filters. Partition filters can be ranges, individual partitions,\nor records after a specific digest within a single partition.\nDefault number of partitions to back up: 0 to 4095: all partitions.",
                     "type": "string",
@@ -1753,6 +3280,16 @@ const docTemplate = `{
                         0
                     ]
                 },
+                "replicate-to": {
+                    "description": "The names of the secondary storages the backups are copied to (optional).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gcp"
+                    ]
+                },
                 "secret-agent": {
                     "description": "The Secret Agent configuration for the routine (optional).",
                     "type": "string",
@@ -1777,6 +3314,29 @@ const docTemplate = `{
                     "description": "The name of the corresponding storage provider configuration.",
                     "type": "string",
                     "example": "aws"
+                },
+                "storage-prefix": {
+                    "description": "The prefix of the backup paths in the storages, the routine name if empty.\nIt is set when the routine is renamed, so that its backups keep their paths.",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "tiering": {
+                    "description": "Moves the aged backups to another storage (optional).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.TieringPolicy"
+                        }
+                    ]
+                },
+                "time-zone": {
+                    "description": "The IANA time zone of the cron expressions (optional, UTC by default).",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "verify-interval-cron": {
+                    "description": "The interval for verification of the latest full backup as a cron expression string (optional).",
+                    "type": "string",
+                    "example": "0 0 3 ? * SUN"
                 }
             }
         },
@@ -1784,6 +3344,29 @@ const docTemplate = `{
             "description": "BackupServiceConfig represents the backup service configuration properties.",
             "type": "object",
             "properties": {
+                "blackout-windows": {
+                    "description": "Scheduled backups of all routines are not run during the blackout windows.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BlackoutWindow"
+                    }
+                },
+                "concurrency-limits": {
+                    "description": "Limits of concurrently running backup jobs (optional).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ConcurrencyLimits"
+                        }
+                    ]
+                },
+                "garbage-collector": {
+                    "description": "Removal of incomplete backups (optional).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GarbageCollector"
+                        }
+                    ]
+                },
                 "http": {
                     "description": "HTTPServer is the backup service HTTP server configuration.",
                     "allOf": [
@@ -1792,13 +3375,76 @@ const docTemplate = `{
                         }
                     ]
                 },
-                "logger": {
-                    "description": "Logger is the backup service logger configuration.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.LoggerConfig"
-                        }
-                    ]
+                "logger": {
+                    "description": "Logger is the backup service logger configuration.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.LoggerConfig"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.BackupVerification": {
+            "description": "BackupVerification contains the verification results of a backup.",
+            "type": "object",
+            "properties": {
+                "results": {
+                    "description": "The results of the last verification, per namespace.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VerificationResult"
+                    }
+                },
+                "running": {
+                    "description": "The verification of the backup is running.",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.BlackoutWindow": {
+            "description": "BlackoutWindow defines a period of time when scheduled backups are not run.",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "What to do with the runs that fall inside the window: skip (default) or defer until the window ends.",
+                    "type": "string",
+                    "enum": [
+                        "skip",
+                        "defer"
+                    ],
+                    "example": "skip"
+                },
+                "cancel-running": {
+                    "description": "Cancel running scheduled backups when the window starts.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "cron": {
+                    "description": "The start of the recurring window as a cron expression, requires duration.",
+                    "type": "string",
+                    "example": "0 0 1 * * *"
+                },
+                "duration": {
+                    "description": "The duration of the window started by the cron expression, e.g. 2h, 1d.",
+                    "type": "string",
+                    "example": "2h"
+                },
+                "from": {
+                    "description": "The start of the daily window in HH:MM format.",
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "time-zone": {
+                    "description": "The IANA time zone of the window, UTC by default.",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "to": {
+                    "description": "The end of the daily window in HH:MM format, the window ends on the next day if it is not after from.",
+                    "type": "string",
+                    "example": "02:00"
                 }
             }
         },
@@ -1821,6 +3467,41 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ConcurrencyLimits": {
+            "description": "ConcurrencyLimits limits the number of concurrently running backup jobs.",
+            "type": "object",
+            "properties": {
+                "clusters": {
+                    "description": "The maximum number of concurrent backup jobs by cluster name, overrides max-backups-per-cluster.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "max-backups": {
+                    "description": "The maximum number of concurrent backup jobs of the service.",
+                    "type": "integer",
+                    "example": 4
+                },
+                "max-backups-per-cluster": {
+                    "description": "The default maximum number of concurrent backup jobs per cluster.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "max-backups-per-storage": {
+                    "description": "The default maximum number of concurrent backup jobs per storage.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "storage": {
+                    "description": "The maximum number of concurrent backup jobs by storage name, overrides max-backups-per-storage.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "dto.Config": {
             "description": "Config represents the service configuration file.",
             "type": "object",
@@ -1909,6 +3590,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.RunningJob"
                         }
                     ]
+                },
+                "retry": {
+                    "description": "Retry represents the state of the full backup retries. Nil if the last\nfull backup is not being retried.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RetryStatus"
+                        }
+                    ]
                 }
             }
         },
@@ -1972,6 +3661,27 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.GarbageCollector": {
+            "description": "GarbageCollector configures the removal of incomplete backups.",
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "description": "Incomplete backups are not removed periodically if disabled.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "interval": {
+                    "description": "The interval between the runs, the first run is on startup (default: 1h).",
+                    "type": "string",
+                    "example": "1h"
+                },
+                "min-age": {
+                    "description": "Only the incomplete backups older than min-age are removed (default: 1d).",
+                    "type": "string",
+                    "example": "1d"
+                }
+            }
+        },
         "dto.GcpStorage": {
             "type": "object",
             "required": [
@@ -2034,15 +3744,40 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.IncompleteBackup": {
+            "description": "IncompleteBackup is a backup folder without metadata.",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "The time the backup was started.",
+                    "type": "string",
+                    "example": "2024-02-14T13:00:00Z"
+                },
+                "path": {
+                    "description": "The path to the backup folder.",
+                    "type": "string",
+                    "example": "daily/backup/1707915600000"
+                },
+                "routine": {
+                    "description": "The name of the backup routine.",
+                    "type": "string",
+                    "example": "daily"
+                }
+            }
+        },
         "dto.JobStatus": {
             "type": "string",
             "enum": [
+                "Queued",
                 "Running",
+                "Skipped",
                 "Done",
                 "Failed"
             ],
             "x-enum-varnames": [
+                "JobStatusQueued",
                 "JobStatusRunning",
+                "JobStatusSkipped",
                 "JobStatusDone",
                 "JobStatusFailed"
             ]
@@ -2101,6 +3836,18 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.NamespaceOrder": {
+            "description": "NamespaceOrder is the order in which the namespaces of a routine are backed up.",
+            "type": "string",
+            "enum": [
+                "config",
+                "size"
+            ],
+            "x-enum-varnames": [
+                "NamespaceOrderConfig",
+                "NamespaceOrderSize"
+            ]
+        },
         "dto.RateLimiterConfig": {
             "description": "RateLimiterConfig is the HTTP server rate limiter configuration.",
             "type": "object",
@@ -2140,6 +3887,46 @@ const docTemplate = `{
                 "RemoveIncremental"
             ]
         },
+        "dto.ReplicaStatus": {
+            "description": "ReplicaStatus is the replication status of a backup to a replica storage.",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "The number of copy attempts.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "error": {
+                    "description": "The error of the last failed attempt.",
+                    "type": "string",
+                    "example": "cannot copy backup file"
+                },
+                "file-count": {
+                    "description": "The number of files copied.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "description": "The replication status.",
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "replicated",
+                        "failed"
+                    ]
+                },
+                "storage": {
+                    "description": "The name of the replica storage.",
+                    "type": "string",
+                    "example": "gcp"
+                },
+                "updated": {
+                    "description": "The time the status was updated.",
+                    "type": "string",
+                    "example": "2023-03-20T14:55:00Z"
+                }
+            }
+        },
         "dto.RestoreJobStatus": {
             "description": "RestoreJobStatus represents a restore job status.",
             "type": "object",
@@ -2354,6 +4141,11 @@ const docTemplate = `{
                 "unique": {
                     "description": "Existing records take precedence. With this option, only records that do not exist in\nthe namespace are restored, regardless of generation numbers. If a record exists in\nthe namespace, the record from the backup is ignored.",
                     "type": "boolean"
+                },
+                "verify-checksums": {
+                    "description": "Verify the checksums of the backup metadata and files before restoring them.\nBackups without checksums cannot be restored with this option.",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -2432,6 +4224,37 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.RetentionPolicy": {
+            "description": "RetentionPolicy defines which full backups (and the incremental backups depending on them) are kept.",
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "The number of days to keep the latest full backup of the day for.",
+                    "type": "integer",
+                    "example": 7
+                },
+                "keep-last": {
+                    "description": "The number of latest full backups to keep.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "max-age": {
+                    "description": "Full backups younger than max-age are kept, e.g. 12h, 30d, 4w.",
+                    "type": "string",
+                    "example": "30d"
+                },
+                "monthly": {
+                    "description": "The number of months to keep the latest full backup of the month for.",
+                    "type": "integer",
+                    "example": 12
+                },
+                "weekly": {
+                    "description": "The number of weeks to keep the latest full backup of the week for.",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
         "dto.RetryPolicy": {
             "description": "RetryPolicy defines the configuration for retry attempts in case of failures.",
             "type": "object",
@@ -2450,6 +4273,99 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.RetryStatus": {
+            "description": "RetryStatus represents the state of the retries of a failed full backup.",
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "Attempt is the number of the current retry attempt, starting from 1.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "next-retry-time": {
+                    "description": "NextRetryTime is the time of the pending retry.\nNil if the retry attempt is running.",
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                }
+            }
+        },
+        "dto.RoutineSchedule": {
+            "description": "RoutineSchedule represents the schedule and the last runs of a backup routine.",
+            "type": "object",
+            "properties": {
+                "catch-up-pending": {
+                    "description": "A catch-up full backup is scheduled on startup if the last full backup is older than the schedule interval.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "disabled": {
+                    "description": "Disabled routines are not scheduled.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed-namespaces": {
+                    "description": "The namespaces which incremental backup failed in the last run.\nThey are backed up from their last successful backup in the next run.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "source-ns1"
+                    ]
+                },
+                "last-error": {
+                    "description": "The error message of the last failed backup run.",
+                    "type": "string",
+                    "example": "failed to connect to cluster"
+                },
+                "last-error-time": {
+                    "description": "Last time a backup run failed.",
+                    "type": "string",
+                    "example": "2023-12-15T11:00:00Z"
+                },
+                "last-full-run": {
+                    "description": "Last time the full backup was performed.",
+                    "type": "string",
+                    "example": "2023-12-14T10:08:54Z"
+                },
+                "last-incremental-run": {
+                    "description": "Last time the incremental backup was performed.",
+                    "type": "string",
+                    "example": "2023-12-15T12:00:00Z"
+                },
+                "last-incremental-status": {
+                    "description": "The status of the last incremental backup run.",
+                    "type": "string",
+                    "enum": [
+                        "succeeded",
+                        "partially-failed",
+                        "failed"
+                    ]
+                },
+                "next-full-backups": {
+                    "description": "The next fire times of the full backup trigger.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next-incremental-backups": {
+                    "description": "The next fire times of the incremental backup trigger.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "running": {
+                    "description": "The currently running backups.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.CurrentBackups"
+                        }
+                    ]
+                }
+            }
+        },
         "dto.RunningJob": {
             "description": "RunningJob tracks progress of currently running job.",
             "type": "object",
@@ -2629,6 +4545,61 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.StorageCheck": {
+            "description": "StorageCheck is the result of checking that the service can write, list, read and delete objects in a storage.",
+            "type": "object",
+            "properties": {
+                "failed-operation": {
+                    "description": "The first failed operation.",
+                    "type": "string",
+                    "enum": [
+                        "write",
+                        "list",
+                        "read",
+                        "delete"
+                    ]
+                },
+                "operations": {
+                    "description": "The results of the performed operations, in order.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StorageOperationResult"
+                    }
+                },
+                "success": {
+                    "description": "All the operations succeeded.",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.StorageOperationResult": {
+            "description": "StorageOperationResult is the result of an operation of the storage check.",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "The provider error of a failed operation.",
+                    "type": "string",
+                    "example": "AccessDenied: Access Denied"
+                },
+                "latency": {
+                    "description": "The duration of the operation in milliseconds.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 35
+                },
+                "operation": {
+                    "description": "The checked operation.",
+                    "type": "string",
+                    "enum": [
+                        "write",
+                        "list",
+                        "read",
+                        "delete"
+                    ]
+                }
+            }
+        },
         "dto.TLS": {
             "description": "TLS represents the Aerospike cluster TLS configuration options.",
             "type": "object",
@@ -2674,6 +4645,90 @@ const docTemplate = `{
                     "example": "TLSv1.2"
                 }
             }
+        },
+        "dto.TieringPolicy": {
+            "description": "TieringPolicy defines when the backups of a routine are moved to a cheaper storage.",
+            "type": "object",
+            "required": [
+                "after",
+                "move-to"
+            ],
+            "properties": {
+                "after": {
+                    "description": "The age of the backups to move, e.g. 12h, 7d, 4w.",
+                    "type": "string",
+                    "example": "7d"
+                },
+                "move-to": {
+                    "description": "The name of the storage to move the backups to.",
+                    "type": "string",
+                    "example": "archive"
+                }
+            }
+        },
+        "dto.VerificationResult": {
+            "description": "VerificationResult is the result of reading back the backup of a namespace.",
+            "type": "object",
+            "properties": {
+                "byte-count": {
+                    "description": "The number of bytes read from the storage.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2000
+                },
+                "created": {
+                    "description": "The creation time of the verified backup.",
+                    "type": "string",
+                    "example": "2023-03-20T14:50:00Z"
+                },
+                "error": {
+                    "description": "The reason of a failed verification.",
+                    "type": "string",
+                    "example": "record count mismatch: expected 100, read 90"
+                },
+                "expected-byte-count": {
+                    "description": "The number of bytes in the backup metadata.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2000
+                },
+                "expected-record-count": {
+                    "description": "The number of records in the backup metadata.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
+                },
+                "file-count": {
+                    "description": "The number of backup files read.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
+                },
+                "namespace": {
+                    "description": "The namespace of the verified backup.",
+                    "type": "string",
+                    "example": "testNamespace"
+                },
+                "record-count": {
+                    "description": "The number of records read from the backup files.",
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
+                },
+                "status": {
+                    "description": "The verification outcome.",
+                    "type": "string",
+                    "enum": [
+                        "passed",
+                        "failed"
+                    ]
+                },
+                "verified": {
+                    "description": "The time the verification finished.",
+                    "type": "string",
+                    "example": "2023-03-27T03:00:00Z"
+                }
+            }
         }
     },
     "externalDocs": {
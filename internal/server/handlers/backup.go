@@ -2,6 +2,10 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -10,6 +14,7 @@ import (
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/service"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
 	"github.com/gorilla/mux"
 	"github.com/reugn/go-quartz/quartz"
 )
@@ -21,6 +26,14 @@ import (
 // @Produce  json
 // @Param    from query int false "Lower bound timestamp filter" format(int64)
 // @Param    to query int false "Upper bound timestamp filter" format(int64)
+// @Param    namespace query string false "Namespace filter"
+// @Param    type query string false "Backup type filter" Enums(full, incremental)
+// @Param    ad-hoc query bool false "Ad-hoc backup filter"
+// @Param    cluster query string false "Source cluster label or name filter"
+// @Param    set query string false "Filter of the backups including the set"
+// @Param    bin query string false "Filter of the backups including the bin"
+// @Param    compression query string false "Compression mode filter"
+// @Param    encryption query string false "Encryption mode filter"
 // @Router   /v1/backups/full [get]
 // @Success  200 {object} map[string][]dto.BackupDetails "Full backups by routine"
 // @Failure  400 {string} string
@@ -37,6 +50,14 @@ func (s *Service) GetAllFullBackups(w http.ResponseWriter, r *http.Request) {
 // @Param    name path string true "Backup routine name"
 // @Param    from query int false "Lower bound timestamp filter" format(int64)
 // @Param    to query int false "Upper bound timestamp filter" format(int64)
+// @Param    namespace query string false "Namespace filter"
+// @Param    type query string false "Backup type filter" Enums(full, incremental)
+// @Param    ad-hoc query bool false "Ad-hoc backup filter"
+// @Param    cluster query string false "Source cluster label or name filter"
+// @Param    set query string false "Filter of the backups including the set"
+// @Param    bin query string false "Filter of the backups including the bin"
+// @Param    compression query string false "Compression mode filter"
+// @Param    encryption query string false "Encryption mode filter"
 // @Router   /v1/backups/full/{name} [get]
 // @Success  200 {object} []dto.BackupDetails "Full backups for routine"
 // @Failure  400 {string} string
@@ -52,6 +73,14 @@ func (s *Service) GetFullBackupsForRoutine(w http.ResponseWriter, r *http.Reques
 // @Produce  json
 // @Param    from query int false "Lower bound timestamp filter" format(int64)
 // @Param    to query int false "Upper bound timestamp filter" format(int64)
+// @Param    namespace query string false "Namespace filter"
+// @Param    type query string false "Backup type filter" Enums(full, incremental)
+// @Param    ad-hoc query bool false "Ad-hoc backup filter"
+// @Param    cluster query string false "Source cluster label or name filter"
+// @Param    set query string false "Filter of the backups including the set"
+// @Param    bin query string false "Filter of the backups including the bin"
+// @Param    compression query string false "Compression mode filter"
+// @Param    encryption query string false "Encryption mode filter"
 // @Router   /v1/backups/incremental [get]
 // @Success  200 {object} map[string][]dto.BackupDetails "Incremental backups by routine"
 // @Failure  400 {string} string
@@ -68,6 +97,14 @@ func (s *Service) GetAllIncrementalBackups(w http.ResponseWriter, r *http.Reques
 // @Param    name path string true "Backup routine name"
 // @Param    from query int false "Lower bound timestamp filter" format(int64)
 // @Param    to query int false "Upper bound timestamp filter" format(int64)
+// @Param    namespace query string false "Namespace filter"
+// @Param    type query string false "Backup type filter" Enums(full, incremental)
+// @Param    ad-hoc query bool false "Ad-hoc backup filter"
+// @Param    cluster query string false "Source cluster label or name filter"
+// @Param    set query string false "Filter of the backups including the set"
+// @Param    bin query string false "Filter of the backups including the bin"
+// @Param    compression query string false "Compression mode filter"
+// @Param    encryption query string false "Encryption mode filter"
 // @Router   /v1/backups/incremental/{name} [get]
 // @Success  200 {object} []dto.BackupDetails "Incremental backups for routine"
 // @Failure  400 {string} string
@@ -92,7 +129,16 @@ func (s *Service) readAllBackups(w http.ResponseWriter, r *http.Request, isFullB
 		http.Error(w, "failed parse time limits: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	backups, err := readBackupsLogic(r.Context(), s.backupBackends, timeBounds.ToModel(), isFullBackup)
+	filter, err := dto.NewBackupFilterFromQuery(r.URL.Query())
+	if err != nil {
+		hLogger.Error("failed parse backup filter",
+			slog.Any("error", err),
+		)
+		http.Error(w, "failed parse backup filter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	backups, err := readBackupsLogic(r.Context(), s.backupBackends, timeBounds.ToModel(), filter.ToModel(),
+		isFullBackup)
 	if err != nil {
 		hLogger.Error("failed to retrieve backup list",
 			slog.Any("timeBounds", timeBounds),
@@ -140,6 +186,14 @@ func (s *Service) readBackupsForRoutine(w http.ResponseWriter, r *http.Request,
 		http.Error(w, "failed parse time limits: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	filter, err := dto.NewBackupFilterFromQuery(r.URL.Query())
+	if err != nil {
+		hLogger.Error("failed parse backup filter",
+			slog.Any("error", err),
+		)
+		http.Error(w, "failed parse backup filter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	routine := mux.Vars(r)["name"]
 	if routine == "" {
@@ -168,6 +222,7 @@ func (s *Service) readBackupsForRoutine(w http.ResponseWriter, r *http.Request,
 		http.Error(w, "failed to retrieve backup list: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	backups = filter.ToModel().Filter(backups)
 	backupDetails := dto.ConvertModelsToDTO(backups, dto.NewBackupDetailsFromModel)
 	response, err := dto.Serialize(backupDetails, dto.JSON)
 	if err != nil {
@@ -192,6 +247,7 @@ func (s *Service) readBackupsForRoutine(w http.ResponseWriter, r *http.Request,
 func readBackupsLogic(ctx context.Context,
 	backends service.BackendsHolder,
 	timeBounds *model.TimeBounds,
+	filter *model.BackupFilter,
 	isFullBackup bool,
 ) (map[string][]model.BackupDetails, error) {
 	result := make(map[string][]model.BackupDetails)
@@ -202,7 +258,7 @@ func readBackupsLogic(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
-		result[routine] = list
+		result[routine] = filter.Filter(list)
 	}
 	return result, nil
 }
@@ -216,14 +272,127 @@ func backupsReadFunction(
 	return backend.IncrementalBackupList
 }
 
+// DeleteFullBackup
+// @Summary  Delete a full backup.
+// @ID       DeleteFullBackup
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Param    timestamp path int true "Backup timestamp" format(int64)
+// @Param    cascade query bool false "Delete dependent incremental backups"
+// @Router   /v1/backups/full/{name}/{timestamp} [delete]
+// @Success  204
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  409 {string} string
+// @Failure  500 {string} string
+func (s *Service) DeleteFullBackup(w http.ResponseWriter, r *http.Request) {
+	s.deleteBackup(w, r, true)
+}
+
+// DeleteIncrementalBackup
+// @Summary  Delete an incremental backup.
+// @ID       DeleteIncrementalBackup
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Param    timestamp path int true "Backup timestamp" format(int64)
+// @Router   /v1/backups/incremental/{name}/{timestamp} [delete]
+// @Success  204
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  409 {string} string
+// @Failure  500 {string} string
+func (s *Service) DeleteIncrementalBackup(w http.ResponseWriter, r *http.Request) {
+	s.deleteBackup(w, r, false)
+}
+
+func (s *Service) deleteBackup(w http.ResponseWriter, r *http.Request, isFullBackup bool) {
+	hLogger := s.logger.With(slog.String("handler", "deleteBackup"))
+
+	routine := mux.Vars(r)["name"]
+	if routine == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, "routine name required", http.StatusBadRequest)
+		return
+	}
+
+	timestampStr := mux.Vars(r)["timestamp"]
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		hLogger.Error("failed to parse timestamp",
+			slog.String("timestamp", timestampStr),
+			slog.Any("error", err))
+		http.Error(w, "Timestamp incorrect", http.StatusBadRequest)
+		return
+	}
+
+	var cascade bool
+	if cascadeParameter := r.URL.Query().Get("cascade"); cascadeParameter != "" {
+		cascade, err = strconv.ParseBool(cascadeParameter)
+		if err != nil {
+			hLogger.Error("failed to parse cascade parameter",
+				slog.String("cascade", cascadeParameter),
+				slog.Any("error", err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	backend, found := s.backupBackends.Get(routine)
+	if !found {
+		hLogger.Error("routine name not found",
+			slog.String("routine", routine),
+		)
+		http.Error(w, "routine name not found: "+routine, http.StatusNotFound)
+		return
+	}
+
+	if isFullBackup {
+		err = backend.DeleteFullBackup(r.Context(), time.UnixMilli(timestamp), cascade)
+	} else {
+		err = backend.DeleteIncrementalBackup(r.Context(), time.UnixMilli(timestamp))
+	}
+	if err != nil {
+		hLogger.Error("failed to delete backup",
+			slog.String("routine", routine),
+			slog.Int64("timestamp", timestamp),
+			slog.Bool("isFullBackup", isFullBackup),
+			slog.Any("error", err),
+		)
+		switch {
+		case errors.Is(err, service.ErrBackupNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrFullBackupInProgress), errors.Is(err, service.ErrDependentBackups),
+			errors.Is(err, service.ErrHistoricalBackup):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	hLogger.Info("backup deleted",
+		slog.String("routine", routine),
+		slog.Int64("timestamp", timestamp),
+		slog.Bool("isFullBackup", isFullBackup),
+		slog.Bool("cascade", cascade),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ScheduleFullBackup
-// @Summary  Schedule a full backup once per routine name.
+// @Summary  Schedule a backup once per routine name.
+// @Description Schedules a one-off full (default) or incremental backup.
+// @Description The optional request body overrides the routine parameters for this incremental run only.
+// @Description Full backups are run with the routine parameters, as they are used to restore the routine.
 // @ID       ScheduleFullBackup
 // @Tags     Backup
+// @Accept   json
 // @Param    name path string true "Backup routine name"
 // @Param    delay query int false "Delay interval in milliseconds"
+// @Param    type query string false "Backup type" Enums(full, incremental)
+// @Param    request body dto.BackupOverrides false "Backup parameters overrides"
 // @Router   /v1/backups/schedule/{name} [post]
-// @Success  202
+// @Success  202 {int64} int64 "Backup job id"
 // @Failure  400 {string} string
 // @Failure  404 {string} string
 // @Failure  500 {string} string
@@ -257,8 +426,34 @@ func (s *Service) ScheduleFullBackup(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "nonpositive delay query parameter", http.StatusBadRequest)
 		return
 	}
-	fullBackupJobDetail := service.NewAdHocFullBackupJobForRoutine(routineName)
-	if fullBackupJobDetail == nil {
+	overrides, err := parseBackupOverrides(r.Body)
+	if err != nil {
+		hLogger.Error("failed to parse request body",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var jobDetail *quartz.JobDetail
+	var jobID model.BackupJobID
+	switch backupType := r.URL.Query().Get("type"); backupType {
+	case "", "full":
+		if overrides != nil {
+			hLogger.Error("overrides are not supported for full backups")
+			http.Error(w, "overrides are supported for incremental backups only", http.StatusBadRequest)
+			return
+		}
+		jobDetail, jobID = service.NewAdHocFullBackupJobForRoutine(routineName)
+	case "incremental":
+		jobDetail, jobID = service.NewAdHocIncrementalBackupJobForRoutine(routineName, overrides)
+	default:
+		hLogger.Error("invalid backup type",
+			slog.String("type", backupType),
+		)
+		http.Error(w, "invalid backup type "+backupType, http.StatusBadRequest)
+		return
+	}
+	if jobDetail == nil {
 		hLogger.Error("unknown routine name",
 			slog.String("name", routineName),
 		)
@@ -267,15 +462,137 @@ func (s *Service) ScheduleFullBackup(w http.ResponseWriter, r *http.Request) {
 	}
 	trigger := quartz.NewRunOnceTrigger(time.Duration(delayMillis) * time.Millisecond)
 	// schedule using the quartz scheduler
-	if err := s.scheduler.ScheduleJob(fullBackupJobDetail, trigger); err != nil {
+	if err := s.scheduler.ScheduleJob(jobDetail, trigger); err != nil {
 		hLogger.Error("failed to schedule job",
 			slog.Any("trigger", trigger),
 			slog.Any("error", err),
 		)
+		service.SetBackupJobFailed(jobID, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
+	_, _ = fmt.Fprint(w, jobID)
+}
+
+// GetBackupJobStatus
+// @Summary     Retrieve status of an ad-hoc backup job.
+// @Description The status of a finished job is kept for 24 hours.
+// @ID          getBackupJobStatus
+// @Tags        Backup
+// @Produce     json
+// @Param       id path int true "Job ID to retrieve the status" format(int64)
+// @Router      /v1/backups/jobs/{id} [get]
+// @Success     200 {object} dto.BackupJobStatus "Backup job status details"
+// @Failure     400 {string} string
+// @Failure     404 {string} string
+func (s *Service) GetBackupJobStatus(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "GetBackupJobStatus"))
+
+	jobIDParam := mux.Vars(r)["id"]
+	jobID, err := strconv.Atoi(jobIDParam)
+	if err != nil {
+		hLogger.Error("failed to parse job id",
+			slog.String("jobID", jobIDParam),
+			slog.Any("error", err),
+		)
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+
+	status, err := service.GetBackupJobStatus(model.BackupJobID(jobID))
+	if err != nil {
+		hLogger.Error("failed to get backup job status",
+			slog.Int("jobID", jobID),
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	jsonResponse, err := dto.Serialize(dto.NewBackupJobStatusFromModel(status), dto.JSON)
+	if err != nil {
+		hLogger.Error("failed to marshal backup job status",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		hLogger.Error("failed to write response",
+			slog.String("response", string(jsonResponse)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// CancelBackup
+// @Summary  Cancel running backups of the routine.
+// @Description Cancels running full and incremental backups of the routine and waits for them to stop.
+// @Description Partial output of the cancelled backups is deleted.
+// @ID       CancelBackup
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Router   /v1/backups/cancel/{name} [post]
+// @Success  204
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  500 {string} string
+func (s *Service) CancelBackup(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "CancelBackup"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, "routine name required", http.StatusBadRequest)
+		return
+	}
+
+	if _, found := s.config.BackupRoutines[routineName]; !found {
+		hLogger.Error("unknown routine name",
+			slog.String("name", routineName),
+		)
+		http.Error(w, "unknown routine name "+routineName, http.StatusNotFound)
+		return
+	}
+
+	cancelled, err := s.backupCanceler.Cancel(r.Context(), routineName)
+	if err != nil {
+		hLogger.Error("failed to cancel backup",
+			slog.String("name", routineName),
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !cancelled {
+		http.Error(w, "no backup is running for routine "+routineName, http.StatusNotFound)
+		return
+	}
+
+	hLogger.Info("backup cancelled",
+		slog.String("name", routineName),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseBackupOverrides parses the optional backup overrides from the request body.
+func parseBackupOverrides(body io.Reader) (*model.BackupOverrides, error) {
+	var overrides *dto.BackupOverrides
+	if err := json.NewDecoder(body).Decode(&overrides); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := overrides.Validate(); err != nil {
+		return nil, err
+	}
+
+	return overrides.ToModel(), nil
 }
 
 // GetCurrentBackupInfo
@@ -299,7 +616,10 @@ func (s *Service) GetCurrentBackupInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the handlers are replaced when the configuration is applied
+	s.Lock()
 	handler, found := s.handlerHolder[routineName]
+	s.Unlock()
 	if !found {
 		hLogger.Error("unknown routine name",
 			slog.String("name", routineName),
@@ -328,3 +648,428 @@ func (s *Service) GetCurrentBackupInfo(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 }
+
+// RemoveIncompleteBackups
+// @Summary  Remove incomplete backups.
+// @Description Removes the backup folders without metadata, left by failed backups or service crashes,
+// @Description that are older than the garbage collector min-age. The folders of running backups are skipped.
+// @Description With dry-run, the incomplete backups are only reported.
+// @ID       RemoveIncompleteBackups
+// @Tags     Backup
+// @Produce  json
+// @Param    dry-run query bool false "Report the incomplete backups without removing them"
+// @Router   /v1/backups/incomplete [post]
+// @Success  200 {array} dto.IncompleteBackup "The removed (or found, with dry-run) incomplete backups"
+// @Failure  400 {string} string
+// @Failure  500 {string} string
+func (s *Service) RemoveIncompleteBackups(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "RemoveIncompleteBackups"))
+
+	var dryRun bool
+	if dryRunParameter := r.URL.Query().Get("dry-run"); dryRunParameter != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParameter)
+		if err != nil {
+			hLogger.Error("failed to parse dry-run parameter",
+				slog.String("dry-run", dryRunParameter),
+				slog.Any("error", err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	backups, err := s.garbageCollector.Collect(r.Context(), dryRun)
+	if err != nil {
+		hLogger.Error("failed to remove incomplete backups",
+			slog.Bool("dryRun", dryRun),
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse, err := dto.Serialize(dto.NewIncompleteBackupsFromModel(backups), dto.JSON)
+	if err != nil {
+		hLogger.Error("failed to marshal incomplete backups",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		hLogger.Error("failed to write response",
+			slog.String("response", string(jsonResponse)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// RebuildBackupCatalog
+// @Summary  Rebuild the backup catalog of a routine.
+// @Description The backups are listed from the catalog of the routine, which is updated on every backup write and
+// @Description delete. Rebuilds the catalog from the metadata files of the backups, if it is out of date.
+// @ID       RebuildBackupCatalog
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Router   /v1/backups/catalog/{name} [post]
+// @Success  204
+// @Failure  404 {string} string
+// @Failure  500 {string} string
+func (s *Service) RebuildBackupCatalog(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "RebuildBackupCatalog"))
+
+	routine := mux.Vars(r)["name"]
+	backend, found := s.backupBackends.Get(routine)
+	if !found {
+		hLogger.Error("routine name not found",
+			slog.String("routine", routine),
+		)
+		http.Error(w, "routine name not found: "+routine, http.StatusNotFound)
+		return
+	}
+
+	full, incremental, err := backend.RebuildCatalog(r.Context())
+	if err != nil {
+		hLogger.Error("failed to rebuild backup catalog",
+			slog.String("routine", routine),
+			slog.Any("error", err),
+		)
+		http.Error(w, "failed to rebuild backup catalog: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	hLogger.Info("rebuilt backup catalog",
+		slog.String("routine", routine),
+		slog.Int("full", full),
+		slog.Int("incremental", incremental),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyLatestBackup
+// @Summary  Verify the latest full backup.
+// @Description Reads the latest full backup of the routine back, decrypting and decompressing it with the routine
+// @Description policy, and compares the number of records and bytes with the backup metadata.
+// @Description The verification runs in the background, its results are stored next to the backup metadata.
+// @ID       VerifyLatestBackup
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Router   /v1/backups/verify/{name} [post]
+// @Success  202 {int64} int64 "The timestamp of the verified backup"
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  409 {string} string
+// @Failure  500 {string} string
+func (s *Service) VerifyLatestBackup(w http.ResponseWriter, r *http.Request) {
+	s.verifyBackup(w, r)
+}
+
+// VerifyBackup
+// @Summary  Verify a backup.
+// @Description Reads the full or incremental backup back, decrypting and decompressing it with the routine policy,
+// @Description and compares the number of records and bytes with the backup metadata.
+// @Description The verification runs in the background, its results are stored next to the backup metadata.
+// @ID       VerifyBackup
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Param    timestamp path int true "Backup timestamp" format(int64)
+// @Router   /v1/backups/verify/{name}/{timestamp} [post]
+// @Success  202 {int64} int64 "The timestamp of the verified backup"
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  409 {string} string
+// @Failure  500 {string} string
+func (s *Service) VerifyBackup(w http.ResponseWriter, r *http.Request) {
+	s.verifyBackup(w, r)
+}
+
+func (s *Service) verifyBackup(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "verifyBackup"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, "routine name required", http.StatusBadRequest)
+		return
+	}
+
+	var created *time.Time
+	if timestampStr := mux.Vars(r)["timestamp"]; timestampStr != "" {
+		timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+		if err != nil {
+			hLogger.Error("failed to parse timestamp",
+				slog.String("timestamp", timestampStr),
+				slog.Any("error", err))
+			http.Error(w, "Timestamp incorrect", http.StatusBadRequest)
+			return
+		}
+		created = util.Ptr(time.UnixMilli(timestamp))
+	}
+
+	// the handlers are replaced when the configuration is applied
+	s.Lock()
+	handler, found := s.handlerHolder[routineName]
+	s.Unlock()
+	if !found {
+		hLogger.Error("unknown routine name",
+			slog.String("name", routineName),
+		)
+		http.Error(w, "unknown routine name "+routineName, http.StatusNotFound)
+		return
+	}
+
+	jobDetail, backupTime, err := handler.NewAdHocVerifyJob(r.Context(), created)
+	if err != nil {
+		hLogger.Error("failed to create verification job",
+			slog.String("name", routineName),
+			slog.Any("error", err),
+		)
+		switch {
+		case errors.Is(err, service.ErrBackupNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrVerificationInProgress):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := s.scheduler.ScheduleJob(jobDetail, quartz.NewRunOnceTrigger(0)); err != nil {
+		hLogger.Error("failed to schedule verification job",
+			slog.String("name", routineName),
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = fmt.Fprint(w, backupTime.UnixMilli())
+}
+
+// GetBackupVerification
+// @Summary  Get the verification results of a backup.
+// @ID       GetBackupVerification
+// @Tags     Backup
+// @Produce  json
+// @Param    name path string true "Backup routine name"
+// @Param    timestamp path int true "Backup timestamp" format(int64)
+// @Router   /v1/backups/verify/{name}/{timestamp} [get]
+// @Success  200 {object} dto.BackupVerification "The verification results"
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  500 {string} string
+func (s *Service) GetBackupVerification(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "GetBackupVerification"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, "routine name required", http.StatusBadRequest)
+		return
+	}
+
+	timestampStr := mux.Vars(r)["timestamp"]
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		hLogger.Error("failed to parse timestamp",
+			slog.String("timestamp", timestampStr),
+			slog.Any("error", err))
+		http.Error(w, "Timestamp incorrect", http.StatusBadRequest)
+		return
+	}
+
+	s.Lock()
+	handler, found := s.handlerHolder[routineName]
+	s.Unlock()
+	if !found {
+		hLogger.Error("unknown routine name",
+			slog.String("name", routineName),
+		)
+		http.Error(w, "unknown routine name "+routineName, http.StatusNotFound)
+		return
+	}
+
+	verification, err := handler.GetBackupVerification(r.Context(), time.UnixMilli(timestamp))
+	if err != nil {
+		hLogger.Error("failed to read verification results",
+			slog.String("name", routineName),
+			slog.Int64("timestamp", timestamp),
+			slog.Any("error", err),
+		)
+		if errors.Is(err, service.ErrBackupNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse, err := dto.Serialize(dto.NewBackupVerificationFromModel(verification), dto.JSON)
+	if err != nil {
+		hLogger.Error("failed to marshal verification results",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		hLogger.Error("failed to write response",
+			slog.String("response", string(jsonResponse)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// GetBackupReplication
+// @Summary  Get the replication status of a backup.
+// @ID       GetBackupReplication
+// @Tags     Backup
+// @Produce  json
+// @Param    name path string true "Backup routine name"
+// @Param    timestamp path int true "Backup timestamp" format(int64)
+// @Router   /v1/backups/replication/{name}/{timestamp} [get]
+// @Success  200 {object} dto.BackupReplication "The replication status"
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  500 {string} string
+func (s *Service) GetBackupReplication(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "GetBackupReplication"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, "routine name required", http.StatusBadRequest)
+		return
+	}
+
+	timestampStr := mux.Vars(r)["timestamp"]
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		hLogger.Error("failed to parse timestamp",
+			slog.String("timestamp", timestampStr),
+			slog.Any("error", err))
+		http.Error(w, "Timestamp incorrect", http.StatusBadRequest)
+		return
+	}
+
+	s.Lock()
+	handler, found := s.handlerHolder[routineName]
+	s.Unlock()
+	if !found {
+		hLogger.Error("unknown routine name",
+			slog.String("name", routineName),
+		)
+		http.Error(w, "unknown routine name "+routineName, http.StatusNotFound)
+		return
+	}
+
+	replication, err := handler.GetBackupReplication(r.Context(), time.UnixMilli(timestamp))
+	if err != nil {
+		hLogger.Error("failed to read replication status",
+			slog.String("name", routineName),
+			slog.Int64("timestamp", timestamp),
+			slog.Any("error", err),
+		)
+		if errors.Is(err, service.ErrBackupNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse, err := dto.Serialize(dto.NewBackupReplicationFromModel(replication), dto.JSON)
+	if err != nil {
+		hLogger.Error("failed to marshal replication status",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		hLogger.Error("failed to write response",
+			slog.String("response", string(jsonResponse)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// MigrateHistoricalBackups
+// @Summary  Migrate the backups of the historical storages of a routine.
+// @Description When the storage of a routine is changed, the previous storage is kept as a read-only historical
+// @Description storage, and its backups are still listed and restorable. The migration runs after the storage
+// @Description switch, while the new backups are already written to the routine storage: it copies the backups of
+// @Description the historical storages to the routine storage in the background, and removes the migrated
+// @Description historical storages from the routine configuration.
+// @ID       MigrateHistoricalBackups
+// @Tags     Backup
+// @Param    name path string true "Backup routine name"
+// @Router   /v1/backups/migrate/{name} [post]
+// @Success  202
+// @Failure  400 {string} string
+// @Failure  404 {string} string
+// @Failure  409 {string} string
+func (s *Service) MigrateHistoricalBackups(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "MigrateHistoricalBackups"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, "routine name required", http.StatusBadRequest)
+		return
+	}
+
+	s.Lock()
+	handler, found := s.handlerHolder[routineName]
+	s.Unlock()
+	if !found {
+		hLogger.Error("unknown routine name",
+			slog.String("name", routineName),
+		)
+		http.Error(w, "unknown routine name "+routineName, http.StatusNotFound)
+		return
+	}
+
+	err := handler.MigrateHistoricalBackups(r.Context(), func(migrated []model.Storage) {
+		err := s.changeConfig(context.Background(), func(config *model.Config) error {
+			return config.RemoveHistoricalStorages(routineName, migrated)
+		})
+		if err != nil {
+			hLogger.Error("failed to remove migrated historical storages",
+				slog.String("name", routineName),
+				slog.Any("error", err),
+			)
+			return
+		}
+		hLogger.Info("migrated historical backups",
+			slog.String("name", routineName),
+			slog.Int("storages", len(migrated)),
+		)
+	})
+	if err != nil {
+		hLogger.Error("failed to start backup migration",
+			slog.String("name", routineName),
+			slog.Any("error", err),
+		)
+		switch {
+		case errors.Is(err, service.ErrNoHistoricalStorages):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrMigrationInProgress):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
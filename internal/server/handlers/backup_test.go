@@ -3,10 +3,19 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service"
 	"github.com/gorilla/mux"
 	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 //nolint:dupl // No duplication here, just tests.
@@ -234,6 +243,73 @@ func TestService_ScheduleFullBackup(t *testing.T) {
 	}
 }
 
+func TestService_ScheduleBackupWithOverrides(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/backups/schedule/{name}",
+		h.ScheduleFullBackup,
+	).Methods(http.MethodPost)
+
+	testCases := []struct {
+		statusCode  int
+		backupType  string
+		requestBody string
+	}{
+		{http.StatusNotFound, "incremental", ""},
+		{http.StatusNotFound, "incremental", `{"namespaces": ["source-ns1"], "from": 1739538000000}`},
+		{http.StatusBadRequest, "full", `{"namespaces": ["source-ns1"]}`},
+		{http.StatusBadRequest, "", `{"setList": ["set1"]}`},
+		{http.StatusBadRequest, "differential", ""},
+		{http.StatusBadRequest, "incremental", `{"from": -1}`},
+		{http.StatusBadRequest, "incremental", `{"namespaces": "source-ns1"}`},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Method(http.MethodPost).
+			URL(fmt.Sprintf("/backups/schedule/%s", testRoutineName)).
+			QueryParams(map[string]string{"type": tt.backupType}).
+			Body(tt.requestBody).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
+
+func TestService_GetBackupJobStatus(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/backups/jobs/{id}",
+		h.GetBackupJobStatus,
+	).Methods(http.MethodGet)
+
+	testCases := []struct {
+		method     string
+		statusCode int
+		id         string
+	}{
+		{http.MethodGet, http.StatusNotFound, "1"},
+		{http.MethodGet, http.StatusBadRequest, "job"},
+		{http.MethodPost, http.StatusMethodNotAllowed, "1"},
+		{http.MethodDelete, http.StatusMethodNotAllowed, "1"},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL(fmt.Sprintf("/backups/jobs/%s", tt.id)).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
+
 func TestService_GetCurrentBackupInfo(t *testing.T) {
 	t.Parallel()
 	h := newServiceMock()
@@ -273,3 +349,329 @@ func TestService_GetCurrentBackupInfo(t *testing.T) {
 			End()
 	}
 }
+
+func TestService_DeleteBackup(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/backups/full/{name}/{timestamp}",
+		h.DeleteFullBackup,
+	).Methods(http.MethodDelete)
+	router.HandleFunc(
+		"/backups/incremental/{name}/{timestamp}",
+		h.DeleteIncrementalBackup,
+	).Methods(http.MethodDelete)
+
+	const timestamp = "1723453567"
+
+	testCases := []struct {
+		method     string
+		statusCode int
+		url        string
+		cascade    string
+	}{
+		{http.MethodDelete, http.StatusNotFound, "/backups/full/unknown/" + timestamp, ""},
+		{http.MethodDelete, http.StatusNotFound, "/backups/incremental/unknown/" + timestamp, ""},
+		{http.MethodDelete, http.StatusBadRequest, "/backups/full/" + testRoutineName + "/abc", ""},
+		{http.MethodDelete, http.StatusBadRequest, "/backups/full/" + testRoutineName + "/" + timestamp, "maybe"},
+		{http.MethodGet, http.StatusMethodNotAllowed, "/backups/full/" + testRoutineName + "/" + timestamp, ""},
+		{http.MethodPost, http.StatusMethodNotAllowed, "/backups/incremental/" + testRoutineName + "/" + timestamp, ""},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL(tt.url).
+			QueryParams(map[string]string{"cascade": tt.cascade}).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
+
+// newLocalBackends returns the backends of the test routine in a temporary
+// local storage, with a full backup created at the given time.
+func newLocalBackends(t *testing.T, created time.Time) service.BackendsHolder {
+	t.Helper()
+	dir := t.TempDir()
+	metadata, err := yaml.Marshal(model.BackupMetadata{Created: created, Namespace: "source-ns1"})
+	require.NoError(t, err)
+	path := filepath.Join(dir, testRoutineName, model.FullBackupDirectory,
+		strconv.FormatInt(created.UnixMilli(), 10), model.DataDirectory, "source-ns1")
+	require.NoError(t, os.MkdirAll(path, 0o744))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "metadata.yaml"), metadata, 0o644))
+
+	backends := service.NewBackupBackends()
+	backends.Init(&model.Config{
+		BackupRoutines: map[string]*model.BackupRoutine{
+			testRoutineName: {
+				BackupPolicy: &model.BackupPolicy{},
+				Storage:      &model.LocalStorage{Path: dir},
+			},
+		},
+	})
+	return backends
+}
+
+func TestService_DeleteFullBackup(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	backends := newLocalBackends(t, time.UnixMilli(1723453567))
+	h.backupBackends = backends
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/backups/full/{name}/{timestamp}",
+		h.DeleteFullBackup,
+	).Methods(http.MethodDelete)
+	url := "/backups/full/" + testRoutineName + "/1723453567"
+
+	backend, _ := backends.Get(testRoutineName)
+	backend.FullBackupInProgress().Store(true)
+	apitest.New().
+		Handler(router).
+		Method(http.MethodDelete).
+		URL(url).
+		Expect(t).
+		Status(http.StatusConflict).
+		End()
+
+	backend.FullBackupInProgress().Store(false)
+	apitest.New().
+		Handler(router).
+		Method(http.MethodDelete).
+		URL(url).
+		Expect(t).
+		Status(http.StatusNoContent).
+		End()
+
+	// already deleted
+	apitest.New().
+		Handler(router).
+		Method(http.MethodDelete).
+		URL(url).
+		Expect(t).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func TestService_CancelBackup(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		method     string
+		statusCode int
+		name       string
+		canceler   backupCancelerMock
+	}{
+		{http.MethodPost, http.StatusNoContent, testRoutineName, backupCancelerMock{running: testRoutineName}},
+		// no backup is running
+		{http.MethodPost, http.StatusNotFound, testRoutineName, backupCancelerMock{}},
+		{http.MethodPost, http.StatusInternalServerError, testRoutineName, backupCancelerMock{err: errTest}},
+		{http.MethodPost, http.StatusNotFound, "unknownRoutine", backupCancelerMock{running: "unknownRoutine"}},
+		{http.MethodPost, http.StatusNotFound, "", backupCancelerMock{}},
+		{http.MethodGet, http.StatusMethodNotAllowed, testRoutineName, backupCancelerMock{}},
+		{http.MethodDelete, http.StatusMethodNotAllowed, testRoutineName, backupCancelerMock{}},
+	}
+
+	for _, tt := range testCases {
+		h := newServiceMock()
+		h.backupCanceler = tt.canceler
+		router := mux.NewRouter()
+		router.HandleFunc(
+			"/backups/cancel/{name}",
+			h.CancelBackup,
+		).Methods(http.MethodPost)
+
+		apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL(fmt.Sprintf("/backups/cancel/%s", tt.name)).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
+
+func TestService_RemoveIncompleteBackups(t *testing.T) {
+	t.Parallel()
+	backups := []model.IncompleteBackup{{
+		Routine: testRoutineName,
+		Path:    testRoutineName + "/backup/1707915600000",
+		Created: time.UnixMilli(1707915600000).UTC(),
+	}}
+	testCases := []struct {
+		method     string
+		query      map[string]string
+		statusCode int
+		collector  garbageCollectorMock
+	}{
+		{http.MethodPost, nil, http.StatusOK, garbageCollectorMock{backups: backups}},
+		{http.MethodPost, map[string]string{"dry-run": "true"}, http.StatusOK, garbageCollectorMock{backups: backups}},
+		{http.MethodPost, map[string]string{"dry-run": "maybe"}, http.StatusBadRequest, garbageCollectorMock{}},
+		{http.MethodPost, nil, http.StatusInternalServerError, garbageCollectorMock{err: errTest}},
+		{http.MethodGet, nil, http.StatusMethodNotAllowed, garbageCollectorMock{}},
+	}
+
+	for _, tt := range testCases {
+		h := newServiceMock()
+		h.garbageCollector = tt.collector
+		router := mux.NewRouter()
+		router.HandleFunc(
+			"/backups/incomplete",
+			h.RemoveIncompleteBackups,
+		).Methods(http.MethodPost)
+
+		test := apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL("/backups/incomplete").
+			QueryParams(tt.query).
+			Expect(t).
+			Status(tt.statusCode)
+		if tt.statusCode == http.StatusOK {
+			test = test.Body(`[{"routine":"` + testRoutineName + `","path":"` + testRoutineName +
+				`/backup/1707915600000","created":"2024-02-14T13:00:00Z"}]`)
+		}
+		test.End()
+	}
+}
+
+func TestService_VerifyBackup(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		method     string
+		url        string
+		statusCode int
+	}{
+		{http.MethodPost, "/backups/verify/unknown", http.StatusNotFound},
+		{http.MethodPost, "/backups/verify/unknown/1707915600000", http.StatusNotFound},
+		{http.MethodPost, "/backups/verify/" + testRoutineName + "/abc", http.StatusBadRequest},
+		{http.MethodGet, "/backups/verify/unknown/1707915600000", http.StatusNotFound},
+		{http.MethodGet, "/backups/verify/" + testRoutineName + "/abc", http.StatusBadRequest},
+		{http.MethodGet, "/backups/verify/" + testRoutineName, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range testCases {
+		h := newServiceMock()
+		router := mux.NewRouter()
+		router.HandleFunc("/backups/verify/{name}", h.VerifyLatestBackup).Methods(http.MethodPost)
+		router.HandleFunc("/backups/verify/{name}/{timestamp}", h.VerifyBackup).Methods(http.MethodPost)
+		router.HandleFunc("/backups/verify/{name}/{timestamp}", h.GetBackupVerification).Methods(http.MethodGet)
+
+		apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL(tt.url).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
+
+func TestService_GetBackupReplication(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		url        string
+		statusCode int
+	}{
+		{"/backups/replication/unknown/1707915600000", http.StatusNotFound},
+		{"/backups/replication/" + testRoutineName + "/abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range testCases {
+		h := newServiceMock()
+		router := mux.NewRouter()
+		router.HandleFunc("/backups/replication/{name}/{timestamp}", h.GetBackupReplication).Methods(http.MethodGet)
+
+		apitest.New().
+			Handler(router).
+			Get(tt.url).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
+
+func TestService_MigrateHistoricalBackups(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc("/backups/migrate/{name}", h.MigrateHistoricalBackups).Methods(http.MethodPost)
+
+	apitest.New().
+		Handler(router).
+		Post("/backups/migrate/unknown").
+		Expect(t).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func TestService_GetFullBackupsForRoutine_Filter(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/backups/full/{name}",
+		h.GetFullBackupsForRoutine,
+	).Methods(http.MethodGet)
+
+	testCases := []struct {
+		name       string
+		query      map[string]string
+		statusCode int
+		count      int
+	}{
+		{"namespace", map[string]string{"namespace": "ns"}, http.StatusOK, 1},
+		{"other namespace", map[string]string{"namespace": "other"}, http.StatusOK, 0},
+		{"invalid type", map[string]string{"type": "differential"}, http.StatusBadRequest, 0},
+		{"invalid ad-hoc", map[string]string{"ad-hoc": "maybe"}, http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := apitest.New().
+				Handler(router).
+				Get("/backups/full/" + testRoutineName).
+				QueryParams(tt.query).
+				Expect(t).
+				Status(tt.statusCode).
+				End()
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var backups []dto.BackupDetails
+			result.JSON(&backups)
+			require.Len(t, backups, tt.count)
+		})
+	}
+}
+
+func TestService_RebuildBackupCatalog(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/backups/catalog/{name}",
+		h.RebuildBackupCatalog,
+	).Methods(http.MethodPost)
+
+	testCases := []struct {
+		name       string
+		statusCode int
+	}{
+		{"unknown", http.StatusNotFound},
+		// the mock backend has no catalog
+		{testRoutineName, http.StatusInternalServerError},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Post("/backups/catalog/" + tt.name).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service"
 	"github.com/gorilla/mux"
 )
 
@@ -50,9 +54,12 @@ func (s *Service) addRoutine(w http.ResponseWriter, r *http.Request) {
 	}
 	r.Body.Close()
 	name := mux.Vars(r)["name"]
-	if name == "" {
-		hLogger.Error("routine name required")
-		http.Error(w, routineNameNotSpecifiedMsg, http.StatusBadRequest)
+	if err := validateRoutineName(name); err != nil {
+		hLogger.Error("invalid routine name",
+			slog.String("name", name),
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	toModel, err := newRoutine.ToModel(s.config)
@@ -116,12 +123,13 @@ func (s *Service) ReadRoutines(w http.ResponseWriter, _ *http.Request) {
 
 // readRoutine reads a specific routine from the configuration given its name.
 // @Summary     Reads a specific routine from the configuration given its name.
+// @Description The response includes the next scheduled backup times of the routine.
 // @ID	        readRoutine
 // @Tags        Configuration
 // @Router      /v1/config/routines/{name} [get]
 // @Param       name path string true "Backup routine name"
 // @Produce     json
-// @Success  	200 {object} dto.BackupRoutine
+// @Success  	200 {object} dto.BackupRoutineWithSchedule
 // @Response    400 {string} string
 // @Failure     404 {string} string "The specified cluster could not be found"
 func (s *Service) readRoutine(w http.ResponseWriter, r *http.Request) {
@@ -138,7 +146,13 @@ func (s *Service) readRoutine(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Routine %s could not be found", routineName), http.StatusNotFound)
 		return
 	}
-	jsonResponse, err := dto.Serialize(dto.NewRoutineFromModel(routine, s.config), dto.JSON)
+	nextFull, nextIncremental := service.NextBackupTimes(routine, time.Now())
+	response := dto.BackupRoutineWithSchedule{
+		BackupRoutine:         *dto.NewRoutineFromModel(routine, s.config),
+		NextFullBackup:        nextFull,
+		NextIncrementalBackup: nextIncremental,
+	}
+	jsonResponse, err := dto.Serialize(response, dto.JSON)
 	if err != nil {
 		hLogger.Error("failed to marshal backup routines",
 			slog.Any("error", err),
@@ -245,3 +259,152 @@ func (s *Service) deleteRoutine(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// PauseRoutine
+// @Summary     Pauses a backup routine.
+// @Description Disables the routine and unschedules its backups.
+// @Description Existing backups of the routine are still available for listing and restore.
+// @ID          pauseRoutine
+// @Tags        Configuration
+// @Router      /v1/config/routines/{name}/pause [post]
+// @Param       name path string true "Backup routine name"
+// @Success     200
+// @Failure     400 {string} string
+// @Failure     404 {string} string
+func (s *Service) PauseRoutine(w http.ResponseWriter, r *http.Request) {
+	s.setRoutineDisabled(w, r, true)
+}
+
+// ResumeRoutine
+// @Summary     Resumes a paused backup routine.
+// @Description Enables the routine and schedules its backups again.
+// @ID          resumeRoutine
+// @Tags        Configuration
+// @Router      /v1/config/routines/{name}/resume [post]
+// @Param       name path string true "Backup routine name"
+// @Success     200
+// @Failure     400 {string} string
+// @Failure     404 {string} string
+func (s *Service) ResumeRoutine(w http.ResponseWriter, r *http.Request) {
+	s.setRoutineDisabled(w, r, false)
+}
+
+func (s *Service) setRoutineDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	hLogger := s.logger.With(slog.String("handler", "setRoutineDisabled"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, routineNameNotSpecifiedMsg, http.StatusBadRequest)
+		return
+	}
+
+	err := s.changeConfig(r.Context(), func(config *model.Config) error {
+		return config.SetRoutineDisabled(routineName, disabled)
+	})
+	if err != nil {
+		hLogger.Error("failed to set routine disabled",
+			slog.String("name", routineName),
+			slog.Bool("disabled", disabled),
+			slog.Any("error", err),
+		)
+		if errors.Is(err, model.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hLogger.Info("routine disabled flag updated",
+		slog.String("name", routineName),
+		slog.Bool("disabled", disabled),
+	)
+	w.WriteHeader(http.StatusOK)
+}
+
+// RenameRoutine
+// @Summary     Renames a backup routine.
+// @Description The backups of the routine keep their paths in the storages, which are derived from the original
+// @Description routine name, and the routine keeps its backup history and state. The routine cannot be renamed
+// @Description while its backups are running, new backups of the routine wait until the rename is applied.
+// @Description The new name follows the same rules as the name of a new routine.
+// @ID          renameRoutine
+// @Tags        Configuration
+// @Router      /v1/config/routines/{name}/rename [post]
+// @Param       name path string true "Backup routine name"
+// @Param       new-name query string true "The new routine name"
+// @Success     200
+// @Failure     400 {string} string
+// @Failure     404 {string} string
+// @Failure     409 {string} string
+func (s *Service) RenameRoutine(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "RenameRoutine"))
+
+	routineName := mux.Vars(r)["name"]
+	if routineName == "" {
+		hLogger.Error("routine name required")
+		http.Error(w, routineNameNotSpecifiedMsg, http.StatusBadRequest)
+		return
+	}
+	newName := r.URL.Query().Get("new-name")
+	if err := validateRoutineName(newName); err != nil {
+		hLogger.Error("invalid new routine name",
+			slog.String("newName", newName),
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the backups of the routine are held from the check until the renamed
+	// routine is scheduled, under the configuration lock
+	var resume func()
+	err := s.changeConfig(r.Context(), func(config *model.Config) error {
+		if handler, found := s.handlerHolder[routineName]; found {
+			var err error
+			if resume, err = handler.HoldBackups(); err != nil {
+				return fmt.Errorf("routine %s: %w", routineName, err)
+			}
+		}
+		return config.RenameRoutine(routineName, newName)
+	})
+	if resume != nil {
+		resume()
+	}
+	if err != nil {
+		hLogger.Error("failed to rename routine",
+			slog.String("name", routineName),
+			slog.String("newName", newName),
+			slog.Any("error", err),
+		)
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, model.ErrAlreadyExists), errors.Is(err, service.ErrBackupInProgress):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	hLogger.Info("routine renamed",
+		slog.String("name", routineName),
+		slog.String("newName", newName),
+	)
+	w.WriteHeader(http.StatusOK)
+}
+
+// validateRoutineName checks the routine name given outside of the request
+// path by the rules of the names in the path: the name is a path segment of
+// the routine endpoints and of its backup paths.
+func validateRoutineName(name string) error {
+	switch {
+	case name == "":
+		return errors.New(routineNameNotSpecifiedMsg)
+	case strings.Contains(name, "/"), name == ".", name == "..":
+		return fmt.Errorf("invalid routine name %q", name)
+	}
+	return nil
+}
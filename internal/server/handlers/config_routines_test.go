@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
 	"github.com/gorilla/mux"
@@ -105,6 +106,34 @@ func TestService_ConfigRoutineActionHandlerGet(t *testing.T) {
 	}
 }
 
+func TestService_ReadRoutineSchedule(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc(
+		"/config/routines/{name}",
+		h.ConfigRoutineActionHandler,
+	).Methods(http.MethodGet)
+
+	apitest.New().
+		Handler(router).
+		Method(http.MethodGet).
+		URL(fmt.Sprintf("/config/routines/%s", testRoutine)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(func(res *http.Response, _ *http.Request) error {
+			var routine dto.BackupRoutineWithSchedule
+			if err := json.NewDecoder(res.Body).Decode(&routine); err != nil {
+				return err
+			}
+			if routine.NextFullBackup == nil || !routine.NextFullBackup.After(time.Now()) {
+				return fmt.Errorf("unexpected next full backup time %v", routine.NextFullBackup)
+			}
+			return nil
+		}).
+		End()
+}
+
 func TestService_ConfigRoutineActionHandlerPut(t *testing.T) {
 	t.Parallel()
 	h := newServiceMock()
@@ -183,6 +212,75 @@ func TestService_ConfigRoutineActionHandlerDelete(t *testing.T) {
 	}
 }
 
+func TestService_PauseResumeRoutine(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc("/config/routines/{name}/pause", h.PauseRoutine).Methods(http.MethodPost)
+	router.HandleFunc("/config/routines/{name}/resume", h.ResumeRoutine).Methods(http.MethodPost)
+
+	testCases := []struct {
+		method     string
+		action     string
+		statusCode int
+		name       string
+		disabled   bool
+	}{
+		{http.MethodPost, "pause", http.StatusOK, testRoutine, true},
+		{http.MethodPost, "pause", http.StatusOK, testRoutine, true},
+		{http.MethodPost, "resume", http.StatusOK, testRoutine, false},
+		{http.MethodPost, "pause", http.StatusNotFound, "unknown", false},
+		{http.MethodGet, "pause", http.StatusMethodNotAllowed, testRoutine, false},
+		{http.MethodPut, "resume", http.StatusMethodNotAllowed, testRoutine, false},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL(fmt.Sprintf("/config/routines/%s/%s", tt.name, tt.action)).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+		require.Equal(t, tt.disabled, h.config.BackupRoutines[testRoutine].Disabled)
+	}
+}
+
+func TestService_RenameRoutine(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc("/config/routines/{name}/rename", h.RenameRoutine).Methods(http.MethodPost)
+
+	testCases := []struct {
+		name       string
+		newName    string
+		statusCode int
+		routine    string
+		prefix     string
+	}{
+		{testRoutine, "", http.StatusBadRequest, testRoutine, ""},
+		{testRoutine, "a/b", http.StatusBadRequest, testRoutine, ""},
+		{testRoutine, "..", http.StatusBadRequest, testRoutine, ""},
+		{"unknown", "renamed", http.StatusNotFound, testRoutine, ""},
+		{testRoutine, testRoutine, http.StatusConflict, testRoutine, ""},
+		{testRoutine, "renamed", http.StatusOK, "renamed", testRoutine},
+		{"renamed", testRoutine, http.StatusOK, testRoutine, ""},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Post(fmt.Sprintf("/config/routines/%s/rename", tt.name)).
+			Query("new-name", tt.newName).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+		require.Contains(t, h.config.BackupRoutines, tt.routine)
+		require.Equal(t, tt.prefix, h.config.BackupRoutines[tt.routine].StoragePrefix)
+	}
+}
+
 func TestService_ReadRoutines(t *testing.T) {
 	t.Parallel()
 	h := newServiceMock()
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
 	"github.com/gorilla/mux"
 )
 
@@ -218,3 +221,82 @@ func (s *Service) deleteStorage(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// TestStorage
+// @Summary     Checks the access to a storage.
+// @Description Writes, lists, reads and deletes a probe object in the storage, and reports the latency of each
+// @Description operation and the provider error of the failed one. The storage definition in the request body is
+// @Description checked if given, so that it can be checked before it is saved, otherwise the configured storage.
+// @ID          testStorage
+// @Tags        Configuration
+// @Router      /v1/config/storage/{name}/test [post]
+// @Accept      json
+// @Produce     json
+// @Param       name path string true "Backup storage name"
+// @Param       storage body dto.Storage false "Unsaved backup storage details"
+// @Success     200 {object} dto.StorageCheck
+// @Failure     400 {string} string
+// @Failure     404 {string} string "The specified storage could not be found"
+// @Failure     500 {string} string
+func (s *Service) TestStorage(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "TestStorage"))
+
+	storageName := mux.Vars(r)["name"]
+	if storageName == "" {
+		hLogger.Error("storage name required")
+		http.Error(w, storageNameNotSpecifiedMsg, http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	var checked model.Storage
+	if len(bytes.TrimSpace(body)) > 0 {
+		unsaved, err := dto.NewStorageFromReader(bytes.NewReader(body), dto.JSON)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		checked = unsaved.ToModel()
+	} else {
+		s.Lock()
+		configured, ok := s.config.Storage[storageName]
+		s.Unlock()
+		if !ok {
+			http.Error(w, fmt.Sprintf("Storage %s could not be found", storageName), http.StatusNotFound)
+			return
+		}
+		checked = configured
+	}
+
+	check := storage.Check(r.Context(), checked)
+	if !check.Success {
+		hLogger.Warn("storage check failed",
+			slog.String("name", storageName),
+			slog.String("operation", string(check.FailedOperation)),
+		)
+	}
+
+	jsonResponse, err := dto.Serialize(dto.NewStorageCheckFromModel(check), dto.JSON)
+	if err != nil {
+		hLogger.Error("failed to marshal storage check",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		hLogger.Error("failed to write response",
+			slog.String("response", string(jsonResponse)),
+			slog.Any("error", err),
+		)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
@@ -216,3 +218,46 @@ func TestService_ReadAllStorage(t *testing.T) {
 			End()
 	}
 }
+
+func TestService_TestStorage(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc("/config/storage/{name}/test", h.TestStorage).Methods(http.MethodPost)
+
+	unreachable := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(unreachable, nil, 0o600))
+
+	testCases := []struct {
+		name            string
+		body            string
+		statusCode      int
+		failedOperation string
+	}{
+		{"unsaved", `{"local-storage":{"path":"` + t.TempDir() + `"}}`, http.StatusOK, ""},
+		{"unsaved", `{"local-storage":{"path":"` + unreachable + `"}}`, http.StatusOK, "write"},
+		{"unsaved", `{}`, http.StatusBadRequest, ""},
+		{"unknown", "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range testCases {
+		result := apitest.New().
+			Handler(router).
+			Post(fmt.Sprintf("/config/storage/%s/test", tt.name)).
+			Body(tt.body).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+		if tt.statusCode != http.StatusOK {
+			continue
+		}
+
+		var check dto.StorageCheck
+		result.JSON(&check)
+		require.Equal(t, tt.failedOperation == "", check.Success)
+		require.Equal(t, tt.failedOperation, check.FailedOperation)
+		if check.Success {
+			require.Len(t, check.Operations, 4)
+		}
+	}
+}
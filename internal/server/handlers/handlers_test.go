@@ -180,6 +180,31 @@ func (mock backendsHolderMock) GetAllReaders() map[string]service.BackupListRead
 	return nil
 }
 
+func (mock backendsHolderMock) GetAll() map[string]*service.BackupBackend {
+	return nil
+}
+
+type backupCancelerMock struct {
+	running string
+	err     error
+}
+
+func (mock backupCancelerMock) Cancel(_ context.Context, routineName string) (bool, error) {
+	if mock.err != nil {
+		return true, mock.err
+	}
+	return routineName == mock.running, nil
+}
+
+type garbageCollectorMock struct {
+	backups []model.IncompleteBackup
+	err     error
+}
+
+func (mock garbageCollectorMock) Collect(_ context.Context, _ bool) ([]model.IncompleteBackup, error) {
+	return mock.backups, mock.err
+}
+
 type configurationManagerMock struct{}
 
 func (mock configurationManagerMock) Read(_ context.Context) (*model.Config, error) {
@@ -212,6 +237,8 @@ func newServiceMock() *Service {
 		restoreManager:       restoreManagerMock{},
 		backupBackends:       backendsHolderMock{},
 		handlerHolder:        nil,
+		backupCanceler:       backupCancelerMock{},
+		garbageCollector:     garbageCollectorMock{},
 		configurationManager: configurationManagerMock{},
 		logger:               slog.New(slog.NewJSONHandler(io.Discard, nil)),
 	}
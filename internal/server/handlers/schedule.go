@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/dto"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service"
+)
+
+const (
+	defaultScheduleCount = 5
+	maxScheduleCount     = 100
+)
+
+// GetSchedule
+// @Summary     Get the upcoming backup schedule of all routines.
+// @Description Returns per routine the next fire times of the full and incremental backup triggers,
+// @Description the currently running backups, the last run results and whether a catch-up
+// @Description full backup is pending.
+// @ID          getSchedule
+// @Tags        Backup
+// @Produce     json
+// @Param       count query int false "The number of next fire times per trigger (default 5, max 100)"
+// @Router      /v1/schedule [get]
+// @Success     200 {object} map[string]dto.RoutineSchedule
+// @Failure     400 {string} string
+// @Failure     500 {string} string
+func (s *Service) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	hLogger := s.logger.With(slog.String("handler", "GetSchedule"))
+
+	count := defaultScheduleCount
+	if countParam := r.URL.Query().Get("count"); countParam != "" {
+		var err error
+		count, err = strconv.Atoi(countParam)
+		if err != nil || count <= 0 || count > maxScheduleCount {
+			hLogger.Error("invalid count query parameter",
+				slog.String("count", countParam),
+			)
+			http.Error(w, "count should be a number between 1 and "+strconv.Itoa(maxScheduleCount),
+				http.StatusBadRequest)
+			return
+		}
+	}
+
+	// the handlers are replaced when the configuration is applied
+	s.Lock()
+	schedules := service.RoutineSchedules(s.scheduler, s.handlerHolder, count)
+	s.Unlock()
+	response := dto.ConvertModelMapToDTO(schedules, dto.NewRoutineScheduleFromModel)
+	jsonResponse, err := dto.Serialize(response, dto.JSON)
+	if err != nil {
+		hLogger.Error("failed to marshal schedule",
+			slog.Any("error", err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(jsonResponse)
+	if err != nil {
+		hLogger.Error("failed to write response",
+			slog.String("response", string(jsonResponse)),
+			slog.Any("error", err),
+		)
+	}
+}
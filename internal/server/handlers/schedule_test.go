@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/steinfletcher/apitest"
+)
+
+func TestService_GetSchedule(t *testing.T) {
+	t.Parallel()
+	h := newServiceMock()
+	router := mux.NewRouter()
+	router.HandleFunc("/schedule", h.GetSchedule).Methods(http.MethodGet)
+
+	testCases := []struct {
+		method     string
+		statusCode int
+		count      string
+	}{
+		{http.MethodGet, http.StatusOK, ""},
+		{http.MethodGet, http.StatusOK, "10"},
+		{http.MethodGet, http.StatusBadRequest, "0"},
+		{http.MethodGet, http.StatusBadRequest, "101"},
+		{http.MethodGet, http.StatusBadRequest, "ten"},
+		{http.MethodPost, http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, tt := range testCases {
+		apitest.New().
+			Handler(router).
+			Method(tt.method).
+			URL("/schedule").
+			QueryParams(map[string]string{"count": tt.count}).
+			Expect(t).
+			Status(tt.statusCode).
+			End()
+	}
+}
@@ -11,6 +11,7 @@ import (
 )
 
 type Service struct {
+	// guards the configuration and the handlers replaced when it is applied
 	sync.Mutex
 	config               *model.Config
 	configApplier        service.ConfigApplier
@@ -18,6 +19,8 @@ type Service struct {
 	restoreManager       service.RestoreManager
 	backupBackends       service.BackendsHolder
 	handlerHolder        service.BackupHandlerHolder
+	backupCanceler       service.BackupCanceler
+	garbageCollector     service.GarbageCollector
 	configurationManager configuration.Manager
 	logger               *slog.Logger
 }
@@ -29,6 +32,8 @@ func NewService(
 	restoreManager service.RestoreManager,
 	backupBackends service.BackendsHolder,
 	handlerHolder service.BackupHandlerHolder,
+	backupCanceler service.BackupCanceler,
+	garbageCollector service.GarbageCollector,
 	configurationManager configuration.Manager,
 	logger *slog.Logger,
 ) *Service {
@@ -39,6 +44,8 @@ func NewService(
 		restoreManager:       restoreManager,
 		backupBackends:       backupBackends,
 		handlerHolder:        handlerHolder,
+		backupCanceler:       backupCanceler,
+		garbageCollector:     garbageCollector,
 		configurationManager: configurationManager,
 		logger:               logger,
 	}
@@ -52,6 +52,7 @@ func NewRouter(apiPath, sysPath string, h *handlers.Service, middlewares ...mux.
 	apiRouter.HandleFunc("/config/storage/{name}", h.ConfigStorageActionHandler).
 		Methods(http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete)
 	apiRouter.HandleFunc("/config/storage", h.ReadAllStorage).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/config/storage/{name}/test", h.TestStorage).Methods(http.MethodPost)
 
 	// policy config routes
 	apiRouter.HandleFunc("/config/policies/{name}", h.ConfigPolicyActionHandler).
@@ -62,6 +63,9 @@ func NewRouter(apiPath, sysPath string, h *handlers.Service, middlewares ...mux.
 	apiRouter.HandleFunc("/config/routines/{name}", h.ConfigRoutineActionHandler).
 		Methods(http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete)
 	apiRouter.HandleFunc("/config/routines", h.ReadRoutines).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/config/routines/{name}/pause", h.PauseRoutine).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/config/routines/{name}/resume", h.ResumeRoutine).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/config/routines/{name}/rename", h.RenameRoutine).Methods(http.MethodPost)
 
 	// Restore job endpoints
 	// Restore from full backup (by folder)
@@ -85,12 +89,43 @@ func NewRouter(apiPath, sysPath string, h *handlers.Service, middlewares ...mux.
 	apiRouter.HandleFunc("/backups/incremental/{name}", h.GetIncrementalBackupsForRoutine).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/backups/incremental", h.GetAllIncrementalBackups).Methods(http.MethodGet)
 
+	// Delete backup
+	apiRouter.HandleFunc("/backups/full/{name}/{timestamp}", h.DeleteFullBackup).Methods(http.MethodDelete)
+	apiRouter.HandleFunc("/backups/incremental/{name}/{timestamp}", h.DeleteIncrementalBackup).
+		Methods(http.MethodDelete)
+
 	// Schedules a full backup operation
 	apiRouter.HandleFunc("/backups/schedule/{name}", h.ScheduleFullBackup).Methods(http.MethodPost)
 
+	// Get status of ad-hoc backup jobs
+	apiRouter.HandleFunc("/backups/jobs/{id}", h.GetBackupJobStatus).Methods(http.MethodGet)
+
+	// Cancels running backups
+	apiRouter.HandleFunc("/backups/cancel/{name}", h.CancelBackup).Methods(http.MethodPost)
+
+	// Remove incomplete backups
+	apiRouter.HandleFunc("/backups/incomplete", h.RemoveIncompleteBackups).Methods(http.MethodPost)
+
+	// Rebuild the backup catalog
+	apiRouter.HandleFunc("/backups/catalog/{name}", h.RebuildBackupCatalog).Methods(http.MethodPost)
+
+	// Verify backups
+	apiRouter.HandleFunc("/backups/verify/{name}", h.VerifyLatestBackup).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/backups/verify/{name}/{timestamp}", h.VerifyBackup).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/backups/verify/{name}/{timestamp}", h.GetBackupVerification).Methods(http.MethodGet)
+
+	// Get the replication status of backups
+	apiRouter.HandleFunc("/backups/replication/{name}/{timestamp}", h.GetBackupReplication).Methods(http.MethodGet)
+
+	// Migrate the backups of the historical storages
+	apiRouter.HandleFunc("/backups/migrate/{name}", h.MigrateHistoricalBackups).Methods(http.MethodPost)
+
 	// Get information on currently running backups
 	apiRouter.HandleFunc("/backups/currentBackup/{name}", h.GetCurrentBackupInfo).Methods(http.MethodGet)
 
+	// Get the upcoming backup schedule
+	apiRouter.HandleFunc("/schedule", h.GetSchedule).Methods(http.MethodGet)
+
 	return r
 }
 
@@ -23,8 +23,32 @@ type BackupMetadata struct {
 	Created time.Time `yaml:"created,omitempty" json:"created,omitempty" example:"2023-03-20T14:50:00Z"`
 	// The lower time bound of backup entities in the ISO 8601 format (for incremental backups).
 	From time.Time `yaml:"from,omitempty" json:"from,omitempty" example:"2023-03-19T14:50:00Z"`
+	// The upper time bound of backup entities in the ISO 8601 format.
+	To time.Time `yaml:"to,omitempty" json:"to,omitempty" example:"2023-03-20T14:50:00Z"`
 	// The namespace of a backup.
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty" example:"testNamespace"`
+	// The name of the backup routine.
+	Routine string `yaml:"routine,omitempty" json:"routine,omitempty" example:"daily"`
+	// The label of the source cluster in the service configuration.
+	ClusterLabel string `yaml:"cluster-label,omitempty" json:"cluster-label,omitempty" example:"cluster1"`
+	// The name of the source cluster reported by the Aerospike server.
+	ClusterName string `yaml:"cluster-name,omitempty" json:"cluster-name,omitempty" example:"prod"`
+	// The version of the backup service which created the backup.
+	ServiceVersion string `yaml:"service-version,omitempty" json:"service-version,omitempty" example:"2.0.0"`
+	// The type of the backup.
+	Type string `yaml:"type,omitempty" json:"type,omitempty" enums:"full,incremental"`
+	// Whether the backup was run on demand rather than on schedule.
+	AdHoc bool `yaml:"ad-hoc,omitempty" json:"ad-hoc,omitempty" example:"false"`
+	// The key of the full backup the incremental backup is based on.
+	Parent string `yaml:"parent,omitempty" json:"parent,omitempty" example:"daily/backup/1707915600000/source-ns1"`
+	// The compression mode of the backup files.
+	Compression string `yaml:"compression,omitempty" json:"compression,omitempty" example:"ZSTD"`
+	// The encryption mode of the backup files.
+	Encryption string `yaml:"encryption,omitempty" json:"encryption,omitempty" example:"AES256"`
+	// The backed up sets, empty if all sets were backed up.
+	SetList []string `yaml:"set-list,omitempty" json:"set-list,omitempty" example:"set1"`
+	// The backed up bins, empty if all bins were backed up.
+	BinList []string `yaml:"bin-list,omitempty" json:"bin-list,omitempty" example:"dataBin"`
 	// The total number of records backed up.
 	RecordCount uint64 `yaml:"record-count,omitempty" json:"record-count,omitempty" format:"int64" example:"100"`
 	// The size of the backup in bytes.
@@ -35,18 +59,67 @@ type BackupMetadata struct {
 	SecondaryIndexCount uint64 `yaml:"secondary-index-count,omitempty" json:"secondary-index-count,omitempty" format:"int64" example:"5"`
 	// The number of UDF files backed up.
 	UDFCount uint64 `yaml:"udf-count,omitempty" json:"udf-count,omitempty" format:"int64" example:"2"`
+	// The partition filters of a backup, empty if all partitions were backed up.
+	PartitionList string `yaml:"partition-list,omitempty" json:"partition-list,omitempty" example:"0-1000"`
+	// The start time of the namespace backup in the ISO 8601 format.
+	Started time.Time `yaml:"started,omitempty" json:"started,omitempty" example:"2023-03-20T14:50:01Z"`
+	// The end time of the namespace backup in the ISO 8601 format.
+	Finished time.Time `yaml:"finished,omitempty" json:"finished,omitempty" example:"2023-03-20T14:55:00Z"`
+	// The duration of the namespace backup in milliseconds.
+	Duration int64 `yaml:"duration,omitempty" json:"duration,omitempty" format:"int64" example:"299000"`
+	// The number of records backed up per second.
+	RecordsPerSecond uint64 `yaml:"records-per-second,omitempty" json:"records-per-second,omitempty" format:"int64" example:"1000"`
+	// The number of bytes written per second.
+	BytesPerSecond uint64 `yaml:"bytes-per-second,omitempty" json:"bytes-per-second,omitempty" format:"int64" example:"20000"`
+	// The manifest of the backup data files.
+	Files []BackupFile `yaml:"files,omitempty" json:"files,omitempty"`
+	// The SHA-256 checksum of the backup metadata.
+	Checksum string `yaml:"checksum,omitempty" json:"checksum,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+// BackupFile is an entry of the backup file manifest.
+// @Description BackupFile is an entry of the backup file manifest.
+type BackupFile struct {
+	// The path of the file relative to the backup folder.
+	Path string `yaml:"path" json:"path" example:"source-ns1_1.asb"`
+	// The size of the file in bytes.
+	Size uint64 `yaml:"size" json:"size" format:"int64" example:"2000"`
+	// The hex encoded SHA-256 checksum of the file.
+	SHA256 string `yaml:"sha256" json:"sha256" example:"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`
 }
 
 func (d *BackupDetails) fromModel(m *model.BackupDetails) {
 	d.Key = m.Key
 	d.Created = m.Created
 	d.From = m.From
+	d.To = m.To
 	d.Namespace = m.Namespace
+	d.Routine = m.Routine
+	d.ClusterLabel = m.ClusterLabel
+	d.ClusterName = m.ClusterName
+	d.ServiceVersion = m.ServiceVersion
+	d.Type = string(m.Type)
+	d.AdHoc = m.AdHoc
+	d.Parent = m.Parent
+	d.Compression = m.Compression
+	d.Encryption = m.Encryption
+	d.SetList = m.SetList
+	d.BinList = m.BinList
 	d.RecordCount = m.RecordCount
 	d.ByteCount = m.ByteCount
 	d.FileCount = m.FileCount
 	d.SecondaryIndexCount = m.SecondaryIndexCount
 	d.UDFCount = m.UDFCount
+	d.PartitionList = m.PartitionList
+	d.Started = m.Started
+	d.Finished = m.Finished
+	d.Duration = m.Duration
+	d.RecordsPerSecond = m.RecordsPerSecond
+	d.BytesPerSecond = m.BytesPerSecond
+	for _, f := range m.Files {
+		d.Files = append(d.Files, BackupFile{Path: f.Path, Size: f.Size, SHA256: f.SHA256})
+	}
+	d.Checksum = m.Checksum
 	d.Storage = NewStorageFromModel(m.Storage)
 }
 
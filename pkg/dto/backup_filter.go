@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// BackupFilter selects backups by their metadata.
+// Empty fields match any backup.
+type BackupFilter struct {
+	Namespace   string
+	Type        string
+	AdHoc       *bool
+	Cluster     string
+	Set         string
+	Bin         string
+	Compression string
+	Encryption  string
+}
+
+// NewBackupFilterFromQuery creates a BackupFilter from the query parameters
+// of a backup list request.
+func NewBackupFilterFromQuery(query url.Values) (*BackupFilter, error) {
+	f := &BackupFilter{
+		Namespace:   query.Get("namespace"),
+		Type:        query.Get("type"),
+		Cluster:     query.Get("cluster"),
+		Set:         query.Get("set"),
+		Bin:         query.Get("bin"),
+		Compression: query.Get("compression"),
+		Encryption:  query.Get("encryption"),
+	}
+	if adHoc := query.Get("ad-hoc"); adHoc != "" {
+		value, err := strconv.ParseBool(adHoc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ad-hoc value %q: %w", adHoc, err)
+		}
+		f.AdHoc = &value
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Validate validates the backup filter.
+func (f *BackupFilter) Validate() error {
+	switch model.BackupType(f.Type) {
+	case "", model.BackupTypeFull, model.BackupTypeIncremental:
+		return nil
+	default:
+		return fmt.Errorf("invalid backup type %q, expected %s or %s",
+			f.Type, model.BackupTypeFull, model.BackupTypeIncremental)
+	}
+}
+
+func (f *BackupFilter) ToModel() *model.BackupFilter {
+	return &model.BackupFilter{
+		Namespace:   f.Namespace,
+		Type:        model.BackupType(f.Type),
+		AdHoc:       f.AdHoc,
+		Cluster:     f.Cluster,
+		Set:         f.Set,
+		Bin:         f.Bin,
+		Compression: f.Compression,
+		Encryption:  f.Encryption,
+	}
+}
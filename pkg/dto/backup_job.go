@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// BackupJobStatus represents the status of an ad-hoc backup job.
+// @Description BackupJobStatus represents the status of an ad-hoc backup job.
+//
+//nolint:lll
+type BackupJobStatus struct {
+	// The backup routine name.
+	Routine string `json:"routine,omitempty" example:"daily"`
+	// The backup type.
+	Type   string    `json:"type,omitempty" example:"full" enums:"full,incremental"`
+	Status JobStatus `json:"status,omitempty" enums:"Queued,Running,Skipped,Done,Failed"`
+	// The time the job was scheduled.
+	CreatedTime time.Time `json:"created-time,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	// The time the job started, absent if it has not started yet.
+	StartTime *time.Time `json:"start-time,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	// The time the job finished, absent if it has not finished yet.
+	EndTime *time.Time `json:"end-time,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	// Backup statistics by namespace.
+	Namespaces map[string]*BackupJobStats `json:"namespaces,omitempty"`
+	// The keys of the created backups, available when the job is done.
+	Keys []string `json:"keys,omitempty" example:"daily/backup/1707915600000/data/source-ns1"`
+	// The error chain of a failed job, from the outermost error to the root cause.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BackupJobStats represents the statistics of a namespace backup.
+// @Description BackupJobStats represents the statistics of a namespace backup.
+//
+//nolint:lll
+type BackupJobStats struct {
+	// The number of records backed up.
+	RecordCount uint64 `json:"record-count,omitempty" format:"int64" example:"100"`
+	// The estimated total number of records to back up.
+	TotalRecords uint64 `json:"total-records,omitempty" format:"int64" example:"100"`
+	// The number of bytes written.
+	ByteCount uint64 `json:"byte-count,omitempty" format:"int64" example:"2000"`
+	// The number of backup files created.
+	FileCount uint64 `json:"file-count,omitempty" format:"int64" example:"1"`
+	// The number of secondary indexes backed up.
+	SecondaryIndexCount uint64 `json:"secondary-index-count,omitempty" format:"int64" example:"5"`
+	// The number of UDF files backed up.
+	UDFCount uint64 `json:"udf-count,omitempty" format:"int64" example:"2"`
+}
+
+func NewBackupJobStatusFromModel(m *model.BackupJobStatus) *BackupJobStatus {
+	if m == nil {
+		return nil
+	}
+
+	s := &BackupJobStatus{}
+	s.fromModel(m)
+	return s
+}
+
+func (s *BackupJobStatus) fromModel(m *model.BackupJobStatus) {
+	s.Routine = m.Routine
+	s.Type = m.Type
+	s.Status = JobStatus(m.Status)
+	s.CreatedTime = m.CreatedTime
+	s.StartTime = timeOrNil(m.StartTime)
+	s.EndTime = timeOrNil(m.EndTime)
+	if len(m.Namespaces) > 0 {
+		s.Namespaces = make(map[string]*BackupJobStats, len(m.Namespaces))
+		for namespace, stats := range m.Namespaces {
+			s.Namespaces[namespace] = &BackupJobStats{
+				RecordCount:         stats.RecordCount,
+				TotalRecords:        stats.TotalRecords,
+				ByteCount:           stats.ByteCount,
+				FileCount:           stats.FileCount,
+				SecondaryIndexCount: stats.SecondaryIndexCount,
+				UDFCount:            stats.UDFCount,
+			}
+		}
+	}
+	s.Keys = m.Keys
+	s.Errors = m.Errors
+}
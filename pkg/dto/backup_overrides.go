@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// BackupOverrides are the parameters of a single ad-hoc incremental backup
+// run, which override the backup routine configuration.
+// @Description BackupOverrides are the parameters of a single ad-hoc incremental backup run,
+// @Description which override the backup routine configuration.
+//
+//nolint:lll
+type BackupOverrides struct {
+	// The list of the namespaces to back up (optional, the routine namespaces by default).
+	Namespaces []string `json:"namespaces,omitempty" example:"source-ns1"`
+	// The list of backup set names (optional, the routine set list by default).
+	SetList []string `json:"set-list,omitempty" example:"set1"`
+	// The list of backup bin names (optional, the routine bin list by default).
+	BinList []string `json:"bin-list,omitempty" example:"dataBin"`
+	// Back up only records modified after this epoch time in milliseconds
+	// (optional, the time of the last backup by default).
+	From *int64 `json:"from,omitempty" format:"int64" example:"1739538000000"`
+}
+
+// Validate validates the backup overrides.
+func (o *BackupOverrides) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.From != nil && *o.From < 0 {
+		return errors.New("from should be positive or zero")
+	}
+	return nil
+}
+
+func (o *BackupOverrides) ToModel() *model.BackupOverrides {
+	if o == nil {
+		return nil
+	}
+
+	var from *time.Time
+	if o.From != nil {
+		t := time.UnixMilli(*o.From)
+		from = &t
+	}
+
+	return &model.BackupOverrides{
+		Namespaces: o.Namespaces,
+		SetList:    o.SetList,
+		BinList:    o.BinList,
+		From:       from,
+	}
+}
@@ -18,6 +18,15 @@ const (
 // @Description RemoveFilesType represents the type of the backup storage.
 type RemoveFilesType string
 
+const (
+	NamespaceOrderConfig NamespaceOrder = "config"
+	NamespaceOrderSize   NamespaceOrder = "size"
+)
+
+// NamespaceOrder is the order in which the namespaces of a routine are backed up.
+// @Description NamespaceOrder is the order in which the namespaces of a routine are backed up.
+type NamespaceOrder string
+
 // BackupPolicy represents a scheduled backup policy.
 // @Description BackupPolicy represents a scheduled backup policy.
 //
@@ -34,6 +43,11 @@ type BackupPolicy struct {
 	MaxRetries *int32 `yaml:"max-retries,omitempty" json:"max-retries,omitempty" example:"3"`
 	// RetryDelay defines the delay in milliseconds before retrying a failed operation.
 	RetryDelay *int32 `yaml:"retry-delay,omitempty" json:"retry-delay,omitempty" example:"500"`
+	// RetryMaxDelay defines the maximum delay in milliseconds between the retries (default: 10 minutes).
+	RetryMaxDelay *int32 `yaml:"retry-max-delay,omitempty" json:"retry-max-delay,omitempty" example:"600000"`
+	// RetryMultiplier defines the factor the retry delay is multiplied by after
+	// each retry, 1 keeps the delay fixed (default: 2).
+	RetryMultiplier *float64 `yaml:"retry-multiplier,omitempty" json:"retry-multiplier,omitempty" example:"2"`
 	// Whether to clear the output directory (default: KeepAll).
 	RemoveFiles *RemoveFilesType `yaml:"remove-files,omitempty" json:"remove-files,omitempty" enums:"KeepAll,RemoveAll,RemoveIncremental"`
 	// Do not back up any record data (metadata or bin data).
@@ -59,6 +73,17 @@ type BackupPolicy struct {
 	// When true, the backup contains only records that last modified before backup started.
 	// When false (default), records updated during backup might be included in the backup, but it's not guaranteed.
 	Sealed *bool `yaml:"sealed,omitempty" json:"sealed,omitempty"`
+	// Retention policy for full and incremental backups (default: keep all).
+	// Cannot be combined with remove-files RemoveAll.
+	Retention *RetentionPolicy `yaml:"retention,omitempty" json:"retention,omitempty"`
+	// Maximum number of namespaces backed up in parallel, 1 backs them up sequentially.
+	// All namespaces are backed up in parallel by default.
+	ParallelNamespaces *int `yaml:"parallel-namespaces,omitempty" json:"parallel-namespaces,omitempty" example:"2"`
+	// The order in which the namespaces are backed up: config (default) keeps the configured order,
+	// size backs up the largest namespaces first.
+	NamespaceOrder *NamespaceOrder `yaml:"namespace-order,omitempty" json:"namespace-order,omitempty" enums:"config,size"`
+	// The namespaces backed up first, in the given order, before the other namespaces in the namespace-order.
+	NamespacePriority []string `yaml:"namespace-priority,omitempty" json:"namespace-priority,omitempty" example:"source-ns1"`
 }
 
 // NewBackupPolicyFromReader creates a new BackupPolicy object from a given reader
@@ -95,6 +120,12 @@ func (p *BackupPolicy) Validate() error {
 	if p.RetryDelay != nil && *p.RetryDelay < 0 {
 		return fmt.Errorf("retryDelay %d invalid, should be positive number", *p.RetryDelay)
 	}
+	if p.RetryMaxDelay != nil && *p.RetryMaxDelay <= 0 {
+		return fmt.Errorf("retryMaxDelay %d invalid, should be positive number", *p.RetryMaxDelay)
+	}
+	if p.RetryMultiplier != nil && *p.RetryMultiplier < 1 {
+		return fmt.Errorf("retryMultiplier %v invalid, should not be less than 1", *p.RetryMultiplier)
+	}
 	if p.Bandwidth != nil && *p.Bandwidth <= 0 {
 		return fmt.Errorf("bandwidth %d invalid, should be positive number", *p.Bandwidth)
 	}
@@ -114,26 +145,63 @@ func (p *BackupPolicy) Validate() error {
 	if err := p.CompressionPolicy.Validate(); err != nil {
 		return err
 	}
+	if p.Retention != nil && p.RemoveFiles != nil && *p.RemoveFiles == RemoveAll {
+		return errors.New("retention cannot be used with RemoveFiles RemoveAll")
+	}
+	if err := p.Retention.Validate(); err != nil {
+		return err
+	}
+	if p.ParallelNamespaces != nil && *p.ParallelNamespaces <= 0 {
+		return fmt.Errorf("parallelNamespaces %d invalid, should be positive number", *p.ParallelNamespaces)
+	}
+	if p.NamespaceOrder != nil &&
+		*p.NamespaceOrder != NamespaceOrderConfig && *p.NamespaceOrder != NamespaceOrderSize {
+		return fmt.Errorf("invalid NamespaceOrder: %s. Possible values: config, size", *p.NamespaceOrder)
+	}
+	if err := validateNamespacePriority(p.NamespacePriority); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateNamespacePriority(namespaces []string) error {
+	seen := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		if namespace == "" {
+			return errors.New("namespace priority contains an empty namespace")
+		}
+		if seen[namespace] {
+			return fmt.Errorf("namespace priority contains duplicate namespace %s", namespace)
+		}
+		seen[namespace] = true
+	}
+
 	return nil
 }
 
 func (p *BackupPolicy) ToModel() *model.BackupPolicy {
 	return &model.BackupPolicy{
-		Parallel:          p.Parallel,
-		SocketTimeout:     p.SocketTimeout,
-		TotalTimeout:      p.TotalTimeout,
-		MaxRetries:        p.MaxRetries,
-		RetryDelay:        p.RetryDelay,
-		RemoveFiles:       (*model.RemoveFilesType)(p.RemoveFiles),
-		NoRecords:         p.NoRecords,
-		NoIndexes:         p.NoIndexes,
-		NoUdfs:            p.NoUdfs,
-		Bandwidth:         p.Bandwidth,
-		RecordsPerSecond:  p.RecordsPerSecond,
-		FileLimit:         p.FileLimit,
-		EncryptionPolicy:  p.EncryptionPolicy.ToModel(),
-		CompressionPolicy: p.CompressionPolicy.ToModel(),
-		Sealed:            p.Sealed,
+		Parallel:           p.Parallel,
+		SocketTimeout:      p.SocketTimeout,
+		TotalTimeout:       p.TotalTimeout,
+		MaxRetries:         p.MaxRetries,
+		RetryDelay:         p.RetryDelay,
+		RetryMaxDelay:      p.RetryMaxDelay,
+		RetryMultiplier:    p.RetryMultiplier,
+		RemoveFiles:        (*model.RemoveFilesType)(p.RemoveFiles),
+		NoRecords:          p.NoRecords,
+		NoIndexes:          p.NoIndexes,
+		NoUdfs:             p.NoUdfs,
+		Bandwidth:          p.Bandwidth,
+		RecordsPerSecond:   p.RecordsPerSecond,
+		FileLimit:          p.FileLimit,
+		EncryptionPolicy:   p.EncryptionPolicy.ToModel(),
+		CompressionPolicy:  p.CompressionPolicy.ToModel(),
+		Sealed:             p.Sealed,
+		Retention:          p.Retention.ToModel(),
+		ParallelNamespaces: p.ParallelNamespaces,
+		NamespaceOrder:     (*model.NamespaceOrder)(p.NamespaceOrder),
+		NamespacePriority:  p.NamespacePriority,
 	}
 }
 
@@ -153,6 +221,8 @@ func (p *BackupPolicy) fromModel(m *model.BackupPolicy) {
 	p.TotalTimeout = m.TotalTimeout
 	p.MaxRetries = m.MaxRetries
 	p.RetryDelay = m.RetryDelay
+	p.RetryMaxDelay = m.RetryMaxDelay
+	p.RetryMultiplier = m.RetryMultiplier
 	p.RemoveFiles = (*RemoveFilesType)(m.RemoveFiles)
 	p.NoRecords = m.NoRecords
 	p.NoIndexes = m.NoIndexes
@@ -169,4 +239,11 @@ func (p *BackupPolicy) fromModel(m *model.BackupPolicy) {
 		p.CompressionPolicy.fromModel(m.CompressionPolicy)
 	}
 	p.Sealed = m.Sealed
+	if m.Retention != nil {
+		p.Retention = &RetentionPolicy{}
+		p.Retention.fromModel(m.Retention)
+	}
+	p.ParallelNamespaces = m.ParallelNamespaces
+	p.NamespaceOrder = (*NamespaceOrder)(m.NamespaceOrder)
+	p.NamespacePriority = m.NamespacePriority
 }
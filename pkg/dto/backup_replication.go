@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// BackupReplication contains the replication status of a backup.
+// @Description BackupReplication contains the replication status of a backup.
+type BackupReplication struct {
+	// The creation time of the backup.
+	Created time.Time `json:"created" example:"2023-03-20T14:50:00Z"`
+	// The status of the copies to the replica storages.
+	Replicas []ReplicaStatus `json:"replicas,omitempty"`
+}
+
+// ReplicaStatus is the replication status of a backup to a replica storage.
+// @Description ReplicaStatus is the replication status of a backup to a replica storage.
+type ReplicaStatus struct {
+	// The name of the replica storage.
+	Storage string `json:"storage" example:"gcp"`
+	// The replication status.
+	Status string `json:"status" enums:"pending,replicated,failed"`
+	// The number of copy attempts.
+	Attempts int32 `json:"attempts" example:"1"`
+	// The time the status was updated.
+	Updated time.Time `json:"updated" example:"2023-03-20T14:55:00Z"`
+	// The number of files copied.
+	FileCount int `json:"file-count" example:"3"`
+	// The error of the last failed attempt.
+	Error string `json:"error,omitempty" example:"cannot copy backup file"`
+}
+
+// NewBackupReplicationFromModel creates a new BackupReplication from the model.
+func NewBackupReplicationFromModel(m *model.BackupReplication) *BackupReplication {
+	if m == nil {
+		return nil
+	}
+
+	r := &BackupReplication{Created: m.Created}
+	for _, s := range m.Replicas {
+		r.Replicas = append(r.Replicas, ReplicaStatus{
+			Storage:   s.Storage,
+			Status:    string(s.Status),
+			Attempts:  s.Attempts,
+			Updated:   s.Updated,
+			FileCount: s.FileCount,
+			Error:     s.Error,
+		})
+	}
+	return r
+}
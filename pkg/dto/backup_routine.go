@@ -1,8 +1,11 @@
 package dto
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"time"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 	"github.com/aws/smithy-go/ptr"
@@ -20,12 +23,27 @@ type BackupRoutine struct {
 	SourceCluster string `yaml:"source-cluster,omitempty" json:"source-cluster,omitempty" example:"testCluster" validate:"required"`
 	// The name of the corresponding storage provider configuration.
 	Storage string `yaml:"storage,omitempty" json:"storage,omitempty" example:"aws" validate:"required"`
+	// The names of the secondary storages the backups are copied to (optional).
+	ReplicateTo []string `yaml:"replicate-to,omitempty" json:"replicate-to,omitempty" example:"gcp"`
+	// Moves the aged backups to another storage (optional).
+	Tiering *TieringPolicy `yaml:"tiering,omitempty" json:"tiering,omitempty"`
+	// The storages the backups of the routine were stored in before, read-only.
+	// The previous storage is added when the storage of the routine is changed,
+	// and removed once its backups are migrated.
+	HistoricalStorages []*Storage `yaml:"historical-storages,omitempty" json:"historical-storages,omitempty"`
+	// The prefix of the backup paths in the storages, the routine name if empty.
+	// It is set when the routine is renamed, so that its backups keep their paths.
+	StoragePrefix string `yaml:"storage-prefix,omitempty" json:"storage-prefix,omitempty" example:"daily"`
 	// The Secret Agent configuration for the routine (optional).
 	SecretAgent *string `yaml:"secret-agent,omitempty" json:"secret-agent,omitempty" example:"sa"`
 	// The interval for full backup as a cron expression string.
 	IntervalCron string `yaml:"interval-cron" json:"interval-cron" example:"0 0 * * * *" validate:"required"`
 	// The interval for incremental backup as a cron expression string (optional).
 	IncrIntervalCron string `yaml:"incr-interval-cron" json:"incr-interval-cron" example:"*/10 * * * * *"`
+	// The interval for verification of the latest full backup as a cron expression string (optional).
+	VerifyIntervalCron string `yaml:"verify-interval-cron,omitempty" json:"verify-interval-cron,omitempty" example:"0 0 3 ? * SUN"`
+	// The IANA time zone of the cron expressions (optional, UTC by default).
+	TimeZone *string `yaml:"time-zone,omitempty" json:"time-zone,omitempty" example:"Europe/Berlin"`
 	// The list of the namespaces to back up (optional, empty list implies backup up whole cluster).
 	Namespaces []string `yaml:"namespaces,omitempty" json:"namespaces,omitempty" example:"source-ns1"`
 	// The list of backup set names (optional, an empty list implies backing up all sets).
@@ -38,6 +56,10 @@ type BackupRoutine struct {
 	// or records after a specific digest within a single partition.
 	// Default number of partitions to back up: 0 to 4095: all partitions.
 	PartitionList *string `yaml:"partition-list,omitempty" json:"partition-list,omitempty" example:"0-1000"`
+	// Disabled routines are not scheduled, their backups are still available for listing and restore.
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty" example:"false"`
+	// Scheduled backups of the routine are not run during the blackout windows.
+	BlackoutWindows []*BlackoutWindow `yaml:"blackout-windows,omitempty" json:"blackout-windows,omitempty"`
 }
 
 // Validate validates the backup routine configuration.
@@ -51,6 +73,23 @@ func (r *BackupRoutine) Validate() error {
 	if r.Storage == "" {
 		return emptyFieldValidationError("storage")
 	}
+	if err := r.validateReplicateTo(); err != nil {
+		return err
+	}
+	if err := r.Tiering.Validate(); err != nil {
+		return err
+	}
+	if r.Tiering != nil && r.Tiering.MoveTo == r.Storage {
+		return fmt.Errorf("tiering move-to storage '%s' is the routine storage", r.Tiering.MoveTo)
+	}
+	for i, s := range r.HistoricalStorages {
+		if s == nil {
+			return emptyFieldValidationError("historical storage")
+		}
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("historical storage %d validation error: %w", i, err)
+		}
+	}
 	if err := quartz.ValidateCronExpression(r.IntervalCron); err != nil {
 		return fmt.Errorf("backup interval string '%s' invalid: %w", r.IntervalCron, err)
 	}
@@ -59,6 +98,16 @@ func (r *BackupRoutine) Validate() error {
 			return fmt.Errorf("incremental backup interval string '%s' invalid: %w", r.IntervalCron, err)
 		}
 	}
+	if r.VerifyIntervalCron != "" { // verification interval is optional
+		if err := quartz.ValidateCronExpression(r.VerifyIntervalCron); err != nil {
+			return fmt.Errorf("verification interval string '%s' invalid: %w", r.VerifyIntervalCron, err)
+		}
+	}
+	if r.TimeZone != nil {
+		if _, err := time.LoadLocation(*r.TimeZone); err != nil {
+			return fmt.Errorf("time zone '%s' invalid: %w", *r.TimeZone, err)
+		}
+	}
 	for _, rack := range r.PreferRacks {
 		if rack < 0 {
 			return fmt.Errorf("rack id %d invalid, should be positive number", rack)
@@ -67,11 +116,54 @@ func (r *BackupRoutine) Validate() error {
 			return fmt.Errorf("rack id %d invalid, should not exceed %d", rack, maxRack)
 		}
 	}
+	if r.PartitionList != nil {
+		if err := validatePartitionList(*r.PartitionList, r.Namespaces); err != nil {
+			return fmt.Errorf("partition list '%s' invalid: %w", *r.PartitionList, err)
+		}
+	}
 	if r.SecretAgent != nil {
 		if *r.SecretAgent == "" {
 			return emptyFieldValidationError("secret-agent")
 		}
 	}
+	if err := validateBlackoutWindows(r.BlackoutWindows); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BackupRoutine) validateReplicateTo() error {
+	seen := make(map[string]bool, len(r.ReplicateTo))
+	for _, name := range r.ReplicateTo {
+		switch {
+		case name == "":
+			return emptyFieldValidationError("replicate-to storage")
+		case name == r.Storage:
+			return fmt.Errorf("replicate-to storage '%s' is the routine storage", name)
+		case seen[name]:
+			return fmt.Errorf("replicate-to storage '%s' is duplicated", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// validatePartitionList validates the partition list against the routine
+// namespaces: a record digest belongs to a single namespace.
+func validatePartitionList(partitionList string, namespaces []string) error {
+	var namespace string
+	if len(namespaces) == 1 {
+		namespace = namespaces[0]
+	}
+
+	filters, err := model.ParsePartitionList(namespace, partitionList)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) != 1 && model.HasDigestFilter(filters) {
+		return errors.New("digest filters require exactly one namespace in the routine")
+	}
+
 	return nil
 }
 
@@ -97,6 +189,28 @@ func (r *BackupRoutine) ToModel(config *model.Config) (*model.BackupRoutine, err
 		return nil, notFoundValidationError("storage", r.Storage)
 	}
 
+	var replicateTo map[string]model.Storage
+	for _, name := range r.ReplicateTo {
+		replica, found := config.Storage[name]
+		if !found {
+			return nil, notFoundValidationError("storage", name)
+		}
+		if replicateTo == nil {
+			replicateTo = make(map[string]model.Storage, len(r.ReplicateTo))
+		}
+		replicateTo[name] = replica
+	}
+
+	tiering, err := r.Tiering.ToModel(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var historicalStorages []model.Storage
+	for _, s := range r.HistoricalStorages {
+		historicalStorages = append(historicalStorages, s.ToModel())
+	}
+
 	var secretAgent *model.SecretAgent
 	if r.SecretAgent != nil {
 		secretAgent, found = config.SecretAgents[*r.SecretAgent]
@@ -105,18 +219,32 @@ func (r *BackupRoutine) ToModel(config *model.Config) (*model.BackupRoutine, err
 		}
 	}
 
+	var timeZone *time.Location
+	if r.TimeZone != nil {
+		// validated before
+		timeZone, _ = time.LoadLocation(*r.TimeZone)
+	}
+
 	return &model.BackupRoutine{
-		BackupPolicy:     policy,
-		SourceCluster:    cluster,
-		Storage:          storage,
-		SecretAgent:      secretAgent,
-		IntervalCron:     r.IntervalCron,
-		IncrIntervalCron: r.IncrIntervalCron,
-		Namespaces:       r.Namespaces,
-		SetList:          r.SetList,
-		BinList:          r.BinList,
-		PreferRacks:      r.PreferRacks,
-		PartitionList:    r.PartitionList,
+		BackupPolicy:       policy,
+		SourceCluster:      cluster,
+		Storage:            storage,
+		ReplicateTo:        replicateTo,
+		Tiering:            tiering,
+		HistoricalStorages: historicalStorages,
+		StoragePrefix:      r.StoragePrefix,
+		SecretAgent:        secretAgent,
+		IntervalCron:       r.IntervalCron,
+		IncrIntervalCron:   r.IncrIntervalCron,
+		VerifyIntervalCron: r.VerifyIntervalCron,
+		TimeZone:           timeZone,
+		Namespaces:         r.Namespaces,
+		SetList:            r.SetList,
+		BinList:            r.BinList,
+		PreferRacks:        r.PreferRacks,
+		PartitionList:      r.PartitionList,
+		Disabled:           r.Disabled,
+		BlackoutWindows:    blackoutWindowsToModel(r.BlackoutWindows),
 	}, nil
 }
 
@@ -148,16 +276,33 @@ func (r *BackupRoutine) fromModel(m *model.BackupRoutine, config *model.Config)
 	r.BackupPolicy = findKeyByValue(config.BackupPolicies, m.BackupPolicy)
 	r.SourceCluster = findKeyByValue(config.AerospikeClusters, m.SourceCluster)
 	r.Storage = findStorageKey(config.Storage, m.Storage)
+	r.ReplicateTo = nil
+	for name := range m.ReplicateTo {
+		r.ReplicateTo = append(r.ReplicateTo, name)
+	}
+	slices.Sort(r.ReplicateTo)
+	r.Tiering = newTieringPolicyFromModel(m.Tiering, config)
+	r.HistoricalStorages = nil
+	for _, s := range m.HistoricalStorages {
+		r.HistoricalStorages = append(r.HistoricalStorages, NewStorageFromModel(s))
+	}
+	r.StoragePrefix = m.StoragePrefix
 	if m.SecretAgent != nil {
 		r.SecretAgent = ptr.String(findKeyByValue(config.SecretAgents, m.SecretAgent))
 	}
 	r.IntervalCron = m.IntervalCron
 	r.IncrIntervalCron = m.IncrIntervalCron
+	r.VerifyIntervalCron = m.VerifyIntervalCron
+	if m.TimeZone != nil {
+		r.TimeZone = ptr.String(m.TimeZone.String())
+	}
 	r.Namespaces = m.Namespaces
 	r.SetList = m.SetList
 	r.BinList = m.BinList
 	r.PreferRacks = m.PreferRacks
 	r.PartitionList = m.PartitionList
+	r.Disabled = m.Disabled
+	r.BlackoutWindows = blackoutWindowsFromModel(m.BlackoutWindows)
 }
 
 func findKeyByValue[V any](m map[string]*V, value *V) string {
@@ -177,3 +322,15 @@ func findStorageKey(storageMap map[string]model.Storage, targetStorage model.Sto
 	}
 	return ""
 }
+
+// BackupRoutineWithSchedule is a backup routine with its next scheduled backup times.
+// @Description BackupRoutineWithSchedule is a backup routine with its next scheduled backup times.
+//
+//nolint:lll
+type BackupRoutineWithSchedule struct {
+	BackupRoutine `yaml:",inline"`
+	// The next scheduled full backup time, empty if the routine is disabled.
+	NextFullBackup *time.Time `yaml:"next-full-backup,omitempty" json:"next-full-backup,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	// The next scheduled incremental backup time, empty if incremental backups are not scheduled.
+	NextIncrementalBackup *time.Time `yaml:"next-incremental-backup,omitempty" json:"next-incremental-backup,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+}
@@ -9,6 +9,12 @@ type BackupServiceConfig struct {
 	HTTPServer *HTTPServerConfig `yaml:"http,omitempty" json:"http,omitempty"`
 	// Logger is the backup service logger configuration.
 	Logger *LoggerConfig `yaml:"logger,omitempty" json:"logger,omitempty"`
+	// Scheduled backups of all routines are not run during the blackout windows.
+	BlackoutWindows []*BlackoutWindow `yaml:"blackout-windows,omitempty" json:"blackout-windows,omitempty"`
+	// Limits of concurrently running backup jobs (optional).
+	ConcurrencyLimits *ConcurrencyLimits `yaml:"concurrency-limits,omitempty" json:"concurrency-limits,omitempty"`
+	// Removal of incomplete backups (optional).
+	GarbageCollector *GarbageCollector `yaml:"garbage-collector,omitempty" json:"garbage-collector,omitempty"`
 }
 
 // NewBackupServiceConfigWithDefaultValues returns a new BackupServiceConfig with default values.
@@ -21,8 +27,11 @@ func NewBackupServiceConfigWithDefaultValues() BackupServiceConfig {
 
 func (b *BackupServiceConfig) ToModel() *model.BackupServiceConfig {
 	return &model.BackupServiceConfig{
-		HTTPServer: b.HTTPServer.ToModel(),
-		Logger:     b.Logger.ToModel(),
+		HTTPServer:        b.HTTPServer.ToModel(),
+		Logger:            b.Logger.ToModel(),
+		BlackoutWindows:   blackoutWindowsToModel(b.BlackoutWindows),
+		ConcurrencyLimits: b.ConcurrencyLimits.ToModel(),
+		GarbageCollector:  b.GarbageCollector.ToModel(),
 	}
 }
 
@@ -39,4 +48,16 @@ func (b *BackupServiceConfig) fromModel(m *model.BackupServiceConfig) {
 		b.Logger = &LoggerConfig{}
 		b.Logger.fromModel(m.Logger)
 	}
+
+	b.BlackoutWindows = blackoutWindowsFromModel(m.BlackoutWindows)
+
+	if m.ConcurrencyLimits != nil {
+		b.ConcurrencyLimits = &ConcurrencyLimits{}
+		b.ConcurrencyLimits.fromModel(m.ConcurrencyLimits)
+	}
+
+	if m.GarbageCollector != nil {
+		b.GarbageCollector = &GarbageCollector{}
+		b.GarbageCollector.fromModel(m.GarbageCollector)
+	}
 }
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// BackupVerification contains the verification results of a backup.
+// @Description BackupVerification contains the verification results of a backup.
+type BackupVerification struct {
+	// The verification of the backup is running.
+	Running bool `json:"running,omitempty" example:"false"`
+	// The results of the last verification, per namespace.
+	Results []VerificationResult `json:"results,omitempty"`
+}
+
+// VerificationResult is the result of reading back the backup of a namespace.
+// @Description VerificationResult is the result of reading back the backup of a namespace.
+//
+//nolint:lll
+type VerificationResult struct {
+	// The namespace of the verified backup.
+	Namespace string `json:"namespace" example:"testNamespace"`
+	// The creation time of the verified backup.
+	Created time.Time `json:"created" example:"2023-03-20T14:50:00Z"`
+	// The time the verification finished.
+	Verified time.Time `json:"verified" example:"2023-03-27T03:00:00Z"`
+	// The verification outcome.
+	Status string `json:"status" enums:"passed,failed"`
+	// The number of records read from the backup files.
+	RecordCount uint64 `json:"record-count" format:"int64" example:"100"`
+	// The number of records in the backup metadata.
+	ExpectedRecordCount uint64 `json:"expected-record-count" format:"int64" example:"100"`
+	// The number of bytes read from the storage.
+	ByteCount uint64 `json:"byte-count" format:"int64" example:"2000"`
+	// The number of bytes in the backup metadata.
+	ExpectedByteCount uint64 `json:"expected-byte-count" format:"int64" example:"2000"`
+	// The number of backup files read.
+	FileCount uint64 `json:"file-count" format:"int64" example:"1"`
+	// The reason of a failed verification.
+	Error string `json:"error,omitempty" example:"record count mismatch: expected 100, read 90"`
+}
+
+// NewBackupVerificationFromModel creates a new BackupVerification from the model.
+func NewBackupVerificationFromModel(m *model.BackupVerification) *BackupVerification {
+	if m == nil {
+		return nil
+	}
+
+	v := &BackupVerification{Running: m.Running}
+	for _, r := range m.Results {
+		v.Results = append(v.Results, VerificationResult{
+			Namespace:           r.Namespace,
+			Created:             r.Created,
+			Verified:            r.Verified,
+			Status:              string(r.Status),
+			RecordCount:         r.RecordCount,
+			ExpectedRecordCount: r.ExpectedRecordCount,
+			ByteCount:           r.ByteCount,
+			ExpectedByteCount:   r.ExpectedByteCount,
+			FileCount:           r.FileCount,
+			Error:               r.Error,
+		})
+	}
+	return v
+}
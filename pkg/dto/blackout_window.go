@@ -0,0 +1,212 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/reugn/go-quartz/quartz"
+)
+
+const (
+	// BlackoutActionSkip skips the runs that fall inside the window.
+	BlackoutActionSkip = "skip"
+	// BlackoutActionDefer defers the runs that fall inside the window until it ends.
+	BlackoutActionDefer = "defer"
+
+	timeOfDayLayout = "15:04"
+)
+
+// BlackoutWindow defines a period of time when scheduled backups are not run.
+// The window is set either by a cron expression and a duration,
+// or by a daily time range.
+// @Description BlackoutWindow defines a period of time when scheduled backups are not run.
+//
+//nolint:lll
+type BlackoutWindow struct {
+	// The start of the recurring window as a cron expression, requires duration.
+	Cron *string `yaml:"cron,omitempty" json:"cron,omitempty" example:"0 0 1 * * *"`
+	// The duration of the window started by the cron expression, e.g. 2h, 1d.
+	Duration *string `yaml:"duration,omitempty" json:"duration,omitempty" example:"2h"`
+	// The start of the daily window in HH:MM format.
+	From *string `yaml:"from,omitempty" json:"from,omitempty" example:"22:00"`
+	// The end of the daily window in HH:MM format, the window ends on the next day if it is not after from.
+	To *string `yaml:"to,omitempty" json:"to,omitempty" example:"02:00"`
+	// The IANA time zone of the window, UTC by default.
+	TimeZone *string `yaml:"time-zone,omitempty" json:"time-zone,omitempty" example:"Europe/Berlin"`
+	// What to do with the runs that fall inside the window: skip (default) or defer until the window ends.
+	Action *string `yaml:"action,omitempty" json:"action,omitempty" example:"skip" enums:"skip,defer"`
+	// Cancel running scheduled backups when the window starts.
+	CancelRunning bool `yaml:"cancel-running,omitempty" json:"cancel-running,omitempty" example:"false"`
+}
+
+// Validate validates the blackout window.
+func (w *BlackoutWindow) Validate() error {
+	if w == nil {
+		return errors.New("blackout window is empty")
+	}
+	switch {
+	case w.Cron != nil:
+		if w.From != nil || w.To != nil {
+			return errors.New("cron and from/to cannot be set together")
+		}
+		if err := quartz.ValidateCronExpression(*w.Cron); err != nil {
+			return fmt.Errorf("cron '%s' invalid: %w", *w.Cron, err)
+		}
+		if w.Duration == nil {
+			return emptyFieldValidationError("duration")
+		}
+		duration, err := util.ParseDuration(*w.Duration)
+		if err != nil {
+			return fmt.Errorf("duration %s invalid: %w", *w.Duration, err)
+		}
+		if duration <= 0 {
+			return fmt.Errorf("duration %s invalid, should be positive duration", *w.Duration)
+		}
+	case w.From != nil || w.To != nil:
+		if w.From == nil {
+			return emptyFieldValidationError("from")
+		}
+		if w.To == nil {
+			return emptyFieldValidationError("to")
+		}
+		if w.Duration != nil {
+			return errors.New("duration can be set with cron only")
+		}
+		if _, err := time.Parse(timeOfDayLayout, *w.From); err != nil {
+			return fmt.Errorf("from '%s' invalid, should be in HH:MM format", *w.From)
+		}
+		if _, err := time.Parse(timeOfDayLayout, *w.To); err != nil {
+			return fmt.Errorf("to '%s' invalid, should be in HH:MM format", *w.To)
+		}
+		if *w.From == *w.To {
+			return errors.New("from and to should be different")
+		}
+	default:
+		return errors.New("either cron and duration or from and to should be set")
+	}
+	if w.TimeZone != nil {
+		if _, err := time.LoadLocation(*w.TimeZone); err != nil {
+			return fmt.Errorf("time zone '%s' invalid: %w", *w.TimeZone, err)
+		}
+	}
+	if w.Action != nil && *w.Action != BlackoutActionSkip && *w.Action != BlackoutActionDefer {
+		return fmt.Errorf("action '%s' invalid, should be %s or %s",
+			*w.Action, BlackoutActionSkip, BlackoutActionDefer)
+	}
+
+	return nil
+}
+
+// ToModel converts the validated blackout window to the model.
+func (w *BlackoutWindow) ToModel() *model.BlackoutWindow {
+	location := time.UTC
+	if w.TimeZone != nil {
+		// validated before
+		location, _ = time.LoadLocation(*w.TimeZone)
+	}
+
+	m := &model.BlackoutWindow{
+		Location:      location,
+		Defer:         w.Action != nil && *w.Action == BlackoutActionDefer,
+		CancelRunning: w.CancelRunning,
+	}
+
+	if w.Cron != nil {
+		m.Cron = *w.Cron
+		// validated before
+		m.Duration, _ = util.ParseDuration(*w.Duration)
+		return m
+	}
+
+	// validated before
+	from, _ := time.Parse(timeOfDayLayout, *w.From)
+	to, _ := time.Parse(timeOfDayLayout, *w.To)
+	m.From = sinceMidnight(from)
+	m.To = sinceMidnight(to)
+
+	return m
+}
+
+func (w *BlackoutWindow) fromModel(m *model.BlackoutWindow) {
+	if m.Cron != "" {
+		w.Cron = util.Ptr(m.Cron)
+		w.Duration = util.Ptr(formatDuration(m.Duration))
+	} else {
+		w.From = util.Ptr(formatTimeOfDay(m.From))
+		w.To = util.Ptr(formatTimeOfDay(m.To))
+	}
+	if m.Location != nil && m.Location != time.UTC {
+		w.TimeZone = util.Ptr(m.Location.String())
+	}
+	if m.Defer {
+		w.Action = util.Ptr(BlackoutActionDefer)
+	}
+	w.CancelRunning = m.CancelRunning
+}
+
+func blackoutWindowsToModel(windows []*BlackoutWindow) []*model.BlackoutWindow {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	result := make([]*model.BlackoutWindow, 0, len(windows))
+	for _, w := range windows {
+		result = append(result, w.ToModel())
+	}
+
+	return result
+}
+
+func blackoutWindowsFromModel(windows []*model.BlackoutWindow) []*BlackoutWindow {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	result := make([]*BlackoutWindow, 0, len(windows))
+	for _, m := range windows {
+		w := &BlackoutWindow{}
+		w.fromModel(m)
+		result = append(result, w)
+	}
+
+	return result
+}
+
+func validateBlackoutWindows(windows []*BlackoutWindow) error {
+	for i, w := range windows {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("blackout window %d invalid: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatDuration formats the duration in whole days if possible,
+// omitting the zero minutes and seconds otherwise, e.g. 2h instead of 2h0m0s.
+func formatDuration(d time.Duration) string {
+	day := 24 * time.Hour
+	if d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
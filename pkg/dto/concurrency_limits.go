@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// ConcurrencyLimits limits the number of concurrently running backup jobs.
+// Jobs exceeding the limits wait in a queue and are started in the order of arrival.
+// @Description ConcurrencyLimits limits the number of concurrently running backup jobs.
+//
+//nolint:lll
+type ConcurrencyLimits struct {
+	// The maximum number of concurrent backup jobs of the service.
+	MaxBackups *int `yaml:"max-backups,omitempty" json:"max-backups,omitempty" example:"4"`
+	// The default maximum number of concurrent backup jobs per cluster.
+	MaxBackupsPerCluster *int `yaml:"max-backups-per-cluster,omitempty" json:"max-backups-per-cluster,omitempty" example:"2"`
+	// The default maximum number of concurrent backup jobs per storage.
+	MaxBackupsPerStorage *int `yaml:"max-backups-per-storage,omitempty" json:"max-backups-per-storage,omitempty" example:"2"`
+	// The maximum number of concurrent backup jobs by cluster name, overrides max-backups-per-cluster.
+	Clusters map[string]int `yaml:"clusters,omitempty" json:"clusters,omitempty"`
+	// The maximum number of concurrent backup jobs by storage name, overrides max-backups-per-storage.
+	Storage map[string]int `yaml:"storage,omitempty" json:"storage,omitempty"`
+}
+
+// Validate validates the concurrency limits.
+func (l *ConcurrencyLimits) Validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.MaxBackups != nil && *l.MaxBackups <= 0 {
+		return fmt.Errorf("max-backups %d invalid, should be positive number", *l.MaxBackups)
+	}
+	if l.MaxBackupsPerCluster != nil && *l.MaxBackupsPerCluster <= 0 {
+		return fmt.Errorf("max-backups-per-cluster %d invalid, should be positive number", *l.MaxBackupsPerCluster)
+	}
+	if l.MaxBackupsPerStorage != nil && *l.MaxBackupsPerStorage <= 0 {
+		return fmt.Errorf("max-backups-per-storage %d invalid, should be positive number", *l.MaxBackupsPerStorage)
+	}
+	for name, limit := range l.Clusters {
+		if limit <= 0 {
+			return fmt.Errorf("limit %d of cluster %s invalid, should be positive number", limit, name)
+		}
+	}
+	for name, limit := range l.Storage {
+		if limit <= 0 {
+			return fmt.Errorf("limit %d of storage %s invalid, should be positive number", limit, name)
+		}
+	}
+	return nil
+}
+
+func (l *ConcurrencyLimits) ToModel() *model.ConcurrencyLimits {
+	if l == nil {
+		return nil
+	}
+
+	return &model.ConcurrencyLimits{
+		MaxBackups:           l.MaxBackups,
+		MaxBackupsPerCluster: l.MaxBackupsPerCluster,
+		MaxBackupsPerStorage: l.MaxBackupsPerStorage,
+		Clusters:             maps.Clone(l.Clusters),
+		Storage:              maps.Clone(l.Storage),
+	}
+}
+
+func (l *ConcurrencyLimits) fromModel(m *model.ConcurrencyLimits) {
+	l.MaxBackups = m.MaxBackups
+	l.MaxBackupsPerCluster = m.MaxBackupsPerCluster
+	l.MaxBackupsPerStorage = m.MaxBackupsPerStorage
+	l.Clusters = maps.Clone(m.Clusters)
+	l.Storage = maps.Clone(m.Storage)
+}
@@ -93,6 +93,9 @@ func (c *Config) Validate() error {
 		if err := routine.Validate(); err != nil {
 			return fmt.Errorf("backup routine '%s' validation error: %s", name, err.Error())
 		}
+		if prefix := routine.StoragePrefix; prefix != "" && prefix != name && c.BackupRoutines[prefix] != nil {
+			return fmt.Errorf("backup routine '%s' storage prefix is the name of another routine", name)
+		}
 	}
 
 	for name, storage := range c.Storage {
@@ -130,10 +133,43 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := validateBlackoutWindows(c.ServiceConfig.BlackoutWindows); err != nil {
+		return fmt.Errorf("service config validation error: %w", err)
+	}
+
+	if err := c.validateConcurrencyLimits(); err != nil {
+		return fmt.Errorf("concurrency limits validation error: %w", err)
+	}
+
+	if err := c.ServiceConfig.GarbageCollector.Validate(); err != nil {
+		return fmt.Errorf("garbage collector validation error: %w", err)
+	}
+
 	_, err := c.ToModel() // reference validation is happening in the model
 	return err
 }
 
+func (c *Config) validateConcurrencyLimits() error {
+	limits := c.ServiceConfig.ConcurrencyLimits
+	if limits == nil {
+		return nil
+	}
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	for name := range limits.Clusters {
+		if _, found := c.AerospikeClusters[name]; !found {
+			return notFoundValidationError("Aerospike cluster", name)
+		}
+	}
+	for name := range limits.Storage {
+		if _, found := c.Storage[name]; !found {
+			return notFoundValidationError("storage", name)
+		}
+	}
+	return nil
+}
+
 func (c *Config) ToModel() (*model.Config, error) {
 	config := c.ServiceConfig
 	modelConfig := &model.Config{
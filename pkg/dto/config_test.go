@@ -3,6 +3,10 @@ package dto
 import (
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
 )
 
 func validConfig() *Config {
@@ -91,3 +95,219 @@ func TestInvalidStorageReference(t *testing.T) {
 		t.Errorf("Expected error message '%s', but got '%s'", expectedError, err.Error())
 	}
 }
+
+func TestEmptyRetentionPolicy(t *testing.T) {
+	config := validConfig()
+	config.BackupPolicies["policy1"].Retention = &RetentionPolicy{}
+
+	if err := config.Validate(); err == nil {
+		t.Fatalf("Expected validation error, but got none.")
+	}
+}
+
+func TestNamespaceParallelismValidation(t *testing.T) {
+	invalid := []func(*BackupPolicy){
+		func(p *BackupPolicy) { p.ParallelNamespaces = util.Ptr(0) },
+		func(p *BackupPolicy) { p.NamespaceOrder = util.Ptr(NamespaceOrder("random")) },
+		func(p *BackupPolicy) { p.NamespacePriority = []string{"source-ns1", "source-ns1"} },
+		func(p *BackupPolicy) { p.NamespacePriority = []string{""} },
+	}
+	for i, update := range invalid {
+		config := validConfig()
+		update(config.BackupPolicies["policy1"])
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected validation error for case %d, but got none.", i)
+		}
+	}
+
+	config := validConfig()
+	policy := config.BackupPolicies["policy1"]
+	policy.ParallelNamespaces = util.Ptr(1)
+	policy.NamespaceOrder = util.Ptr(NamespaceOrderSize)
+	policy.NamespacePriority = []string{"source-ns1"}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+}
+
+func TestRetryBackoffValidation(t *testing.T) {
+	invalid := []func(*BackupPolicy){
+		func(p *BackupPolicy) { p.RetryMaxDelay = util.Ptr(int32(0)) },
+		func(p *BackupPolicy) { p.RetryMultiplier = util.Ptr(0.5) },
+	}
+	for i, update := range invalid {
+		config := validConfig()
+		update(config.BackupPolicies["policy1"])
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected validation error for case %d, but got none.", i)
+		}
+	}
+
+	config := validConfig()
+	policy := config.BackupPolicies["policy1"]
+	policy.RetryMaxDelay = util.Ptr(int32(60_000))
+	policy.RetryMultiplier = util.Ptr(1.0)
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+}
+
+func TestGarbageCollectorValidation(t *testing.T) {
+	for _, gc := range []*GarbageCollector{
+		{Interval: util.Ptr("0h")},
+		{MinAge: util.Ptr("1x")},
+	} {
+		config := validConfig()
+		config.ServiceConfig.GarbageCollector = gc
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected validation error for %+v, but got none.", gc)
+		}
+	}
+
+	config := validConfig()
+	config.ServiceConfig.GarbageCollector = &GarbageCollector{Interval: util.Ptr("30m"), MinAge: util.Ptr("2d")}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	m := config.ServiceConfig.GarbageCollector.ToModel()
+	if m.GetIntervalOrDefault() != 30*time.Minute || m.GetMinAgeOrDefault() != 48*time.Hour {
+		t.Errorf("Unexpected garbage collector model %+v", m)
+	}
+}
+
+func TestVerifyIntervalValidation(t *testing.T) {
+	config := validConfig()
+	config.BackupRoutines["routine1"].VerifyIntervalCron = "every sunday"
+	if err := config.Validate(); err == nil {
+		t.Errorf("Expected validation error, but got none.")
+	}
+
+	config.BackupRoutines["routine1"].VerifyIntervalCron = "0 0 3 ? * SUN"
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+}
+
+func TestReplicateToValidation(t *testing.T) {
+	config := validConfig()
+	routine := config.BackupRoutines["routine1"]
+	for _, replicateTo := range [][]string{{""}, {"storage1"}, {"storage2", "storage2"}, {"nonExistentStorage"}} {
+		routine.ReplicateTo = replicateTo
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected validation error for %v, but got none.", replicateTo)
+		}
+	}
+
+	config.Storage["storage3"] = &Storage{LocalStorage: &LocalStorage{"/replica"}}
+	routine.ReplicateTo = []string{"storage3"}
+	m, err := config.ToModel()
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	if m.BackupRoutines["routine1"].ReplicateTo["storage3"] != m.Storage["storage3"] {
+		t.Errorf("Expected replica storage3, got %v", m.BackupRoutines["routine1"].ReplicateTo)
+	}
+	if err := m.DeleteStorage("storage3"); err == nil {
+		t.Errorf("Expected error deleting the replica storage, but got none.")
+	}
+}
+
+func TestTieringValidation(t *testing.T) {
+	config := validConfig()
+	routine := config.BackupRoutines["routine1"]
+	for _, tiering := range []*TieringPolicy{
+		{After: "7d"},
+		{After: "-1d", MoveTo: "storage2"},
+		{After: "week", MoveTo: "storage2"},
+		{After: "7d", MoveTo: "storage1"},
+		{After: "7d", MoveTo: "nonExistentStorage"},
+	} {
+		routine.Tiering = tiering
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected validation error for %+v, but got none.", tiering)
+		}
+	}
+
+	routine.Tiering = &TieringPolicy{After: "7d", MoveTo: "storage2"}
+	m, err := config.ToModel()
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	tiering := m.BackupRoutines["routine1"].Tiering
+	if tiering.After != 7*24*time.Hour || tiering.MoveTo != m.Storage["storage2"] {
+		t.Errorf("Unexpected tiering model %+v", tiering)
+	}
+	if got := newTieringPolicyFromModel(tiering, m); *got != *routine.Tiering {
+		t.Errorf("Expected %+v, got %+v", routine.Tiering, got)
+	}
+}
+
+func TestHistoricalStorages(t *testing.T) {
+	config := validConfig()
+	routine := config.BackupRoutines["routine1"]
+	routine.HistoricalStorages = []*Storage{{}}
+	if err := config.Validate(); err == nil {
+		t.Errorf("Expected validation error for empty historical storage, but got none.")
+	}
+	routine.HistoricalStorages = nil
+
+	m, err := config.ToModel()
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	previous := m.Storage["storage1"]
+	if err := m.UpdateStorage("storage1", &model.LocalStorage{Path: "/new"}); err != nil {
+		t.Fatalf("Unexpected error updating storage: %v", err)
+	}
+	historical := m.BackupRoutines["routine1"].HistoricalStorages
+	if len(historical) != 1 || historical[0] != previous {
+		t.Fatalf("Expected previous storage to be historical, got %v", historical)
+	}
+
+	// the historical storages are kept when the routine is updated
+	updated := NewRoutineFromModel(m.BackupRoutines["routine1"], m)
+	if len(updated.HistoricalStorages) != 1 || updated.HistoricalStorages[0].LocalStorage.Path != "/" {
+		t.Fatalf("Unexpected historical storages %v", updated.HistoricalStorages)
+	}
+	updatedModel, err := updated.ToModel(m)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updatedModel.HistoricalStorages = nil
+	if err := m.UpdateRoutine("routine1", updatedModel); err != nil {
+		t.Fatalf("Unexpected error updating routine: %v", err)
+	}
+	if len(m.BackupRoutines["routine1"].HistoricalStorages) != 1 {
+		t.Errorf("Expected historical storage to be kept, got %v", m.BackupRoutines["routine1"].HistoricalStorages)
+	}
+
+	if err := m.RemoveHistoricalStorages("routine1", []model.Storage{&model.LocalStorage{Path: "/"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(m.BackupRoutines["routine1"].HistoricalStorages) != 0 {
+		t.Errorf("Expected no historical storages, got %v", m.BackupRoutines["routine1"].HistoricalStorages)
+	}
+}
+
+func TestStoragePrefixValidation(t *testing.T) {
+	config := validConfig()
+	config.BackupRoutines["routine1"].StoragePrefix = "routine2"
+	if err := config.Validate(); err == nil {
+		t.Errorf("Expected validation error for storage prefix of another routine, but got none.")
+	}
+
+	config.BackupRoutines["routine1"].StoragePrefix = "old"
+	m, err := config.ToModel()
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	if err := m.AddRoutine("old", m.BackupRoutines["routine2"]); !errors.Is(err, model.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists adding a routine named as a storage prefix, got %v", err)
+	}
+	if err := m.RenameRoutine("routine1", "old"); err != nil {
+		t.Fatalf("Unexpected error renaming routine: %v", err)
+	}
+	if prefix := m.BackupRoutines["old"].StoragePrefix; prefix != "" {
+		t.Errorf("Expected no storage prefix after renaming back, got %q", prefix)
+	}
+}
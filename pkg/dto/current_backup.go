@@ -12,6 +12,9 @@ type CurrentBackups struct {
 	Full *RunningJob `json:"full,omitempty"`
 	// Incremental represents the state of an incremental backup. Nil if no incremental backup is running.
 	Incremental *RunningJob `json:"incremental,omitempty"`
+	// Retry represents the state of the full backup retries. Nil if the last
+	// full backup is not being retried.
+	Retry *RetryStatus `json:"retry,omitempty"`
 }
 
 func NewCurrentBackupsFromModel(m *model.CurrentBackups) *CurrentBackups {
@@ -27,6 +30,28 @@ func NewCurrentBackupsFromModel(m *model.CurrentBackups) *CurrentBackups {
 func (c *CurrentBackups) fromModel(m *model.CurrentBackups) {
 	c.Full = NewRunningJobFromModel(m.Full)
 	c.Incremental = NewRunningJobFromModel(m.Incremental)
+	c.Retry = NewRetryStatusFromModel(m.Retry)
+}
+
+// RetryStatus represents the state of the retries of a failed full backup.
+// @Description RetryStatus represents the state of the retries of a failed full backup.
+type RetryStatus struct {
+	// Attempt is the number of the current retry attempt, starting from 1.
+	Attempt int32 `json:"attempt" example:"1"`
+	// NextRetryTime is the time of the pending retry.
+	// Nil if the retry attempt is running.
+	NextRetryTime *time.Time `json:"next-retry-time,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+}
+
+func NewRetryStatusFromModel(m *model.RetryStatus) *RetryStatus {
+	if m == nil {
+		return nil
+	}
+
+	return &RetryStatus{
+		Attempt:       m.Attempt,
+		NextRetryTime: m.NextRetryTime,
+	}
 }
 
 // RunningJob tracks progress of currently running job.
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+)
+
+// GarbageCollector configures the removal of incomplete backups, i.e. backup
+// folders without metadata, left by failed backups or service crashes.
+// The changes are applied on restart.
+// @Description GarbageCollector configures the removal of incomplete backups.
+//
+//nolint:lll
+type GarbageCollector struct {
+	// Incomplete backups are not removed periodically if disabled.
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty" example:"false"`
+	// The interval between the runs, the first run is on startup (default: 1h).
+	Interval *string `yaml:"interval,omitempty" json:"interval,omitempty" example:"1h"`
+	// Only the incomplete backups older than min-age are removed (default: 1d).
+	MinAge *string `yaml:"min-age,omitempty" json:"min-age,omitempty" example:"1d"`
+}
+
+// Validate validates the garbage collector configuration.
+func (g *GarbageCollector) Validate() error {
+	if g == nil {
+		return nil
+	}
+	if err := validatePositiveDuration("interval", g.Interval); err != nil {
+		return err
+	}
+	return validatePositiveDuration("min-age", g.MinAge)
+}
+
+func validatePositiveDuration(name string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	duration, err := util.ParseDuration(*value)
+	if err != nil {
+		return fmt.Errorf("%s %s invalid: %w", name, *value, err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("%s %s invalid, should be positive duration", name, *value)
+	}
+	return nil
+}
+
+func (g *GarbageCollector) ToModel() *model.GarbageCollector {
+	if g == nil {
+		return nil
+	}
+
+	m := &model.GarbageCollector{Disabled: g.Disabled}
+	// validated before
+	if g.Interval != nil {
+		m.Interval, _ = util.ParseDuration(*g.Interval)
+	}
+	if g.MinAge != nil {
+		m.MinAge, _ = util.ParseDuration(*g.MinAge)
+	}
+	return m
+}
+
+func (g *GarbageCollector) fromModel(m *model.GarbageCollector) {
+	g.Disabled = m.Disabled
+	if m.Interval > 0 {
+		g.Interval = util.Ptr(formatDuration(m.Interval))
+	}
+	if m.MinAge > 0 {
+		g.MinAge = util.Ptr(formatDuration(m.MinAge))
+	}
+}
+
+// IncompleteBackup is a backup folder without metadata.
+// @Description IncompleteBackup is a backup folder without metadata.
+type IncompleteBackup struct {
+	// The name of the backup routine.
+	Routine string `json:"routine" example:"daily"`
+	// The path to the backup folder.
+	Path string `json:"path" example:"daily/backup/1707915600000"`
+	// The time the backup was started.
+	Created time.Time `json:"created" example:"2024-02-14T13:00:00Z"`
+}
+
+// NewIncompleteBackupsFromModel converts the incomplete backups to DTOs.
+func NewIncompleteBackupsFromModel(m []model.IncompleteBackup) []IncompleteBackup {
+	result := make([]IncompleteBackup, 0, len(m))
+	for _, b := range m {
+		result = append(result, IncompleteBackup{
+			Routine: b.Routine,
+			Path:    b.Path,
+			Created: b.Created,
+		})
+	}
+	return result
+}
@@ -64,6 +64,9 @@ type RestorePolicy struct {
 	// Amount of extra time-to-live to add to records that have expirable void-times.
 	// Must be set in seconds.
 	ExtraTTL *int64 `yaml:"extra-ttl" json:"extra-ttl,omitempty" example:"86400"`
+	// Verify the checksums of the backup metadata and files before restoring them.
+	// Backups without checksums cannot be restored with this option.
+	VerifyChecksums *bool `yaml:"verify-checksums,omitempty" json:"verify-checksums,omitempty" example:"false"`
 }
 
 // Validate validates the restore policy.
@@ -139,5 +142,6 @@ func (p *RestorePolicy) ToModel() *model.RestorePolicy {
 		CompressionPolicy:  p.CompressionPolicy.ToModel(),
 		RetryPolicy:        p.RetryPolicy.ToModel(),
 		ExtraTTL:           p.ExtraTTL,
+		VerifyChecksums:    p.VerifyChecksums,
 	}
 }
@@ -5,7 +5,9 @@ import "github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 type JobStatus string
 
 const (
+	JobStatusQueued  JobStatus = "Queued"
 	JobStatusRunning JobStatus = "Running"
+	JobStatusSkipped JobStatus = "Skipped"
 	JobStatusDone    JobStatus = "Done"
 	JobStatusFailed  JobStatus = "Failed"
 )
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+)
+
+// RetentionPolicy defines which full backups (and the incremental backups
+// depending on them) are kept. A backup is retained if it is kept by at least
+// one of the configured rules; the latest full backup is always retained.
+// @Description RetentionPolicy defines which full backups (and the incremental backups
+// @Description depending on them) are kept.
+//
+//nolint:lll
+type RetentionPolicy struct {
+	// The number of latest full backups to keep.
+	KeepLast *int `yaml:"keep-last,omitempty" json:"keep-last,omitempty" example:"5"`
+	// Full backups younger than max-age are kept, e.g. 12h, 30d, 4w.
+	MaxAge *string `yaml:"max-age,omitempty" json:"max-age,omitempty" example:"30d"`
+	// The number of days to keep the latest full backup of the day for.
+	Daily *int `yaml:"daily,omitempty" json:"daily,omitempty" example:"7"`
+	// The number of weeks to keep the latest full backup of the week for.
+	Weekly *int `yaml:"weekly,omitempty" json:"weekly,omitempty" example:"4"`
+	// The number of months to keep the latest full backup of the month for.
+	Monthly *int `yaml:"monthly,omitempty" json:"monthly,omitempty" example:"12"`
+}
+
+// Validate validates the retention policy.
+func (p *RetentionPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.KeepLast == nil && p.MaxAge == nil && p.Daily == nil && p.Weekly == nil && p.Monthly == nil {
+		return errors.New("at least one of keep-last, max-age, daily, weekly or monthly should be set")
+	}
+	if p.KeepLast != nil && *p.KeepLast <= 0 {
+		return fmt.Errorf("keep-last %d invalid, should be positive number", *p.KeepLast)
+	}
+	if p.MaxAge != nil {
+		maxAge, err := util.ParseDuration(*p.MaxAge)
+		if err != nil {
+			return fmt.Errorf("max-age %s invalid: %w", *p.MaxAge, err)
+		}
+		if maxAge <= 0 {
+			return fmt.Errorf("max-age %s invalid, should be positive duration", *p.MaxAge)
+		}
+	}
+	if p.Daily != nil && *p.Daily <= 0 {
+		return fmt.Errorf("daily %d invalid, should be positive number", *p.Daily)
+	}
+	if p.Weekly != nil && *p.Weekly <= 0 {
+		return fmt.Errorf("weekly %d invalid, should be positive number", *p.Weekly)
+	}
+	if p.Monthly != nil && *p.Monthly <= 0 {
+		return fmt.Errorf("monthly %d invalid, should be positive number", *p.Monthly)
+	}
+	return nil
+}
+
+func (p *RetentionPolicy) ToModel() *model.RetentionPolicy {
+	if p == nil {
+		return nil
+	}
+
+	return &model.RetentionPolicy{
+		KeepLast: p.KeepLast,
+		MaxAge:   p.MaxAge,
+		Daily:    p.Daily,
+		Weekly:   p.Weekly,
+		Monthly:  p.Monthly,
+	}
+}
+
+func (p *RetentionPolicy) fromModel(m *model.RetentionPolicy) {
+	p.KeepLast = m.KeepLast
+	p.MaxAge = m.MaxAge
+	p.Daily = m.Daily
+	p.Weekly = m.Weekly
+	p.Monthly = m.Monthly
+}
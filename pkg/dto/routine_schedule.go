@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// RoutineSchedule represents the schedule and the last runs of a backup routine.
+// @Description RoutineSchedule represents the schedule and the last runs of a backup routine.
+//
+//nolint:lll
+type RoutineSchedule struct {
+	// Disabled routines are not scheduled.
+	Disabled bool `json:"disabled,omitempty" example:"false"`
+	// The next fire times of the full backup trigger.
+	NextFullBackups []time.Time `json:"next-full-backups,omitempty"`
+	// The next fire times of the incremental backup trigger.
+	NextIncrementalBackups []time.Time `json:"next-incremental-backups,omitempty"`
+	// A catch-up full backup is scheduled on startup if the last full backup is older than the schedule interval.
+	CatchUpPending bool `json:"catch-up-pending,omitempty" example:"false"`
+	// The currently running backups.
+	Running *CurrentBackups `json:"running,omitempty"`
+	// Last time the full backup was performed.
+	LastFullRun *time.Time `json:"last-full-run,omitempty" example:"2023-12-14T10:08:54Z"`
+	// Last time the incremental backup was performed.
+	LastIncrementalRun *time.Time `json:"last-incremental-run,omitempty" example:"2023-12-15T12:00:00Z"`
+	// The status of the last incremental backup run.
+	LastIncrementalStatus string `json:"last-incremental-status,omitempty" enums:"succeeded,partially-failed,failed"`
+	// The namespaces which incremental backup failed in the last run.
+	// They are backed up from their last successful backup in the next run.
+	FailedNamespaces []string `json:"failed-namespaces,omitempty" example:"source-ns1"`
+	// The error message of the last failed backup run.
+	LastError string `json:"last-error,omitempty" example:"failed to connect to cluster"`
+	// Last time a backup run failed.
+	LastErrorTime *time.Time `json:"last-error-time,omitempty" example:"2023-12-15T11:00:00Z"`
+}
+
+// NewRoutineScheduleFromModel creates a new RoutineSchedule from the model.
+func NewRoutineScheduleFromModel(m *model.RoutineSchedule) *RoutineSchedule {
+	if m == nil {
+		return nil
+	}
+
+	s := &RoutineSchedule{}
+	s.fromModel(m)
+	return s
+}
+
+func (s *RoutineSchedule) fromModel(m *model.RoutineSchedule) {
+	s.Disabled = m.Disabled
+	s.NextFullBackups = m.NextFullBackups
+	s.NextIncrementalBackups = m.NextIncrementalBackups
+	s.CatchUpPending = m.CatchUpPending
+	s.Running = NewCurrentBackupsFromModel(m.Running)
+	if m.State != nil {
+		s.LastFullRun = timeOrNil(m.State.LastFullRun)
+		s.LastIncrementalRun = timeOrNil(m.State.LastIncrRun)
+		s.LastIncrementalStatus = string(m.State.LastIncrStatus)
+		s.FailedNamespaces = m.State.FailedNamespaces
+		s.LastError = m.State.LastError
+		s.LastErrorTime = timeOrNil(m.State.LastErrorTime)
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// StorageCheck is the result of checking that the service can write, list,
+// read and delete objects in a storage.
+// @Description StorageCheck is the result of checking that the service can write, list,
+// @Description read and delete objects in a storage.
+type StorageCheck struct {
+	// All the operations succeeded.
+	Success bool `json:"success" example:"false"`
+	// The first failed operation.
+	FailedOperation string `json:"failed-operation,omitempty" enums:"write,list,read,delete"`
+	// The results of the performed operations, in order.
+	Operations []StorageOperationResult `json:"operations"`
+}
+
+// StorageOperationResult is the result of an operation of the storage check.
+// @Description StorageOperationResult is the result of an operation of the storage check.
+type StorageOperationResult struct {
+	// The checked operation.
+	Operation string `json:"operation" enums:"write,list,read,delete"`
+	// The duration of the operation in milliseconds.
+	Latency int64 `json:"latency" format:"int64" example:"35"`
+	// The provider error of a failed operation.
+	Error string `json:"error,omitempty" example:"AccessDenied: Access Denied"`
+}
+
+// NewStorageCheckFromModel creates a new StorageCheck from the model.
+func NewStorageCheckFromModel(m *model.StorageCheck) *StorageCheck {
+	if m == nil {
+		return nil
+	}
+
+	c := &StorageCheck{
+		Success:         m.Success,
+		FailedOperation: string(m.FailedOperation),
+		Operations:      make([]StorageOperationResult, 0, len(m.Operations)),
+	}
+	for _, o := range m.Operations {
+		c.Operations = append(c.Operations, StorageOperationResult{
+			Operation: string(o.Operation),
+			Latency:   o.Latency.Milliseconds(),
+			Error:     o.Error,
+		})
+	}
+	return c
+}
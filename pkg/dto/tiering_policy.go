@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+)
+
+// TieringPolicy defines when the backups of a routine are moved to
+// a cheaper storage.
+// @Description TieringPolicy defines when the backups of a routine are moved to a cheaper storage.
+type TieringPolicy struct {
+	// The age of the backups to move, e.g. 12h, 7d, 4w.
+	After string `yaml:"after" json:"after" example:"7d" validate:"required"`
+	// The name of the storage to move the backups to.
+	MoveTo string `yaml:"move-to" json:"move-to" example:"archive" validate:"required"`
+}
+
+// Validate validates the tiering policy.
+func (p *TieringPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.After == "" {
+		return emptyFieldValidationError("tiering after")
+	}
+	after, err := util.ParseDuration(p.After)
+	if err != nil {
+		return fmt.Errorf("tiering after %s invalid: %w", p.After, err)
+	}
+	if after <= 0 {
+		return fmt.Errorf("tiering after %s invalid, should be positive duration", p.After)
+	}
+	if p.MoveTo == "" {
+		return emptyFieldValidationError("tiering move-to")
+	}
+	return nil
+}
+
+// ToModel converts the tiering policy to the model, resolving the storage.
+func (p *TieringPolicy) ToModel(config *model.Config) (*model.TieringPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	storage, found := config.Storage[p.MoveTo]
+	if !found {
+		return nil, notFoundValidationError("storage", p.MoveTo)
+	}
+	after, _ := util.ParseDuration(p.After) // validated before
+
+	return &model.TieringPolicy{
+		After:  after,
+		MoveTo: storage,
+	}, nil
+}
+
+func newTieringPolicyFromModel(m *model.TieringPolicy, config *model.Config) *TieringPolicy {
+	if m == nil {
+		return nil
+	}
+
+	return &TieringPolicy{
+		After:  formatDuration(m.After),
+		MoveTo: findStorageKey(config.Storage, m.MoveTo),
+	}
+}
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,8 +27,32 @@ type BackupMetadata struct {
 	Created time.Time `yaml:"created,omitempty" json:"created,omitempty" example:"2023-03-20T14:50:00Z"`
 	// The lower time bound of backup entities in the ISO 8601 format (for incremental backups).
 	From time.Time `yaml:"from,omitempty" json:"from,omitempty" example:"2023-03-19T14:50:00Z"`
+	// The upper time bound of backup entities in the ISO 8601 format.
+	To time.Time `yaml:"to,omitempty" json:"to,omitempty" example:"2023-03-20T14:50:00Z"`
 	// The namespace of a backup.
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty" example:"testNamespace"`
+	// The name of the backup routine.
+	Routine string `yaml:"routine,omitempty" json:"routine,omitempty" example:"daily"`
+	// The label of the source cluster in the service configuration.
+	ClusterLabel string `yaml:"cluster-label,omitempty" json:"cluster-label,omitempty" example:"cluster1"`
+	// The name of the source cluster reported by the Aerospike server.
+	ClusterName string `yaml:"cluster-name,omitempty" json:"cluster-name,omitempty" example:"prod"`
+	// The version of the backup service which created the backup.
+	ServiceVersion string `yaml:"service-version,omitempty" json:"service-version,omitempty" example:"2.0.0"`
+	// The type of the backup.
+	Type BackupType `yaml:"type,omitempty" json:"type,omitempty" example:"full"`
+	// Whether the backup was run on demand rather than on schedule.
+	AdHoc bool `yaml:"ad-hoc,omitempty" json:"ad-hoc,omitempty" example:"false"`
+	// The key of the full backup the incremental backup is based on.
+	Parent string `yaml:"parent,omitempty" json:"parent,omitempty" example:"daily/backup/1707915600000/source-ns1"`
+	// The compression mode of the backup files.
+	Compression string `yaml:"compression,omitempty" json:"compression,omitempty" example:"ZSTD"`
+	// The encryption mode of the backup files.
+	Encryption string `yaml:"encryption,omitempty" json:"encryption,omitempty" example:"AES256"`
+	// The backed up sets, empty if all sets were backed up.
+	SetList []string `yaml:"set-list,omitempty" json:"set-list,omitempty" example:"set1"`
+	// The backed up bins, empty if all bins were backed up.
+	BinList []string `yaml:"bin-list,omitempty" json:"bin-list,omitempty" example:"dataBin"`
 	// The total number of records backed up.
 	RecordCount uint64 `yaml:"record-count,omitempty" json:"record-count,omitempty" format:"int64" example:"100"`
 	// The size of the backup in bytes.
@@ -36,6 +63,78 @@ type BackupMetadata struct {
 	SecondaryIndexCount uint64 `yaml:"secondary-index-count,omitempty" json:"secondary-index-count,omitempty" format:"int64" example:"5"`
 	// The number of UDF files backed up.
 	UDFCount uint64 `yaml:"udf-count,omitempty" json:"udf-count,omitempty" format:"int64" example:"2"`
+	// The partition filters of a backup, empty if all partitions were backed up.
+	PartitionList string `yaml:"partition-list,omitempty" json:"partition-list,omitempty" example:"0-1000"`
+	// The start time of the namespace backup in the ISO 8601 format.
+	Started time.Time `yaml:"started,omitempty" json:"started,omitempty" example:"2023-03-20T14:50:01Z"`
+	// The end time of the namespace backup in the ISO 8601 format.
+	Finished time.Time `yaml:"finished,omitempty" json:"finished,omitempty" example:"2023-03-20T14:55:00Z"`
+	// The duration of the namespace backup in milliseconds.
+	Duration int64 `yaml:"duration,omitempty" json:"duration,omitempty" format:"int64" example:"299000"`
+	// The number of records backed up per second.
+	RecordsPerSecond uint64 `yaml:"records-per-second,omitempty" json:"records-per-second,omitempty" format:"int64" example:"1000"`
+	// The number of bytes written per second.
+	BytesPerSecond uint64 `yaml:"bytes-per-second,omitempty" json:"bytes-per-second,omitempty" format:"int64" example:"20000"`
+	// The data files of the backup.
+	Files []BackupFile `yaml:"files,omitempty" json:"files,omitempty"`
+	// The SHA-256 checksum of the metadata, see ComputeChecksum.
+	Checksum string `yaml:"checksum,omitempty" json:"checksum,omitempty"`
+}
+
+// BackupType is the type of backup.
+type BackupType string
+
+const (
+	// BackupTypeFull is a backup of all records.
+	BackupTypeFull BackupType = "full"
+	// BackupTypeIncremental is a backup of the records changed since the
+	// previous backup.
+	BackupTypeIncremental BackupType = "incremental"
+)
+
+// BackupFile is an entry of the backup file manifest.
+type BackupFile struct {
+	// The path of the file relative to the backup folder.
+	Path string `yaml:"path" json:"path" example:"source-ns1_1.asb"`
+	// The size of the file in bytes.
+	Size uint64 `yaml:"size" json:"size" format:"int64" example:"2000"`
+	// The hex encoded SHA-256 checksum of the file.
+	SHA256 string `yaml:"sha256" json:"sha256"`
+}
+
+// ErrChecksumMismatch is returned when the checksum of backup metadata or
+// files does not match their content.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ComputeChecksum returns the hex encoded SHA-256 checksum of the metadata
+// YAML without the checksum field.
+func (m BackupMetadata) ComputeChecksum() (string, error) {
+	m.Checksum = ""
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChecksum returns an error if the metadata has no checksum, or the
+// checksum does not match the metadata.
+func (m *BackupMetadata) VerifyChecksum() error {
+	if m.Checksum == "" {
+		return errors.New("backup metadata has no checksum")
+	}
+
+	checksum, err := m.ComputeChecksum()
+	if err != nil {
+		return err
+	}
+	if checksum != m.Checksum {
+		return fmt.Errorf("%w: backup metadata", ErrChecksumMismatch)
+	}
+
+	return nil
 }
 
 // NewMetadataFromBytes creates a new Metadata object from a byte slice
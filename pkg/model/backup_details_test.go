@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBackupMetadataChecksum(t *testing.T) {
+	metadata := BackupMetadata{
+		Created:     time.Date(2024, 2, 14, 13, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Namespace:   "test",
+		RecordCount: 100,
+		FileCount:   1,
+		Files:       []BackupFile{{Path: "test_1.asb", Size: 2000, SHA256: "abc"}},
+	}
+	require.Error(t, metadata.VerifyChecksum())
+
+	checksum, err := metadata.ComputeChecksum()
+	require.NoError(t, err)
+	metadata.Checksum = checksum
+
+	// the checksum survives a round trip through the metadata file
+	data, err := yaml.Marshal(metadata)
+	require.NoError(t, err)
+	read, err := NewMetadataFromBytes(data)
+	require.NoError(t, err)
+	require.NoError(t, read.VerifyChecksum())
+
+	read.RecordCount++
+	require.ErrorIs(t, read.VerifyChecksum(), ErrChecksumMismatch)
+
+	read.RecordCount--
+	read.Files[0].SHA256 = "abd"
+	require.ErrorIs(t, read.VerifyChecksum(), ErrChecksumMismatch)
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// BackupFilter selects backups by their metadata.
+// Empty fields match any backup.
+type BackupFilter struct {
+	// The namespace of the backup.
+	Namespace string
+	// The type of the backup.
+	Type BackupType
+	// Whether the backup was run on demand.
+	AdHoc *bool
+	// The label or the name of the source cluster.
+	Cluster string
+	// A set included in the backup.
+	Set string
+	// A bin included in the backup.
+	Bin string
+	// The compression mode of the backup.
+	Compression string
+	// The encryption mode of the backup.
+	Encryption string
+}
+
+// Matches returns true if the backup metadata satisfies the filter.
+// The backups of all sets or bins include any set or bin.
+func (f *BackupFilter) Matches(m *BackupMetadata) bool {
+	switch {
+	case f.Namespace != "" && f.Namespace != m.Namespace:
+		return false
+	case f.Type != "" && f.Type != m.Type:
+		return false
+	case f.AdHoc != nil && *f.AdHoc != m.AdHoc:
+		return false
+	case f.Cluster != "" && f.Cluster != m.ClusterLabel && f.Cluster != m.ClusterName:
+		return false
+	case f.Set != "" && len(m.SetList) > 0 && !slices.Contains(m.SetList, f.Set):
+		return false
+	case f.Bin != "" && len(m.BinList) > 0 && !slices.Contains(m.BinList, f.Bin):
+		return false
+	case f.Compression != "" && !strings.EqualFold(f.Compression, m.Compression):
+		return false
+	case f.Encryption != "" && !strings.EqualFold(f.Encryption, m.Encryption):
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the backups matching the filter, a nil filter matches all of them.
+func (f *BackupFilter) Filter(backups []BackupDetails) []BackupDetails {
+	if f == nil {
+		return backups
+	}
+
+	return slices.DeleteFunc(backups, func(b BackupDetails) bool {
+		return !f.Matches(&b.BackupMetadata)
+	})
+}
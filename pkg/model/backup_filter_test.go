@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupFilter_Matches(t *testing.T) {
+	metadata := &BackupMetadata{
+		Namespace:    "ns1",
+		Type:         BackupTypeIncremental,
+		AdHoc:        true,
+		ClusterLabel: "label",
+		ClusterName:  "name",
+		SetList:      []string{"set1", "set2"},
+		Compression:  "ZSTD",
+		Encryption:   "NONE",
+	}
+
+	tests := []struct {
+		name    string
+		filter  BackupFilter
+		matches bool
+	}{
+		{name: "Empty", filter: BackupFilter{}, matches: true},
+		{name: "Namespace", filter: BackupFilter{Namespace: "ns1"}, matches: true},
+		{name: "OtherNamespace", filter: BackupFilter{Namespace: "ns2"}, matches: false},
+		{name: "Type", filter: BackupFilter{Type: BackupTypeIncremental}, matches: true},
+		{name: "OtherType", filter: BackupFilter{Type: BackupTypeFull}, matches: false},
+		{name: "AdHoc", filter: BackupFilter{AdHoc: util.Ptr(true)}, matches: true},
+		{name: "Scheduled", filter: BackupFilter{AdHoc: util.Ptr(false)}, matches: false},
+		{name: "ClusterLabel", filter: BackupFilter{Cluster: "label"}, matches: true},
+		{name: "ClusterName", filter: BackupFilter{Cluster: "name"}, matches: true},
+		{name: "OtherCluster", filter: BackupFilter{Cluster: "other"}, matches: false},
+		{name: "Set", filter: BackupFilter{Set: "set2"}, matches: true},
+		{name: "OtherSet", filter: BackupFilter{Set: "set3"}, matches: false},
+		{name: "AllBins", filter: BackupFilter{Bin: "bin"}, matches: true},
+		{name: "Compression", filter: BackupFilter{Compression: "zstd"}, matches: true},
+		{name: "Encryption", filter: BackupFilter{Encryption: "AES256"}, matches: false},
+		{name: "Combined", filter: BackupFilter{Namespace: "ns1", Set: "set3"}, matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.filter.Matches(metadata))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// BackupJobID represents the ad-hoc backup job id.
+type BackupJobID int
+
+// BackupJobStatus represents the status of an ad-hoc backup job.
+type BackupJobStatus struct {
+	// The backup routine name.
+	Routine string
+	// The backup type (full or incremental).
+	Type string
+	// The job status.
+	Status JobStatus
+	// The time the job was scheduled.
+	CreatedTime time.Time
+	// The time the job started, zero if it has not started yet.
+	StartTime time.Time
+	// The time the job finished, zero if it has not finished yet.
+	EndTime time.Time
+	// Backup statistics by namespace.
+	Namespaces map[string]*BackupJobStats
+	// The keys of the created backups, available when the job is done.
+	Keys []string
+	// The error chain of a failed job, from the outermost error to the root cause.
+	Errors []string
+}
+
+// BackupJobStats represents the statistics of a namespace backup.
+type BackupJobStats struct {
+	RecordCount         uint64
+	TotalRecords        uint64
+	ByteCount           uint64
+	FileCount           uint64
+	SecondaryIndexCount uint64
+	UDFCount            uint64
+}
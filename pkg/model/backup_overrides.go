@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// BackupOverrides are the parameters of a single ad-hoc incremental backup
+// run, which override the backup routine configuration.
+type BackupOverrides struct {
+	// The list of the namespaces to back up.
+	Namespaces []string
+	// The list of backup set names.
+	SetList []string
+	// The list of backup bin names.
+	BinList []string
+	// Back up only records modified after this time.
+	From *time.Time
+}
+
+// Apply returns a copy of the backup routine with the overrides applied.
+// The routine itself is not modified.
+func (o *BackupOverrides) Apply(routine *BackupRoutine) *BackupRoutine {
+	if o == nil {
+		return routine
+	}
+
+	overridden := *routine
+	if len(o.Namespaces) > 0 {
+		overridden.Namespaces = o.Namespaces
+	}
+	if len(o.SetList) > 0 {
+		overridden.SetList = o.SetList
+	}
+	if len(o.BinList) > 0 {
+		overridden.BinList = o.BinList
+	}
+
+	return &overridden
+}
@@ -14,6 +14,14 @@ const (
 // @Description RemoveFilesType represents the type of the backup storage.
 type RemoveFilesType string
 
+const (
+	NamespaceOrderConfig NamespaceOrder = "config"
+	NamespaceOrderSize   NamespaceOrder = "size"
+)
+
+// NamespaceOrder is the order in which the namespaces of a routine are backed up.
+type NamespaceOrder string
+
 // BackupPolicy represents a scheduled backup policy.
 type BackupPolicy struct {
 	// Maximum number of scan calls to run in parallel.
@@ -27,6 +35,11 @@ type BackupPolicy struct {
 	MaxRetries *int32
 	// RetryDelay defines the delay in milliseconds before retrying a failed operation.
 	RetryDelay *int32
+	// RetryMaxDelay defines the maximum delay in milliseconds between the retries.
+	RetryMaxDelay *int32
+	// RetryMultiplier defines the factor the retry delay is multiplied by after
+	// each retry, 1 keeps the delay fixed.
+	RetryMultiplier *float64
 	// Whether to clear the output directory (default: KeepAll).
 	RemoveFiles *RemoveFilesType
 	// Do not back up any record data (metadata or bin data).
@@ -52,6 +65,16 @@ type BackupPolicy struct {
 	// When true, the backup contains only records that last modified before backup started.
 	// When false (default), records updated during backup might be included in the backup, but it's not guaranteed.
 	Sealed *bool
+	// Retention policy for full and incremental backups (default: keep all).
+	Retention *RetentionPolicy
+	// Maximum number of namespaces backed up in parallel, 1 backs them up
+	// sequentially (default: all namespaces in parallel).
+	ParallelNamespaces *int
+	// The order in which the namespaces are backed up (default: the configured order).
+	NamespaceOrder *NamespaceOrder
+	// The namespaces backed up first, in the given order, before the other
+	// namespaces in the NamespaceOrder.
+	NamespacePriority []string
 }
 
 // GetMaxRetriesOrDefault returns the value of the MaxRetries property.
@@ -72,6 +95,24 @@ func (p *BackupPolicy) GetRetryDelayOrDefault() int32 {
 	return defaultConfig.backupPolicy.retryDelay
 }
 
+// GetRetryMaxDelayOrDefault returns the value of the RetryMaxDelay property.
+// If the property is not set, it returns the default value.
+func (p *BackupPolicy) GetRetryMaxDelayOrDefault() int32 {
+	if p.RetryMaxDelay != nil {
+		return *p.RetryMaxDelay
+	}
+	return defaultConfig.backupPolicy.retryMaxDelay
+}
+
+// GetRetryMultiplierOrDefault returns the value of the RetryMultiplier property.
+// If the property is not set, it returns the default value.
+func (p *BackupPolicy) GetRetryMultiplierOrDefault() float64 {
+	if p.RetryMultiplier != nil {
+		return *p.RetryMultiplier
+	}
+	return defaultConfig.backupPolicy.retryMultiplier
+}
+
 // IsSealed returns the value of the Sealed property.
 // If the property is not set, it returns the default value.
 func (p *BackupPolicy) IsSealed() bool {
@@ -85,22 +126,34 @@ func (p *BackupPolicy) IsSealed() bool {
 // New instance has NoIndexes and NoUdfs set to true.
 func (p *BackupPolicy) CopySMDDisabled() *BackupPolicy {
 	return &BackupPolicy{
-		Parallel:         p.Parallel,
-		SocketTimeout:    p.SocketTimeout,
-		TotalTimeout:     p.TotalTimeout,
-		MaxRetries:       p.MaxRetries,
-		RetryDelay:       p.RetryDelay,
-		RemoveFiles:      p.RemoveFiles,
-		NoRecords:        p.NoRecords,
-		NoIndexes:        util.Ptr(true),
-		NoUdfs:           util.Ptr(true),
-		Bandwidth:        p.Bandwidth,
-		RecordsPerSecond: p.RecordsPerSecond,
-		FileLimit:        p.FileLimit,
-		Sealed:           p.Sealed,
+		Parallel:           p.Parallel,
+		SocketTimeout:      p.SocketTimeout,
+		TotalTimeout:       p.TotalTimeout,
+		MaxRetries:         p.MaxRetries,
+		RetryDelay:         p.RetryDelay,
+		RetryMaxDelay:      p.RetryMaxDelay,
+		RetryMultiplier:    p.RetryMultiplier,
+		RemoveFiles:        p.RemoveFiles,
+		NoRecords:          p.NoRecords,
+		NoIndexes:          util.Ptr(true),
+		NoUdfs:             util.Ptr(true),
+		Bandwidth:          p.Bandwidth,
+		RecordsPerSecond:   p.RecordsPerSecond,
+		FileLimit:          p.FileLimit,
+		Sealed:             p.Sealed,
+		Retention:          p.Retention,
+		ParallelNamespaces: p.ParallelNamespaces,
+		NamespaceOrder:     p.NamespaceOrder,
+		NamespacePriority:  p.NamespacePriority,
 	}
 }
 
+// IsOrderedBySize returns true if the namespaces are backed up from the
+// largest to the smallest one.
+func (p *BackupPolicy) IsOrderedBySize() bool {
+	return p.NamespaceOrder != nil && *p.NamespaceOrder == NamespaceOrderSize
+}
+
 func (r *RemoveFilesType) RemoveFullBackup() bool {
 	// Full backups are deleted only if RemoveFiles is explicitly set to RemoveAll
 	return r != nil && *r == RemoveAll
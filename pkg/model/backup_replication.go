@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReplicationStatus is the state of the copy of a backup to a replica storage.
+type ReplicationStatus string
+
+const (
+	// ReplicationPending means that the backup is being copied, or that the
+	// copy failed and is going to be retried.
+	ReplicationPending ReplicationStatus = "pending"
+	// ReplicationReplicated means that the backup was copied to the replica.
+	ReplicationReplicated ReplicationStatus = "replicated"
+	// ReplicationFailed means that the backup could not be copied and
+	// is not retried anymore.
+	ReplicationFailed ReplicationStatus = "failed"
+)
+
+// ReplicaStatus is the replication status of a backup to a replica storage.
+type ReplicaStatus struct {
+	// The name of the replica storage.
+	Storage string `yaml:"storage"`
+	// The replication status.
+	Status ReplicationStatus `yaml:"status"`
+	// The number of copy attempts.
+	Attempts int32 `yaml:"attempts"`
+	// The time the status was updated.
+	Updated time.Time `yaml:"updated"`
+	// The number of files copied.
+	FileCount int `yaml:"file-count"`
+	// The error of the last failed attempt.
+	Error string `yaml:"error,omitempty"`
+}
+
+// BackupReplication contains the replication status of a backup to the
+// replica storages of its routine. It is stored in the backup folder.
+type BackupReplication struct {
+	// The creation time of the backup.
+	Created time.Time `yaml:"created"`
+	// The status of the replicas, sorted by storage name.
+	Replicas []ReplicaStatus `yaml:"replicas,omitempty"`
+}
+
+// NewBackupReplicationFromBytes creates a new BackupReplication from a byte slice.
+func NewBackupReplicationFromBytes(data []byte) (*BackupReplication, error) {
+	var replication BackupReplication
+	if err := yaml.Unmarshal(data, &replication); err != nil {
+		return nil, fmt.Errorf("error unmarshaling YAML: %w", err)
+	}
+	return &replication, nil
+}
+
+// SetReplica sets the status of the replica, replacing its previous status.
+func (r *BackupReplication) SetReplica(status ReplicaStatus) {
+	r.Replicas = slices.DeleteFunc(r.Replicas, func(s ReplicaStatus) bool {
+		return s.Storage == status.Storage
+	})
+	r.Replicas = append(r.Replicas, status)
+	slices.SortFunc(r.Replicas, func(a, b ReplicaStatus) int {
+		return strings.Compare(a.Storage, b.Storage)
+	})
+}
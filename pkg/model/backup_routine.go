@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // BackupRoutine represents a scheduled backup operation routine.
 // @Description BackupRoutine represents a scheduled backup operation routine.
 //
@@ -11,12 +13,26 @@ type BackupRoutine struct {
 	SourceCluster *AerospikeCluster
 	// The name of the corresponding storage provider configuration.
 	Storage Storage
+	// The secondary storages the backups are copied to, by name (optional).
+	ReplicateTo map[string]Storage
+	// Moves the aged backups to another storage (optional).
+	Tiering *TieringPolicy
+	// The storages the backups of the routine were stored in before,
+	// their backups are listed and restored but never modified.
+	HistoricalStorages []Storage
+	// The prefix of the backup paths in the storages, the routine name if empty.
+	// It keeps the previous name of a renamed routine.
+	StoragePrefix string
 	// The Secret Agent configuration for the routine (optional).
 	SecretAgent *SecretAgent
 	// The interval for full backup as a cron expression string.
 	IntervalCron string
 	// The interval for incremental backup as a cron expression string (optional).
 	IncrIntervalCron string
+	// The interval for verification of the latest full backup as a cron expression string (optional).
+	VerifyIntervalCron string
+	// The time zone of the cron expressions (optional, UTC by default).
+	TimeZone *time.Location
 	// The list of the namespaces to back up (optional, empty list implies backup up whole cluster).
 	Namespaces []string
 	// The list of backup set names (optional, an empty list implies backing up all sets).
@@ -29,4 +45,46 @@ type BackupRoutine struct {
 	// or records after a specific digest within a single partition.
 	// Default number of partitions to back up: 0 to 4095: all partitions.
 	PartitionList *string
+	// Disabled routines are not scheduled, their backups are still available
+	// for listing and restore.
+	Disabled bool
+	// Scheduled backups of the routine are not run during the blackout windows.
+	BlackoutWindows []*BlackoutWindow
+}
+
+// AddHistoricalStorage adds the storage to the historical storages of the
+// routine, unless the routine already reads its location.
+func (r *BackupRoutine) AddHistoricalStorage(s Storage) {
+	if s == nil || SameLocation(s, r.Storage) || (r.Tiering != nil && SameLocation(s, r.Tiering.MoveTo)) {
+		return
+	}
+	for _, historical := range r.HistoricalStorages {
+		if SameLocation(s, historical) {
+			return
+		}
+	}
+	r.HistoricalStorages = append(r.HistoricalStorages, s)
+}
+
+// keepStorages keeps the backups of the previous configuration of the routine
+// readable, by adding its storages to the historical storages.
+func (r *BackupRoutine) keepStorages(previous *BackupRoutine) {
+	if r.StoragePrefix == "" {
+		r.StoragePrefix = previous.StoragePrefix
+	}
+	for _, s := range previous.HistoricalStorages {
+		r.AddHistoricalStorage(s)
+	}
+	r.AddHistoricalStorage(previous.Storage)
+	if previous.Tiering != nil {
+		r.AddHistoricalStorage(previous.Tiering.MoveTo)
+	}
+}
+
+// Location returns the time zone of the routine schedule.
+func (r *BackupRoutine) Location() *time.Location {
+	if r.TimeZone == nil {
+		return time.UTC
+	}
+	return r.TimeZone
 }
@@ -7,6 +7,12 @@ type BackupServiceConfig struct {
 	HTTPServer *HTTPServerConfig
 	// Logger is the backup service logger configuration.
 	Logger *LoggerConfig
+	// Scheduled backups of all routines are not run during the blackout windows.
+	BlackoutWindows []*BlackoutWindow
+	// Limits of concurrently running backup jobs (optional).
+	ConcurrencyLimits *ConcurrencyLimits
+	// Removal of incomplete backups (optional).
+	GarbageCollector *GarbageCollector
 }
 
 // NewBackupServiceConfigWithDefaultValues returns a new BackupServiceConfig with default values.
@@ -2,22 +2,54 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // BackupState represents the state of a backup routine.
 // @Description BackupState represents the state of a backup routine.
+//
+//nolint:lll
 type BackupState struct {
-	sync.Mutex
+	mu sync.Mutex
 	// Last time the full backup was performed.
 	LastFullRun time.Time `yaml:"last-run,omitempty" json:"last-run,omitempty" example:"2023-12-14T10:08:54Z"`
 	// Last time the incremental backup was performed.
 	LastIncrRun time.Time `yaml:"last-incr-run,omitempty" json:"last-incr-run,omitempty" example:"2023-12-15T12:00:00Z"`
 	// The number of successful full backups created for the routine.
 	Performed int `yaml:"performed,omitempty" json:"performed,omitempty" example:"5"`
+	// The error message of the last failed backup run.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty" example:"failed to connect to cluster"`
+	// Last time a backup run failed.
+	LastErrorTime time.Time `yaml:"last-error-time,omitempty" json:"last-error-time,omitempty" example:"2023-12-15T11:00:00Z"`
+	// Last time a backup (full or incremental) succeeded, per namespace.
+	NamespaceLastSuccess map[string]time.Time `yaml:"namespace-last-success,omitempty" json:"namespace-last-success,omitempty"`
+	// Last time the incremental backup succeeded, per namespace.
+	// The next incremental backup of the namespace starts from this time.
+	NamespaceLastIncrRun map[string]time.Time `yaml:"namespace-last-incr-run,omitempty" json:"namespace-last-incr-run,omitempty"`
+	// The status of the last incremental backup run.
+	LastIncrStatus RunStatus `yaml:"last-incr-status,omitempty" json:"last-incr-status,omitempty" enums:"succeeded,partially-failed,failed"`
+	// The namespaces which incremental backup failed in the last run.
+	FailedNamespaces []string `yaml:"failed-namespaces,omitempty" json:"failed-namespaces,omitempty"`
 }
 
+// RunStatus represents the result of a backup run over the routine namespaces.
+type RunStatus string
+
+const (
+	// RunStatusSucceeded means that all the namespaces were backed up.
+	RunStatusSucceeded RunStatus = "succeeded"
+	// RunStatusPartiallyFailed means that some of the namespaces failed.
+	RunStatusPartiallyFailed RunStatus = "partially-failed"
+	// RunStatusFailed means that all the namespaces failed.
+	RunStatusFailed RunStatus = "failed"
+)
+
 // String satisfies the fmt.Stringer interface.
 func (state *BackupState) String() string {
 	backupState, err := json.Marshal(state)
@@ -32,28 +64,153 @@ func NewBackupState() *BackupState {
 	return &BackupState{}
 }
 
+// NewStateFromBytes creates a new BackupState object from a byte slice.
+func NewStateFromBytes(data []byte) (*BackupState, error) {
+	var state BackupState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error unmarshaling YAML: %w", err)
+	}
+	return &state, nil
+}
+
+// Bytes returns the YAML representation of the state.
+func (state *BackupState) Bytes() ([]byte, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return yaml.Marshal(state)
+}
+
 func (state *BackupState) LastFullRunIsEmpty() bool {
-	state.Lock()
-	defer state.Unlock()
+	state.mu.Lock()
+	defer state.mu.Unlock()
 	return state.LastFullRun.Equal(time.Time{})
 }
 
+// GetLastFullRun returns the time of the last successful full backup.
+func (state *BackupState) GetLastFullRun() time.Time {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.LastFullRun
+}
+
 func (state *BackupState) SetLastFullRun(time time.Time) {
-	state.Lock()
-	defer state.Unlock()
+	state.mu.Lock()
+	defer state.mu.Unlock()
 	state.LastFullRun = time
 	state.Performed++
 }
 
-func (state *BackupState) SetLastIncrRun(time time.Time) {
-	state.Lock()
-	defer state.Unlock()
-	state.LastIncrRun = time
+// SetLastIncrRun records the incremental backup run of the given namespaces.
+// The failed namespaces keep their last successful time, so that the next
+// run backs up their changes since then.
+func (state *BackupState) SetLastIncrRun(t time.Time, namespaces, failed []string) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.NamespaceLastIncrRun == nil {
+		state.NamespaceLastIncrRun = make(map[string]time.Time)
+	}
+	for _, namespace := range namespaces {
+		if slices.Contains(failed, namespace) {
+			state.NamespaceLastIncrRun[namespace] = state.incrementalFrom(namespace)
+		} else {
+			state.NamespaceLastIncrRun[namespace] = t
+		}
+	}
+
+	state.LastIncrRun = t
+	state.FailedNamespaces = slices.Clone(failed)
+	switch {
+	case len(failed) == 0:
+		state.LastIncrStatus = RunStatusSucceeded
+	case len(failed) < len(namespaces):
+		state.LastIncrStatus = RunStatusPartiallyFailed
+	default:
+		state.LastIncrStatus = RunStatusFailed
+	}
+}
+
+// IncrementalFrom returns the start time of the next incremental backup of
+// the namespace: the last successful backup of the namespace, full or
+// incremental.
+func (state *BackupState) IncrementalFrom(namespace string) time.Time {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.incrementalFrom(namespace)
+}
+
+func (state *BackupState) incrementalFrom(namespace string) time.Time {
+	lastIncrRun, found := state.NamespaceLastIncrRun[namespace]
+	if !found {
+		// the namespace was not backed up incrementally yet
+		lastIncrRun = state.LastIncrRun
+	}
+	if lastIncrRun.After(state.LastFullRun) {
+		return lastIncrRun
+	}
+
+	return state.LastFullRun
+}
+
+// SetLastRuns sets the times of the last full and incremental backups,
+// e.g. when the backups are deleted.
+// The namespace incremental times are limited by the last run.
+func (state *BackupState) SetLastRuns(lastFullRun, lastIncrRun time.Time) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.LastFullRun = lastFullRun
+	state.LastIncrRun = lastIncrRun
+	for namespace, t := range state.NamespaceLastIncrRun {
+		if t.After(lastIncrRun) {
+			state.NamespaceLastIncrRun[namespace] = lastIncrRun
+		}
+	}
+}
+
+// SetLastError records the error of a failed backup run.
+func (state *BackupState) SetLastError(err error, time time.Time) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.LastError = err.Error()
+	state.LastErrorTime = time
+}
+
+// SetNamespaceLastSuccess records the time of a successful backup of the namespace.
+func (state *BackupState) SetNamespaceLastSuccess(namespace string, t time.Time) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.NamespaceLastSuccess == nil {
+		state.NamespaceLastSuccess = make(map[string]time.Time)
+	}
+	state.NamespaceLastSuccess[namespace] = t
+}
+
+// GetNamespaceLastSuccess returns a copy of the per-namespace last success times.
+func (state *BackupState) GetNamespaceLastSuccess() map[string]time.Time {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return maps.Clone(state.NamespaceLastSuccess)
+}
+
+// Copy returns a copy of the state.
+func (state *BackupState) Copy() *BackupState {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return &BackupState{
+		LastFullRun:          state.LastFullRun,
+		LastIncrRun:          state.LastIncrRun,
+		Performed:            state.Performed,
+		LastError:            state.LastError,
+		LastErrorTime:        state.LastErrorTime,
+		NamespaceLastSuccess: maps.Clone(state.NamespaceLastSuccess),
+		NamespaceLastIncrRun: maps.Clone(state.NamespaceLastIncrRun),
+		LastIncrStatus:       state.LastIncrStatus,
+		FailedNamespaces:     slices.Clone(state.FailedNamespaces),
+	}
 }
 
 func (state *BackupState) LastRun() time.Time {
-	state.Lock()
-	defer state.Unlock()
+	state.mu.Lock()
+	defer state.mu.Unlock()
 	if state.LastIncrRun.After(state.LastFullRun) {
 		return state.LastIncrRun
 	}
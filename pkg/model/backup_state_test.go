@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupState_IncrementalWatermarks(t *testing.T) {
+	full := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	first := full.Add(time.Hour)
+	second := first.Add(time.Hour)
+	namespaces := []string{"ns1", "ns2", "ns3"}
+
+	state := NewBackupState()
+	state.SetLastFullRun(full)
+	for _, namespace := range namespaces {
+		assert.Equal(t, full, state.IncrementalFrom(namespace))
+	}
+
+	// ns2 fails and keeps the time of the full backup
+	state.SetLastIncrRun(first, namespaces, []string{"ns2"})
+	assert.Equal(t, first, state.IncrementalFrom("ns1"))
+	assert.Equal(t, full, state.IncrementalFrom("ns2"))
+	assert.Equal(t, first, state.IncrementalFrom("ns3"))
+	assert.Equal(t, RunStatusPartiallyFailed, state.LastIncrStatus)
+	assert.Equal(t, []string{"ns2"}, state.FailedNamespaces)
+
+	// the namespace added to the routine starts from the last run
+	assert.Equal(t, first, state.IncrementalFrom("ns4"))
+
+	// ns2 recovers, ns3 fails
+	state.SetLastIncrRun(second, namespaces, []string{"ns3"})
+	assert.Equal(t, second, state.IncrementalFrom("ns2"))
+	assert.Equal(t, first, state.IncrementalFrom("ns3"))
+
+	state.SetLastIncrRun(second.Add(time.Hour), namespaces, namespaces)
+	assert.Equal(t, RunStatusFailed, state.LastIncrStatus)
+	assert.Equal(t, first, state.IncrementalFrom("ns3"))
+
+	state.SetLastIncrRun(second.Add(2*time.Hour), namespaces, nil)
+	assert.Equal(t, RunStatusSucceeded, state.LastIncrStatus)
+	assert.Empty(t, state.FailedNamespaces)
+
+	// a full backup covers the failed namespaces
+	state.SetLastIncrRun(second.Add(3*time.Hour), namespaces, []string{"ns1"})
+	state.SetLastFullRun(second.Add(4 * time.Hour))
+	assert.Equal(t, second.Add(4*time.Hour), state.IncrementalFrom("ns1"))
+}
+
+func TestBackupState_SetLastRunsLimitsWatermarks(t *testing.T) {
+	full := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	state := NewBackupState()
+	state.SetLastFullRun(full)
+	state.SetLastIncrRun(full.Add(2*time.Hour), []string{"ns1"}, nil)
+
+	// the last incremental backup is deleted
+	state.SetLastRuns(full, full.Add(time.Hour))
+	assert.Equal(t, full.Add(time.Hour), state.IncrementalFrom("ns1"))
+
+	state.SetLastRuns(full, time.Time{})
+	assert.Equal(t, full, state.IncrementalFrom("ns1"))
+}
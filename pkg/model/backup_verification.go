@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VerificationStatus is the outcome of a backup verification.
+type VerificationStatus string
+
+const (
+	// VerificationPassed means that the backup was read back and matches its metadata.
+	VerificationPassed VerificationStatus = "passed"
+	// VerificationFailed means that the backup could not be read back
+	// or does not match its metadata.
+	VerificationFailed VerificationStatus = "failed"
+)
+
+// VerificationResult is the result of reading back the backup of a namespace.
+// It is stored next to the backup metadata.
+type VerificationResult struct {
+	// The namespace of the verified backup.
+	Namespace string `yaml:"namespace,omitempty"`
+	// The creation time of the verified backup.
+	Created time.Time `yaml:"created,omitempty"`
+	// The time the verification finished.
+	Verified time.Time `yaml:"verified,omitempty"`
+	// The verification outcome.
+	Status VerificationStatus `yaml:"status,omitempty"`
+	// The number of records read from the backup files.
+	RecordCount uint64 `yaml:"record-count"`
+	// The number of records in the backup metadata.
+	ExpectedRecordCount uint64 `yaml:"expected-record-count"`
+	// The number of bytes read from the storage.
+	ByteCount uint64 `yaml:"byte-count"`
+	// The number of bytes in the backup metadata.
+	ExpectedByteCount uint64 `yaml:"expected-byte-count"`
+	// The number of backup files read.
+	FileCount uint64 `yaml:"file-count"`
+	// The reason of a failed verification.
+	Error string `yaml:"error,omitempty"`
+}
+
+// NewVerificationResultFromBytes creates a new VerificationResult from a byte slice.
+func NewVerificationResultFromBytes(data []byte) (*VerificationResult, error) {
+	var result VerificationResult
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling YAML: %w", err)
+	}
+	return &result, nil
+}
+
+// BackupVerification contains the verification results of a backup.
+type BackupVerification struct {
+	// The verification of the backup is running.
+	Running bool
+	// The results of the last verification, per namespace.
+	Results []VerificationResult
+}
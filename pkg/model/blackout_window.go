@@ -0,0 +1,133 @@
+package model
+
+import (
+	"time"
+
+	"github.com/reugn/go-quartz/quartz"
+)
+
+// maxWindowIterations limits the number of cron fire times inspected
+// to find the window containing the given time. If the merged windows do not
+// end within the limit, the window is reported to end after the last
+// inspected fire time and is checked again then.
+const maxWindowIterations = 1000
+
+// BlackoutWindow defines a period of time when scheduled backups are not run.
+// The window is either recurring by the cron expression for the duration,
+// or a daily time range.
+type BlackoutWindow struct {
+	// The start of the window as a cron expression (used with Duration).
+	Cron string
+	// The duration of the window started by Cron.
+	Duration time.Duration
+	// The start of the daily window as an offset from midnight (used when Cron is empty).
+	From time.Duration
+	// The end of the daily window as an offset from midnight,
+	// the window ends on the next day if To is not after From.
+	To time.Duration
+	// The time zone of the window.
+	Location *time.Location
+	// Runs that fall inside the window are deferred until the window ends,
+	// they are skipped otherwise.
+	Defer bool
+	// Running backups are cancelled when the window starts.
+	CancelRunning bool
+}
+
+// ActiveAt returns the end of the window if the window is active at the given time.
+func (w *BlackoutWindow) ActiveAt(t time.Time) (time.Time, bool) {
+	if w.Cron != "" {
+		return w.cronActiveAt(t)
+	}
+
+	t = t.In(w.Location)
+	for _, day := range []int{-1, 0} {
+		start, end := w.dailyRange(t, day)
+		if !t.Before(start) && t.Before(end) {
+			return end, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextStart returns the next start of the window after the given time.
+func (w *BlackoutWindow) NextStart(t time.Time) (time.Time, bool) {
+	if w.Cron != "" {
+		trigger, err := quartz.NewCronTriggerWithLoc(w.Cron, w.Location)
+		if err != nil {
+			return time.Time{}, false
+		}
+		next, err := trigger.NextFireTime(t.UnixNano())
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(0, next), true
+	}
+
+	t = t.In(w.Location)
+	for _, day := range []int{0, 1} {
+		start, _ := w.dailyRange(t, day)
+		if start.After(t) {
+			return start, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (w *BlackoutWindow) cronActiveAt(t time.Time) (time.Time, bool) {
+	trigger, err := quartz.NewCronTriggerWithLoc(w.Cron, w.Location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// windows started before t-Duration have already ended
+	fireTime := t.Add(-w.Duration).UnixNano() - 1
+	var end time.Time
+	for i := 0; ; i++ {
+		if i == maxWindowIterations {
+			// the windows are merged beyond the limit, they are still
+			// active at the end of the last inspected window
+			return end, end.After(t)
+		}
+		next, err := trigger.NextFireTime(fireTime)
+		if err != nil {
+			break
+		}
+		start := time.Unix(0, next)
+		if start.After(t) && (end.IsZero() || start.After(end)) {
+			break
+		}
+		// overlapping windows are merged
+		end = start.Add(w.Duration)
+		fireTime = next
+	}
+
+	if end.After(t) {
+		return end, true
+	}
+
+	return time.Time{}, false
+}
+
+// dailyRange returns the daily window of the day shifted by the given number of days.
+// The bounds are wall clock times, so the window keeps its local times on the
+// days of daylight saving time transitions.
+func (w *BlackoutWindow) dailyRange(t time.Time, day int) (time.Time, time.Time) {
+	start := w.clockTime(t, day, w.From)
+	end := w.clockTime(t, day, w.To)
+	if !end.After(start) {
+		end = w.clockTime(t, day+1, w.To)
+	}
+
+	return start, end
+}
+
+// clockTime returns the wall clock time at the given offset from midnight
+// of the day shifted by the given number of days.
+func (w *BlackoutWindow) clockTime(t time.Time, day int, offset time.Duration) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+day,
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second),
+		0, w.Location)
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlackoutWindow_DailyRange(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// 22:00 - 02:00 Berlin time
+	window := &BlackoutWindow{From: 22 * time.Hour, To: 2 * time.Hour, Location: berlin}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		active bool
+		end    time.Time
+	}{
+		{"before", time.Date(2024, 3, 10, 20, 59, 0, 0, time.UTC), false, time.Time{}},
+		{"start", time.Date(2024, 3, 10, 21, 0, 0, 0, time.UTC), true, time.Date(2024, 3, 11, 2, 0, 0, 0, berlin)},
+		{"after midnight", time.Date(2024, 3, 11, 0, 30, 0, 0, berlin), true, time.Date(2024, 3, 11, 2, 0, 0, 0, berlin)},
+		{"end", time.Date(2024, 3, 11, 2, 0, 0, 0, berlin), false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, active := window.ActiveAt(tt.now)
+			require.Equal(t, tt.active, active)
+			require.True(t, tt.end.Equal(end), "expected end %s, got %s", tt.end, end)
+		})
+	}
+
+	next, ok := window.NextStart(time.Date(2024, 3, 11, 0, 30, 0, 0, berlin))
+	require.True(t, ok)
+	require.True(t, time.Date(2024, 3, 11, 22, 0, 0, 0, berlin).Equal(next))
+}
+
+func TestBlackoutWindow_DailyRangeDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// clocks are moved forward at 02:00 on 2024-03-31 and back at 03:00 on 2024-10-27
+	window := &BlackoutWindow{From: 22 * time.Hour, To: 23 * time.Hour, Location: berlin}
+
+	for _, day := range []time.Time{
+		time.Date(2024, 3, 31, 0, 0, 0, 0, berlin),
+		time.Date(2024, 10, 27, 0, 0, 0, 0, berlin),
+	} {
+		at := func(hour, minute int) time.Time {
+			return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, berlin)
+		}
+
+		end, active := window.ActiveAt(at(22, 30))
+		require.True(t, active)
+		require.True(t, at(23, 0).Equal(end), "unexpected end %s", end)
+
+		_, active = window.ActiveAt(at(21, 30))
+		require.False(t, active)
+		_, active = window.ActiveAt(at(23, 30))
+		require.False(t, active)
+
+		next, ok := window.NextStart(at(12, 0))
+		require.True(t, ok)
+		require.True(t, at(22, 0).Equal(next), "unexpected start %s", next)
+	}
+}
+
+func TestBlackoutWindow_Cron(t *testing.T) {
+	// every day at 01:00 UTC for 2 hours
+	window := &BlackoutWindow{Cron: "0 0 1 * * *", Duration: 2 * time.Hour, Location: time.UTC}
+
+	end, active := window.ActiveAt(time.Date(2024, 3, 10, 0, 59, 59, 0, time.UTC))
+	require.False(t, active)
+	require.True(t, end.IsZero())
+
+	end, active = window.ActiveAt(time.Date(2024, 3, 10, 1, 0, 0, 0, time.UTC))
+	require.True(t, active)
+	require.True(t, time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC).Equal(end))
+
+	_, active = window.ActiveAt(time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC))
+	require.False(t, active)
+
+	next, ok := window.NextStart(time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.True(t, time.Date(2024, 3, 11, 1, 0, 0, 0, time.UTC).Equal(next))
+}
+
+func TestBlackoutWindow_CronOverlapping(t *testing.T) {
+	// windows started every hour last 90 minutes, so they are merged
+	window := &BlackoutWindow{Cron: "0 0 * * * *", Duration: 90 * time.Minute, Location: time.UTC}
+
+	end, active := window.ActiveAt(time.Date(2024, 3, 10, 1, 10, 0, 0, time.UTC))
+	require.True(t, active)
+	require.False(t, end.IsZero())
+	require.True(t, end.After(time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC)))
+}
+
+func TestBlackoutWindow_CronIterationLimit(t *testing.T) {
+	// windows started every second last an hour, they are merged beyond the limit
+	window := &BlackoutWindow{Cron: "* * * * * *", Duration: time.Hour, Location: time.UTC}
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	end, active := window.ActiveAt(now)
+	require.True(t, active)
+	// the end of the last inspected window, the first one started at now-Duration
+	require.True(t, now.Add((maxWindowIterations-1)*time.Second).Equal(end), "unexpected end %s", end)
+}
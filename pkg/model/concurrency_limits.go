@@ -0,0 +1,54 @@
+package model
+
+// ConcurrencyLimits limits the number of concurrently running backup jobs.
+// Jobs exceeding the limits wait in a queue.
+type ConcurrencyLimits struct {
+	// The maximum number of concurrent backup jobs of the service.
+	MaxBackups *int
+	// The default maximum number of concurrent backup jobs per cluster.
+	MaxBackupsPerCluster *int
+	// The default maximum number of concurrent backup jobs per storage.
+	MaxBackupsPerStorage *int
+	// The maximum number of concurrent backup jobs by cluster name,
+	// overrides MaxBackupsPerCluster.
+	Clusters map[string]int
+	// The maximum number of concurrent backup jobs by storage name,
+	// overrides MaxBackupsPerStorage.
+	Storage map[string]int
+}
+
+// ServiceLimit returns the service-wide limit, 0 if not limited.
+func (l *ConcurrencyLimits) ServiceLimit() int {
+	if l == nil || l.MaxBackups == nil {
+		return 0
+	}
+	return *l.MaxBackups
+}
+
+// ClusterLimit returns the limit of the cluster, 0 if not limited.
+func (l *ConcurrencyLimits) ClusterLimit(name string) int {
+	if l == nil {
+		return 0
+	}
+	if limit, ok := l.Clusters[name]; ok {
+		return limit
+	}
+	if l.MaxBackupsPerCluster != nil {
+		return *l.MaxBackupsPerCluster
+	}
+	return 0
+}
+
+// StorageLimit returns the limit of the storage, 0 if not limited.
+func (l *ConcurrencyLimits) StorageLimit(name string) int {
+	if l == nil {
+		return 0
+	}
+	if limit, ok := l.Storage[name]; ok {
+		return limit
+	}
+	if l.MaxBackupsPerStorage != nil {
+		return *l.MaxBackupsPerStorage
+	}
+	return 0
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 )
 
@@ -68,8 +69,17 @@ func (c *Config) UpdateStorage(name string, s Storage) error {
 
 	oldStorage := c.Storage[name]
 	for _, r := range c.BackupRoutines {
+		// the backups in the old location are kept readable
 		if r.Storage == oldStorage {
 			r.Storage = s
+			r.AddHistoricalStorage(oldStorage)
+		}
+		if _, found := r.ReplicateTo[name]; found {
+			r.ReplicateTo[name] = s
+		}
+		if r.Tiering != nil && r.Tiering.MoveTo == oldStorage {
+			r.Tiering.MoveTo = s
+			r.AddHistoricalStorage(oldStorage)
 		}
 	}
 
@@ -83,6 +93,14 @@ func (c *Config) routineUsesStorage(s Storage) string {
 		if r.Storage == s {
 			return name
 		}
+		for _, replica := range r.ReplicateTo {
+			if replica == s {
+				return name
+			}
+		}
+		if r.Tiering != nil && r.Tiering.MoveTo == s {
+			return name
+		}
 	}
 	return ""
 }
@@ -145,7 +163,7 @@ func (c *Config) AddRoutine(name string, r *BackupRoutine) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if _, exists := c.BackupRoutines[name]; exists {
+	if _, exists := c.BackupRoutines[name]; exists || c.storagePrefixUsed(name) {
 		return fmt.Errorf("add backup routine %q: %w", name, ErrAlreadyExists)
 	}
 	c.BackupRoutines[name] = r
@@ -167,13 +185,86 @@ func (c *Config) UpdateRoutine(name string, r *BackupRoutine) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if _, exists := c.BackupRoutines[name]; !exists {
+	previous, exists := c.BackupRoutines[name]
+	if !exists {
 		return fmt.Errorf("update backup routine %q: %w", name, ErrNotFound)
 	}
+	r.keepStorages(previous)
 	c.BackupRoutines[name] = r
 	return nil
 }
 
+// RenameRoutine renames the backup routine. The routine keeps the paths of
+// its backups, which are derived from its original name.
+func (c *Config) RenameRoutine(name, newName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, exists := c.BackupRoutines[name]
+	if !exists {
+		return fmt.Errorf("rename backup routine %q: %w", name, ErrNotFound)
+	}
+	if _, exists := c.BackupRoutines[newName]; exists {
+		return fmt.Errorf("rename backup routine %q to %q: %w", name, newName, ErrAlreadyExists)
+	}
+	if r.StoragePrefix != newName && c.storagePrefixUsed(newName) {
+		return fmt.Errorf("rename backup routine %q to %q: backups of a renamed routine are stored under %q: %w",
+			name, newName, newName, ErrAlreadyExists)
+	}
+
+	if r.StoragePrefix == "" {
+		r.StoragePrefix = name
+	}
+	if r.StoragePrefix == newName {
+		r.StoragePrefix = ""
+	}
+	delete(c.BackupRoutines, name)
+	c.BackupRoutines[newName] = r
+	return nil
+}
+
+// storagePrefixUsed returns true if the backups of a renamed routine are
+// stored under the given routine name.
+func (c *Config) storagePrefixUsed(name string) bool {
+	for _, r := range c.BackupRoutines {
+		if r.StoragePrefix == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveHistoricalStorages removes the given historical storages of the
+// backup routine, e.g. once their backups are migrated.
+func (c *Config) RemoveHistoricalStorages(name string, storages []Storage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, exists := c.BackupRoutines[name]
+	if !exists {
+		return fmt.Errorf("remove historical storages of backup routine %q: %w", name, ErrNotFound)
+	}
+	r.HistoricalStorages = slices.DeleteFunc(r.HistoricalStorages, func(historical Storage) bool {
+		return slices.ContainsFunc(storages, func(s Storage) bool {
+			return SameLocation(s, historical)
+		})
+	})
+	return nil
+}
+
+// SetRoutineDisabled pauses (disabled is true) or resumes the backup routine.
+func (c *Config) SetRoutineDisabled(name string, disabled bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, exists := c.BackupRoutines[name]
+	if !exists {
+		return fmt.Errorf("set backup routine %q disabled: %w", name, ErrNotFound)
+	}
+	r.Disabled = disabled
+	return nil
+}
+
 func (c *Config) AddCluster(name string, cluster *AerospikeCluster) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -8,16 +8,24 @@ import (
 )
 
 type backupPolicy struct {
-	maxRetries int32
-	retryDelay int32
-	sealed     bool
+	maxRetries      int32
+	retryDelay      int32
+	retryMaxDelay   int32
+	retryMultiplier float64
+	sealed          bool
+}
+
+type garbageCollector struct {
+	interval time.Duration
+	minAge   time.Duration
 }
 
 // defaultConfig represents default configuration values.
 var defaultConfig = struct {
-	http         HTTPServerConfig
-	logger       LoggerConfig
-	backupPolicy backupPolicy
+	http             HTTPServerConfig
+	logger           LoggerConfig
+	backupPolicy     backupPolicy
+	garbageCollector garbageCollector
 }{
 	http: HTTPServerConfig{
 		Address: util.Ptr("0.0.0.0"),
@@ -36,8 +44,14 @@ var defaultConfig = struct {
 		StdoutWriter: util.Ptr(true),
 	},
 	backupPolicy: backupPolicy{
-		retryDelay: 60_000, // default retry delay is 1 minute
-		maxRetries: 3,
+		retryDelay:      60_000,  // default retry delay is 1 minute
+		retryMaxDelay:   600_000, // the retry delay grows up to 10 minutes
+		retryMultiplier: 2,
+		maxRetries:      3,
+	},
+	garbageCollector: garbageCollector{
+		interval: time.Hour,
+		minAge:   24 * time.Hour,
 	},
 }
 
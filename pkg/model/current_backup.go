@@ -10,6 +10,18 @@ type CurrentBackups struct {
 	Full *RunningJob
 	// Incremental represents the state of an incremental backup. Nil if no incremental backup is running.
 	Incremental *RunningJob
+	// Retry represents the state of the full backup retries. Nil if the last
+	// full backup is not being retried.
+	Retry *RetryStatus
+}
+
+// RetryStatus represents the state of the retries of a failed full backup.
+type RetryStatus struct {
+	// Attempt is the number of the current retry attempt, starting from 1.
+	Attempt int32
+	// NextRetryTime is the time of the pending retry.
+	// Nil if the retry attempt is running.
+	NextRetryTime *time.Time
 }
 
 // RunningJob tracks progress of currently running job.
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+)
+
+// GarbageCollector configures the removal of incomplete backups, i.e. backup
+// folders without metadata, left by failed backups or service crashes.
+type GarbageCollector struct {
+	// Incomplete backups are not removed periodically if disabled.
+	Disabled bool
+	// The interval between the runs, the first run is on startup.
+	Interval time.Duration
+	// Only the incomplete backups older than MinAge are removed.
+	MinAge time.Duration
+}
+
+// IsDisabled returns true if the incomplete backups are not removed periodically.
+func (g *GarbageCollector) IsDisabled() bool {
+	return g != nil && g.Disabled
+}
+
+// GetIntervalOrDefault returns the interval between the runs.
+// If the interval is not set, it returns the default value.
+func (g *GarbageCollector) GetIntervalOrDefault() time.Duration {
+	if g != nil && g.Interval > 0 {
+		return g.Interval
+	}
+	return defaultConfig.garbageCollector.interval
+}
+
+// GetMinAgeOrDefault returns the minimum age of the removed incomplete backups.
+// If the age is not set, it returns the default value.
+func (g *GarbageCollector) GetMinAgeOrDefault() time.Duration {
+	if g != nil && g.MinAge > 0 {
+		return g.MinAge
+	}
+	return defaultConfig.garbageCollector.minAge
+}
+
+// IncompleteBackup is a backup folder without metadata.
+type IncompleteBackup struct {
+	// The name of the backup routine.
+	Routine string
+	// The path to the backup folder.
+	Path string
+	// The time the backup was started.
+	Created time.Time
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v7"
+	"github.com/aerospike/backup-go"
+)
+
+var (
+	partitionRangeRegex = regexp.MustCompile(`^([0-9]|[1-9][0-9]{1,3}|40[0-8][0-9]|409[0-5])-([1-9]|[1-9][0-9]{1,3}|40[0-8][0-9]|409[0-6])$`)
+	partitionIDRegex    = regexp.MustCompile(`^(409[0-5]|40[0-8]\d|[123]?\d{1,3}|0)$`)
+	// base64 encoded 20 bytes record digest
+	partitionDigestRegex = regexp.MustCompile(`^[A-Za-z0-9+/]{27}=$`)
+)
+
+// ParsePartitionList parses a comma separated list of partition filters.
+// Each filter is one of:
+//   - a range of partitions <begin>-<count>, e.g. 0-1000;
+//   - an individual partition id, e.g. 42;
+//   - a base64 encoded record digest, records after which are backed up
+//     within the digest's partition.
+//
+// The namespace is only used to build digest filters.
+func ParsePartitionList(namespace, partitionList string) ([]*as.PartitionFilter, error) {
+	var filters []*as.PartitionFilter
+	for _, filter := range strings.Split(partitionList, ",") {
+		filter = strings.TrimSpace(filter)
+		if filter == "" {
+			continue
+		}
+
+		partitionFilter, err := parsePartitionFilter(namespace, filter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, partitionFilter)
+	}
+
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("empty partition list")
+	}
+
+	if err := validatePartitionFilters(filters); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+// HasDigestFilter returns true if any of the filters is a digest filter.
+func HasDigestFilter(filters []*as.PartitionFilter) bool {
+	return slices.ContainsFunc(filters, func(filter *as.PartitionFilter) bool {
+		return filter.Digest != nil
+	})
+}
+
+func parsePartitionFilter(namespace, filter string) (*as.PartitionFilter, error) {
+	switch {
+	case partitionRangeRegex.MatchString(filter):
+		bounds := strings.Split(filter, "-")
+		begin, _ := strconv.Atoi(bounds[0])
+		count, _ := strconv.Atoi(bounds[1])
+		if begin+count > backup.MaxPartitions {
+			return nil, fmt.Errorf("partition range %s exceeds max partition %d", filter, backup.MaxPartitions-1)
+		}
+		return backup.NewPartitionFilterByRange(begin, count), nil
+	case partitionIDRegex.MatchString(filter):
+		id, _ := strconv.Atoi(filter)
+		return backup.NewPartitionFilterByID(id), nil
+	case partitionDigestRegex.MatchString(filter):
+		partitionFilter, err := backup.NewPartitionFilterByDigest(namespace, filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partition digest %s: %w", filter, err)
+		}
+		return partitionFilter, nil
+	default:
+		return nil, fmt.Errorf("failed to parse partition filter: %s", filter)
+	}
+}
+
+// validatePartitionFilters checks that partition filters do not overlap.
+func validatePartitionFilters(filters []*as.PartitionFilter) error {
+	intervals := make([][2]int, 0, len(filters))
+	for _, filter := range filters {
+		intervals = append(intervals, [2]int{filter.Begin, filter.Begin + filter.Count})
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i][0] < intervals[j][0]
+	})
+
+	for i := 1; i < len(intervals); i++ {
+		if intervals[i][0] < intervals[i-1][1] {
+			return fmt.Errorf("overlapping partition filters: [%d, %d) and [%d, %d)",
+				intervals[i-1][0], intervals[i-1][1], intervals[i][0], intervals[i][1])
+		}
+	}
+
+	return nil
+}
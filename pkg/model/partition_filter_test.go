@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePartitionList(t *testing.T) {
+	tests := []struct {
+		name          string
+		partitionList string
+		expectedCount int
+		expectedErr   bool
+	}{
+		{
+			name:          "Range",
+			partitionList: "0-1000",
+			expectedCount: 1,
+		},
+		{
+			name:          "RangesAndIDs",
+			partitionList: "0-1000, 1000-1000, 4095",
+			expectedCount: 3,
+		},
+		{
+			name:          "Empty",
+			partitionList: " , ",
+			expectedErr:   true,
+		},
+		{
+			name:          "RangeOutOfBounds",
+			partitionList: "4000-100",
+			expectedErr:   true,
+		},
+		{
+			name:          "Overlapping",
+			partitionList: "0-1000,999",
+			expectedErr:   true,
+		},
+		{
+			name:          "Invalid",
+			partitionList: "a-b",
+			expectedErr:   true,
+		},
+		{
+			name:          "Digest",
+			partitionList: "1000-1000,AAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			expectedCount: 2,
+		},
+		{
+			name:          "InvalidDigest",
+			partitionList: "YWJj",
+			expectedErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := ParsePartitionList("test", tt.partitionList)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, filters, tt.expectedCount)
+		})
+	}
+}
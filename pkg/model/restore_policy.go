@@ -60,6 +60,8 @@ type RestorePolicy struct {
 	// Amount of extra time-to-live to add to records that have expirable void-times.
 	// Must be set in seconds.
 	ExtraTTL *int64
+	// Verify the checksums of the backup metadata and files before restoring them.
+	VerifyChecksums *bool
 }
 
 func (p *RestorePolicy) GetRetryPolicyOrDefault() *models.RetryPolicy {
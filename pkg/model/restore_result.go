@@ -3,7 +3,9 @@ package model
 type JobStatus string
 
 const (
+	JobStatusQueued  JobStatus = "Queued"
 	JobStatusRunning JobStatus = "Running"
+	JobStatusSkipped JobStatus = "Skipped"
 	JobStatusDone    JobStatus = "Done"
 	JobStatusFailed  JobStatus = "Failed"
 )
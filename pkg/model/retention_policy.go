@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+)
+
+// RetentionPolicy defines which full backups (and the incremental backups
+// depending on them) are kept.
+// A backup is retained if it is kept by at least one of the configured rules.
+// The latest full backup is always retained.
+type RetentionPolicy struct {
+	// The number of latest full backups to keep.
+	KeepLast *int
+	// Full backups younger than MaxAge are kept, e.g. 12h, 30d, 4w.
+	MaxAge *string
+	// The number of days to keep the latest full backup of the day for.
+	Daily *int
+	// The number of weeks to keep the latest full backup of the week for.
+	Weekly *int
+	// The number of months to keep the latest full backup of the month for.
+	Monthly *int
+}
+
+// Expired returns creation times of the full backups that are not retained by
+// the policy at the given time. The daily, weekly and monthly periods are
+// calculated in the given time zone.
+// A policy without rules does not expire any backup.
+func (p *RetentionPolicy) Expired(backups []time.Time, now time.Time, loc *time.Location) []time.Time {
+	if p.isEmpty() || len(backups) == 0 {
+		return nil
+	}
+
+	// newest first
+	sorted := slices.Clone(backups)
+	slices.SortFunc(sorted, func(a, b time.Time) int {
+		return b.Compare(a)
+	})
+	sorted = slices.CompactFunc(sorted, time.Time.Equal)
+
+	keep := make([]bool, len(sorted))
+	keep[0] = true
+	if p.KeepLast != nil {
+		for i := 0; i < len(sorted) && i < *p.KeepLast; i++ {
+			keep[i] = true
+		}
+	}
+	if p.MaxAge != nil {
+		// validated before
+		maxAge, _ := util.ParseDuration(*p.MaxAge)
+		for i, t := range sorted {
+			if now.Sub(t) < maxAge {
+				keep[i] = true
+			}
+		}
+	}
+	keepLatestPerPeriod(sorted, keep, p.Daily, loc, func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	})
+	keepLatestPerPeriod(sorted, keep, p.Weekly, loc, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-%d", year, week)
+	})
+	keepLatestPerPeriod(sorted, keep, p.Monthly, loc, func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+
+	var expired []time.Time
+	for i, t := range sorted {
+		if !keep[i] {
+			expired = append(expired, t)
+		}
+	}
+
+	return expired
+}
+
+// isEmpty returns true if the policy has no rules.
+func (p *RetentionPolicy) isEmpty() bool {
+	return p == nil ||
+		p.KeepLast == nil && p.MaxAge == nil && p.Daily == nil && p.Weekly == nil && p.Monthly == nil
+}
+
+// keepLatestPerPeriod marks the latest backup of each of the last count
+// periods as kept. Backups must be sorted newest first.
+func keepLatestPerPeriod(
+	sorted []time.Time, keep []bool, count *int, loc *time.Location, period func(time.Time) string,
+) {
+	if count == nil {
+		return
+	}
+
+	seen := make(map[string]struct{})
+	for i, t := range sorted {
+		key := period(t.In(loc))
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		if len(seen) == *count {
+			return
+		}
+		seen[key] = struct{}{}
+		keep[i] = true
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicy_Expired(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	// two backups a day for the last 90 days
+	var backups []time.Time
+	for i := 0; i < 90; i++ {
+		backups = append(backups, now.Add(-time.Duration(i)*day), now.Add(-time.Duration(i)*day-time.Hour))
+	}
+
+	tests := []struct {
+		name     string
+		policy   *RetentionPolicy
+		retained int
+	}{
+		{
+			name:     "Nil",
+			policy:   nil,
+			retained: len(backups),
+		},
+		{
+			name:     "Empty",
+			policy:   &RetentionPolicy{},
+			retained: len(backups),
+		},
+		{
+			name:     "KeepLast",
+			policy:   &RetentionPolicy{KeepLast: util.Ptr(5)},
+			retained: 5,
+		},
+		{
+			name:     "MaxAge",
+			policy:   &RetentionPolicy{MaxAge: util.Ptr("48h1m")},
+			retained: 5,
+		},
+		{
+			name:     "Daily",
+			policy:   &RetentionPolicy{Daily: util.Ptr(7)},
+			retained: 7,
+		},
+		{
+			name:     "Monthly",
+			policy:   &RetentionPolicy{Monthly: util.Ptr(2)},
+			retained: 2,
+		},
+		{
+			name:     "Combined",
+			policy:   &RetentionPolicy{KeepLast: util.Ptr(2), Daily: util.Ptr(3), Monthly: util.Ptr(3)},
+			retained: 6, // 2 latest, 2 more days, 2 previous months
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired := tt.policy.Expired(backups, now, time.UTC)
+			assert.Len(t, expired, len(backups)-tt.retained)
+			assert.NotContains(t, expired, now)
+		})
+	}
+}
+
+func TestRetentionPolicy_ExpiredTimeZone(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	backups := []time.Time{
+		time.Date(2024, 3, 30, 22, 30, 0, 0, time.UTC), // March 31 local time
+		time.Date(2024, 3, 30, 21, 30, 0, 0, time.UTC), // March 30 local time
+		time.Date(2024, 3, 29, 21, 30, 0, 0, time.UTC), // March 29 local time
+	}
+	policy := &RetentionPolicy{Daily: util.Ptr(2)}
+
+	assert.Equal(t, []time.Time{backups[1]}, policy.Expired(backups, now, time.UTC))
+	assert.Equal(t, []time.Time{backups[2]}, policy.Expired(backups, now, loc))
+}
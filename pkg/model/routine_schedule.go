@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// RoutineSchedule represents the schedule and the last runs of a backup routine.
+type RoutineSchedule struct {
+	// Disabled routines are not scheduled.
+	Disabled bool
+	// The next fire times of the full backup trigger.
+	NextFullBackups []time.Time
+	// The next fire times of the incremental backup trigger.
+	NextIncrementalBackups []time.Time
+	// A catch-up full backup is scheduled on startup if the last full backup
+	// is older than the schedule interval.
+	CatchUpPending bool
+	// The currently running backups.
+	Running *CurrentBackups
+	// The state of the routine with the results of the last runs.
+	State *BackupState
+}
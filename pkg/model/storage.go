@@ -8,6 +8,12 @@ type Storage interface {
 	storage()
 }
 
+// SameLocation returns true if the storages point to the same location,
+// regardless of their credentials and client options.
+func SameLocation(a, b Storage) bool {
+	return a != nil && b != nil && fmt.Sprint(a) == fmt.Sprint(b)
+}
+
 type LocalStorage struct {
 	// Path is the root directory where backups will be stored locally.
 	Path string
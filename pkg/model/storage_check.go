@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// StorageOperation is an operation of the storage check.
+type StorageOperation string
+
+const (
+	// StorageWrite writes the probe object.
+	StorageWrite StorageOperation = "write"
+	// StorageList lists the probe folder.
+	StorageList StorageOperation = "list"
+	// StorageRead reads the probe object back.
+	StorageRead StorageOperation = "read"
+	// StorageDelete deletes the probe folder.
+	StorageDelete StorageOperation = "delete"
+)
+
+// StorageOperationResult is the result of an operation of the storage check.
+type StorageOperationResult struct {
+	// The checked operation.
+	Operation StorageOperation
+	// The time the operation took.
+	Latency time.Duration
+	// The provider error of a failed operation.
+	Error string
+}
+
+// StorageCheck is the result of checking that the service can write, list,
+// read and delete objects in a storage.
+type StorageCheck struct {
+	// All the operations succeeded.
+	Success bool
+	// The first failed operation, empty on success.
+	FailedOperation StorageOperation
+	// The results of the performed operations, in order.
+	Operations []StorageOperationResult
+}
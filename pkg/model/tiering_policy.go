@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// TieringPolicy defines when the backups of a routine are moved to
+// a cheaper storage.
+type TieringPolicy struct {
+	// The backups older than this are moved.
+	After time.Duration
+	// The storage to move the backups to.
+	MoveTo Storage
+}
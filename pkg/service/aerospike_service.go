@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 
 	_ "github.com/aerospike/aerospike-backup-service/v2/modules/schema" // it's required to load configuration schemas in init method
@@ -14,7 +15,14 @@ import (
 	"github.com/go-logr/logr"
 )
 
-const namespaceInfo = "namespaces"
+const (
+	namespaceInfo       = "namespaces"
+	clusterNameInfo     = "cluster-name"
+	namespaceStatsInfo  = "namespace/"
+	dataUsedBytesStat   = "data_used_bytes"
+	deviceUsedBytesStat = "device_used_bytes"
+	memoryUsedBytesStat = "memory_used_bytes"
+)
 
 // getAllNamespacesOfCluster retrieves a list of all namespaces in an Aerospike cluster.
 func getAllNamespacesOfCluster(client backup.AerospikeClient) ([]string, error) {
@@ -30,6 +38,66 @@ func getAllNamespacesOfCluster(client backup.AerospikeClient) ([]string, error)
 	return strings.Split(namespaces, ";"), nil
 }
 
+// getClusterName returns the name of the cluster reported by a random node.
+func getClusterName(client backup.AerospikeClient) (string, error) {
+	node, err := client.Cluster().GetRandomNode()
+	if err != nil {
+		return "", fmt.Errorf("failed to get node: %w", err)
+	}
+	infoRes, err := node.RequestInfo(&as.InfoPolicy{}, clusterNameInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster info: %w", err)
+	}
+	return infoRes[clusterNameInfo], nil
+}
+
+// getNamespaceSizes returns the used bytes of the namespaces summed over the
+// active nodes of the cluster.
+func getNamespaceSizes(client backup.AerospikeClient, namespaces []string) (map[string]uint64, error) {
+	commands := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		commands = append(commands, namespaceStatsInfo+namespace)
+	}
+
+	sizes := make(map[string]uint64, len(namespaces))
+	for _, node := range client.GetNodes() {
+		if !node.IsActive() {
+			continue
+		}
+		infoRes, err := node.RequestInfo(&as.InfoPolicy{}, commands...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace info from node %s: %w", node.GetName(), err)
+		}
+		for _, namespace := range namespaces {
+			sizes[namespace] += namespaceUsedBytes(infoRes[namespaceStatsInfo+namespace])
+		}
+	}
+
+	return sizes, nil
+}
+
+// namespaceUsedBytes parses the used bytes from the namespace statistics,
+// data_used_bytes of server 7 or the sum of device and memory used bytes
+// of the earlier versions.
+func namespaceUsedBytes(stats string) uint64 {
+	values := make(map[string]uint64)
+	for _, pair := range strings.Split(stats, ";") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			values[key] = n
+		}
+	}
+
+	if used, ok := values[dataUsedBytesStat]; ok {
+		return used
+	}
+
+	return values[deviceUsedBytesStat] + values[memoryUsedBytesStat]
+}
+
 func getClusterConfiguration(client backup.AerospikeClient) []asconfig.DotConf {
 	activeHosts := getActiveHosts(client)
 
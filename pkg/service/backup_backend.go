@@ -7,10 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -22,41 +25,191 @@ import (
 // BackupBackend handles the backup management logic, employing a StorageAccessor
 // implementation for I/O operations.
 type BackupBackend struct {
-	storage                model.Storage
+	storage model.Storage
+	// the storages the backups are copied to, by name
+	replicas map[string]model.Storage
+	// the backups moved to the storage of the tiering policy, nil if not set
+	tier *BackupBackend
+	// the backups in the storages the routine used before, read-only
+	history []*BackupBackend
+	// the catalog of a read-only backend is not written to its storage
+	readOnly               bool
 	fullBackupsPath        string
 	incrementalBackupsPath string
 	stateFilePath          string
-	removeFullBackup       bool
+	// the catalog file of the routine, the backup metadata files are read
+	// if not set
+	catalogPath      string
+	removeFullBackup bool
 
 	// BackupBackend needs to know if full backup is running to filter it out
 	fullBackupInProgress *atomic.Bool
+	// the routine state, updated when backups are deleted
+	state *model.BackupState
 }
 
 var _ BackupListReader = (*BackupBackend)(nil)
 
+var (
+	// ErrFullBackupInProgress is returned when backups cannot be modified
+	// because a full backup of the routine is running.
+	ErrFullBackupInProgress = errors.New("full backup is in progress")
+	// ErrBackupInProgress is returned when the routine cannot be changed
+	// because one of its backups is running.
+	ErrBackupInProgress = errors.New("backup is in progress")
+	// ErrDependentBackups is returned on attempt to delete a full backup
+	// with dependent incremental backups.
+	ErrDependentBackups = errors.New("full backup has dependent incremental backups")
+	// ErrHistoricalBackup is returned on attempt to delete a backup stored
+	// in a historical storage of the routine.
+	ErrHistoricalBackup = errors.New("backup is in a read-only historical storage")
+)
+
 func newBackend(routineName string, routine *model.BackupRoutine) *BackupBackend {
+	// the backups of a renamed routine are kept under its previous name
+	if routine.StoragePrefix != "" {
+		routineName = routine.StoragePrefix
+	}
 	removeFullBackup := routine.BackupPolicy.RemoveFiles.RemoveFullBackup()
+	backend := newStorageBackend(routineName, routine.Storage, removeFullBackup)
+	backend.replicas = routine.ReplicateTo
+	if routine.Tiering != nil {
+		backend.tier = newStorageBackend(routineName, routine.Tiering.MoveTo, removeFullBackup)
+	}
+	for _, s := range routine.HistoricalStorages {
+		historical := newStorageBackend(routineName, s, removeFullBackup)
+		historical.readOnly = true
+		backend.history = append(backend.history, historical)
+	}
+
+	return backend
+}
+
+// fullBackupFlags keeps the full backup in progress flags by storage location,
+// so that the backends created by a configuration change keep the flag of
+// the full backup which is running.
+var fullBackupFlags sync.Map
+
+// fullBackupFlag returns the full backup in progress flag of the backups path
+// in the storage.
+func fullBackupFlag(s model.Storage, fullBackupsPath string) *atomic.Bool {
+	flag, _ := fullBackupFlags.LoadOrStore(fmt.Sprintf("%s/%s", s, fullBackupsPath), &atomic.Bool{})
+	return flag.(*atomic.Bool)
+}
+
+// newStorageBackend returns the backend of the routine backups in the storage.
+func newStorageBackend(routineName string, s model.Storage, removeFullBackup bool) *BackupBackend {
+	fullBackupsPath := filepath.Join(routineName, model.FullBackupDirectory)
 	return &BackupBackend{
-		storage:                routine.Storage,
-		fullBackupsPath:        filepath.Join(routineName, model.FullBackupDirectory),
+		storage:                s,
+		fullBackupsPath:        fullBackupsPath,
 		incrementalBackupsPath: filepath.Join(routineName, model.IncrementalBackupDirectory),
 		stateFilePath:          filepath.Join(routineName, model.StateFileName),
+		catalogPath:            filepath.Join(routineName, catalogFile),
 		removeFullBackup:       removeFullBackup,
-		fullBackupInProgress:   &atomic.Bool{},
+		fullBackupInProgress:   fullBackupFlag(s, fullBackupsPath),
 	}
 }
 
+// readState loads the routine state from the state file.
+// If the file is missing or corrupt, the state is read from the state file of
+// the most recent historical storage, as the state of a routine which storage
+// was changed stays there until the first backup to the new storage, and
+// restored from the list of existing backups otherwise.
 func (b *BackupBackend) readState() *model.BackupState {
+	if state := b.readStateFile(); state != nil {
+		return state
+	}
+	for i := len(b.history) - 1; i >= 0; i-- {
+		if state := b.history[i].readStateFile(); state != nil {
+			return state
+		}
+	}
+
+	return b.readStateFromBackupList()
+}
+
+// readStateFile returns the routine state from the state file of the storage,
+// nil if it is missing or corrupt.
+func (b *BackupBackend) readStateFile() *model.BackupState {
+	logger := slog.Default().With(slog.String("path", b.stateFilePath),
+		slog.String("storage", fmt.Sprint(b.storage)))
+
+	data, err := storage.ReadFile(context.Background(), b.storage, b.stateFilePath)
+	if err == nil {
+		state, err := model.NewStateFromBytes(data)
+		if err == nil {
+			return state
+		}
+		logger.Warn("Corrupt backup state file",
+			slog.Any("err", err))
+	} else {
+		logger.Debug("Could not read backup state file",
+			slog.Any("err", err))
+	}
+
+	return nil
+}
+
+// loadState reads the routine state and keeps it to update the last run
+// times when backups are deleted.
+func (b *BackupBackend) loadState() *model.BackupState {
+	b.state = b.readState()
+	return b.state
+}
+
+// refreshState updates the last run times of the state from the remaining
+// backups, so that the next runs do not rely on deleted backups.
+func (b *BackupBackend) refreshState(ctx context.Context) {
+	if b.state == nil {
+		return
+	}
+
+	restored := b.readStateFromBackupList()
+	b.state.SetLastRuns(restored.LastFullRun, restored.LastIncrRun)
+	if err := b.writeState(ctx, b.state); err != nil {
+		slog.Error("Could not write backup state",
+			slog.String("path", b.stateFilePath),
+			slog.Any("err", err))
+	}
+}
+
+// readStateFromBackupList restores the routine state from the backups of all
+// its storages, including the historical ones, so that a routine which
+// storage was changed does not start with a full backup.
+func (b *BackupBackend) readStateFromBackupList() *model.BackupState {
 	to := model.NewTimeBoundsTo(time.Now())
-	fullBackupList, _ := b.FullBackupList(context.Background(), to)
-	incrementalBackupList, _ := b.IncrementalBackupList(context.Background(), to)
+	fullBackupList, _ := b.readMetadataList(context.Background(), to, true)
+	incrementalBackupList, _ := b.readMetadataList(context.Background(), to, false)
 
 	return &model.BackupState{
 		LastFullRun: lastBackupTime(fullBackupList),
 		LastIncrRun: lastBackupTime(incrementalBackupList),
+		Performed:   countBackups(fullBackupList),
 	}
 }
 
+// writeState persists the routine state to the state file.
+func (b *BackupBackend) writeState(ctx context.Context, state *model.BackupState) error {
+	data, err := state.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return storage.ReplaceFile(ctx, b.storage, b.stateFilePath, data)
+}
+
+// countBackups returns the number of distinct backups in the list
+// (each backup has an entry per namespace).
+func countBackups(b []model.BackupDetails) int {
+	timestamps := make(map[int64]struct{}, len(b))
+	for i := range b {
+		timestamps[b[i].Created.UnixMilli()] = struct{}{}
+	}
+
+	return len(timestamps)
+}
+
 func lastBackupTime(b []model.BackupDetails) time.Time {
 	if len(b) > 0 {
 		return latestBackupBeforeTime(b, time.Now())[0].Created
@@ -65,14 +218,87 @@ func lastBackupTime(b []model.BackupDetails) time.Time {
 	return time.Time{}
 }
 
+// writeBackupMetadata writes the metadata with its checksum to the backup folder.
 func (b *BackupBackend) writeBackupMetadata(ctx context.Context, path string, metadata model.BackupMetadata) error {
+	checksum, err := metadata.ComputeChecksum()
+	if err != nil {
+		return err
+	}
+	metadata.Checksum = checksum
+
 	dataYaml, err := yaml.Marshal(metadata)
 	if err != nil {
 		return err
 	}
 
 	metadataFilePath := filepath.Join(path, metadataFile)
-	return storage.WriteFile(ctx, b.storage, metadataFilePath, dataYaml)
+	if err := storage.WriteFile(ctx, b.storage, metadataFilePath, dataYaml); err != nil {
+		return err
+	}
+
+	b.addToCatalog(ctx, path, metadata)
+	return nil
+}
+
+// writeVerificationResult stores the verification result next to the
+// metadata of the namespace backup.
+func (b *BackupBackend) writeVerificationResult(ctx context.Context, details *model.BackupDetails,
+	result *model.VerificationResult) error {
+	data, err := yaml.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return storage.ReplaceFile(ctx, details.Storage, filepath.Join(details.Key, verificationFile), data)
+}
+
+// ReadVerificationResults returns the results of the last verification of
+// the full or incremental backup created at the given time.
+// Namespaces which were not verified are omitted.
+func (b *BackupBackend) ReadVerificationResults(ctx context.Context, created time.Time,
+) ([]model.VerificationResult, error) {
+	backups, err := b.findBackup(ctx, created)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []model.VerificationResult
+	for i := range backups {
+		data, err := storage.ReadFile(ctx, backups[i].Storage, filepath.Join(backups[i].Key, verificationFile))
+		if err != nil {
+			continue // not verified yet
+		}
+		result, err := model.NewVerificationResultFromBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding verification result: %w", err)
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// findBackup returns the namespace backups of the full or incremental
+// backup created at the given time.
+func (b *BackupBackend) findBackup(ctx context.Context, created time.Time) ([]model.BackupDetails, error) {
+	bounds, _ := model.NewTimeBounds(&created, &created)
+	fullBackups, err := b.FullBackupList(ctx, bounds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read full backup list: %w", err)
+	}
+	if len(fullBackups) > 0 {
+		return fullBackups, nil
+	}
+
+	incrBackups, err := b.IncrementalBackupList(ctx, bounds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read incremental backup list: %w", err)
+	}
+	if len(incrBackups) > 0 {
+		return incrBackups, nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrBackupNotFound, created.UnixMilli())
 }
 
 // FullBackupList returns a list of available full backups.
@@ -87,7 +313,116 @@ func (b *BackupBackend) IncrementalBackupList(ctx context.Context, timeBounds *m
 	return b.readMetadataList(ctx, timeBounds, false)
 }
 
+// readMetadataList returns the backups of the routine storage, of the
+// tiering storage and of the historical storages, sorted by creation time.
+// The historical storages which cannot be read are skipped.
 func (b *BackupBackend) readMetadataList(ctx context.Context, timebounds *model.TimeBounds, isFullBackup bool,
+) ([]model.BackupDetails, error) {
+	backups, err := b.readOwnMetadataList(ctx, timebounds, isFullBackup)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, historical := range b.history {
+		historicalBackups, err := historical.readStorageMetadataList(ctx, timebounds, isFullBackup)
+		if err != nil {
+			slog.Warn("Could not read backups of historical storage",
+				slog.String("storage", fmt.Sprint(historical.storage)),
+				slog.Any("err", err))
+			continue
+		}
+		backups = mergeBackupLists(backups, historicalBackups)
+	}
+
+	return backups, nil
+}
+
+// readOwnMetadataList returns the backups of the routine storage and of the
+// tiering storage, sorted by creation time. These are the backups managed by
+// the routine, i.e. deleted by the retention policy.
+func (b *BackupBackend) readOwnMetadataList(ctx context.Context, timebounds *model.TimeBounds, isFullBackup bool,
+) ([]model.BackupDetails, error) {
+	backups, err := b.readStorageMetadataList(ctx, timebounds, isFullBackup)
+	if err != nil || b.tier == nil {
+		return backups, err
+	}
+
+	tierBackups, err := b.tier.readStorageMetadataList(ctx, timebounds, isFullBackup)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read tiering storage: %w", err)
+	}
+
+	return mergeBackupLists(backups, tierBackups), nil
+}
+
+// mergeBackupLists merges the backup lists of two storages, sorted by
+// creation time. The backups of the first list take precedence, as a backup
+// being moved is in both storages.
+func mergeBackupLists(first, second []model.BackupDetails) []model.BackupDetails {
+	keys := make(map[string]struct{}, len(first))
+	for i := range first {
+		keys[first[i].Key] = struct{}{}
+	}
+
+	merged := slices.Clone(first)
+	for i := range second {
+		if _, found := keys[second[i].Key]; !found {
+			merged = append(merged, second[i])
+		}
+	}
+	slices.SortStableFunc(merged, func(a, b model.BackupDetails) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	return merged
+}
+
+// readStorageMetadataList returns the backups of the storage from the catalog,
+// or from the metadata files if the catalog cannot be loaded.
+func (b *BackupBackend) readStorageMetadataList(ctx context.Context, timebounds *model.TimeBounds, isFullBackup bool,
+) ([]model.BackupDetails, error) {
+	if b.catalogPath != "" {
+		backups, err := b.catalogBackups(ctx, timebounds, isFullBackup)
+		if err == nil {
+			return backups, nil
+		}
+		slog.Warn("Could not load backup catalog, reading backup metadata",
+			slog.String("path", b.catalogPath),
+			slog.Any("err", err))
+	}
+
+	backups, err := b.scanMetadataList(ctx, b.storage, timebounds, isFullBackup)
+	if err != nil && len(b.replicas) > 0 {
+		return b.scanReplicaMetadataList(ctx, timebounds, isFullBackup, err)
+	}
+
+	return backups, err
+}
+
+// scanReplicaMetadataList reads the metadata files of the backups from the
+// first readable replica, when the primary storage is unreachable.
+// The replicas may miss the backups which are not replicated yet.
+func (b *BackupBackend) scanReplicaMetadataList(
+	ctx context.Context, timebounds *model.TimeBounds, isFullBackup bool, primaryErr error,
+) ([]model.BackupDetails, error) {
+	errs := []error{primaryErr}
+	for _, name := range replicaNames(b.replicas) {
+		backups, err := b.scanMetadataList(ctx, b.replicas[name], timebounds, isFullBackup)
+		if err == nil {
+			slog.Warn("Could not read backup metadata from storage, listing backups of replica",
+				slog.String("replica", name),
+				slog.Any("err", primaryErr))
+			return backups, nil
+		}
+		errs = append(errs, fmt.Errorf("replica %s: %w", name, err))
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// scanMetadataList reads the metadata files of the backups in the storage.
+func (b *BackupBackend) scanMetadataList(
+	ctx context.Context, s model.Storage, timebounds *model.TimeBounds, isFullBackup bool,
 ) ([]model.BackupDetails, error) {
 	var backupRoot string
 	if isFullBackup {
@@ -95,7 +430,7 @@ func (b *BackupBackend) readMetadataList(ctx context.Context, timebounds *model.
 	} else {
 		backupRoot = b.incrementalBackupsPath
 	}
-	files, err := storage.ReadFiles(ctx, b.storage, backupRoot, metadataFile, timebounds.FromTime)
+	files, err := storage.ReadFiles(ctx, s, backupRoot, metadataFile, timebounds.FromTime)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) || strings.Contains(err.Error(), "is empty") {
 			return nil, nil
@@ -113,7 +448,7 @@ func (b *BackupBackend) readMetadataList(ctx context.Context, timebounds *model.
 			backups = append(backups, model.BackupDetails{
 				BackupMetadata: *metadata,
 				Key:            getKey(backupRoot, metadata, b.removeFullBackup && isFullBackup),
-				Storage:        b.storage,
+				Storage:        s,
 			})
 		}
 	}
@@ -130,7 +465,7 @@ func (b *BackupBackend) FindLastFullBackup(toTime time.Time) ([]model.BackupDeta
 
 	fullBackup := latestBackupBeforeTime(fullBackupList, toTime) // it's a list of namespaces
 	if len(fullBackup) == 0 {
-		return nil, fmt.Errorf("%w: %s", errBackupNotFound, toTime)
+		return nil, fmt.Errorf("%w: %s", ErrBackupNotFound, toTime)
 	}
 	return fullBackup, nil
 }
@@ -180,6 +515,138 @@ func (b *BackupBackend) FindIncrementalBackupsForNamespace(
 	return filteredIncrementalBackups, nil
 }
 
+// DeleteFullBackup deletes the full backup created at the given time.
+// The incremental backups depending on it are deleted if cascade is set,
+// otherwise ErrDependentBackups is returned.
+func (b *BackupBackend) DeleteFullBackup(ctx context.Context, created time.Time, cascade bool) error {
+	// hold the flag, so that backups of the routine do not start while deleting
+	if !b.fullBackupInProgress.CompareAndSwap(false, true) {
+		return ErrFullBackupInProgress
+	}
+	defer b.fullBackupInProgress.Store(false)
+
+	fullBackups, err := b.readOwnMetadataList(ctx, model.NewTimeBoundsTo(time.Now()), true)
+	if err != nil {
+		return fmt.Errorf("cannot read full backup list: %w", err)
+	}
+	fullTimes := backupTimes(fullBackups)
+	if !slices.ContainsFunc(fullTimes, created.Equal) {
+		return b.notFoundError(ctx, created)
+	}
+
+	incrBackups, err := b.readOwnMetadataList(ctx, model.NewTimeBoundsFrom(created), false)
+	if err != nil {
+		return fmt.Errorf("cannot read incremental backup list: %w", err)
+	}
+	dependent := dependentIncrementalBackups(fullTimes, backupTimes(incrBackups), created)
+	if len(dependent) > 0 && !cascade {
+		return fmt.Errorf("%w: %d", ErrDependentBackups, len(dependent))
+	}
+
+	defer b.refreshState(ctx)
+	for _, incr := range dependent {
+		if err := b.deleteIncrementalBackup(ctx, incr); err != nil {
+			return err
+		}
+	}
+
+	return b.deleteFullBackup(ctx, created)
+}
+
+// DeleteIncrementalBackup deletes the incremental backup created at the given time.
+func (b *BackupBackend) DeleteIncrementalBackup(ctx context.Context, created time.Time) error {
+	if !b.fullBackupInProgress.CompareAndSwap(false, true) {
+		return ErrFullBackupInProgress
+	}
+	defer b.fullBackupInProgress.Store(false)
+
+	bounds, _ := model.NewTimeBounds(&created, &created)
+	incrBackups, err := b.readOwnMetadataList(ctx, bounds, false)
+	if err != nil {
+		return fmt.Errorf("cannot read incremental backup list: %w", err)
+	}
+	if !slices.ContainsFunc(backupTimes(incrBackups), created.Equal) {
+		return b.notFoundError(ctx, created)
+	}
+
+	defer b.refreshState(ctx)
+	return b.deleteIncrementalBackup(ctx, created)
+}
+
+// notFoundError returns the error of a backup missing from the routine
+// storages, which is ErrHistoricalBackup if it is in a historical storage.
+func (b *BackupBackend) notFoundError(ctx context.Context, created time.Time) error {
+	if len(b.history) > 0 {
+		if backups, err := b.findBackup(ctx, created); err == nil && len(backups) > 0 {
+			return fmt.Errorf("%w: %d", ErrHistoricalBackup, created.UnixMilli())
+		}
+	}
+
+	return fmt.Errorf("%w: %d", ErrBackupNotFound, created.UnixMilli())
+}
+
+// deleteFullBackup deletes the folder of the full backup (data, metadata and
+// cluster configuration).
+func (b *BackupBackend) deleteFullBackup(ctx context.Context, created time.Time) error {
+	path := getFullBackupFolder(b.fullBackupsPath, created, b.removeFullBackup)
+	if err := b.deleteFolder(ctx, path); err != nil {
+		return fmt.Errorf("cannot delete full backup %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// deleteIncrementalBackup deletes the folder of the incremental backup.
+func (b *BackupBackend) deleteIncrementalBackup(ctx context.Context, created time.Time) error {
+	path := getIncrementalPath(b.incrementalBackupsPath, created)
+	if err := b.deleteFolder(ctx, path); err != nil {
+		return fmt.Errorf("cannot delete incremental backup %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// dependentIncrementalBackups returns the incremental backups depending on
+// the given full backup, i.e. created before the next full backup.
+// Both lists must be sorted.
+func dependentIncrementalBackups(fullTimes, incrTimes []time.Time, full time.Time) []time.Time {
+	var nextFull time.Time
+	for _, t := range fullTimes {
+		if t.After(full) {
+			nextFull = t
+			break
+		}
+	}
+
+	var dependent []time.Time
+	for _, incr := range incrTimes {
+		if incr.Before(full) || (!nextFull.IsZero() && !incr.Before(nextFull)) {
+			continue
+		}
+		dependent = append(dependent, incr)
+	}
+
+	return dependent
+}
+
+// deleteFolder deletes the folder from the storage and the tiering storage,
+// and the backups under it from the catalogs and the replicas.
+func (b *BackupBackend) deleteFolder(ctx context.Context, path string) error {
+	if err := storage.DeleteFolder(ctx, b.storage, path); err != nil {
+		return err
+	}
+	b.removeFromCatalog(ctx, path)
+
+	if b.tier != nil {
+		if err := b.tier.deleteFolder(ctx, path); err != nil {
+			return fmt.Errorf("tiering storage: %w", err)
+		}
+	}
+
+	b.deleteReplicaFolders(ctx, path)
+	return nil
+}
+
 func (b *BackupBackend) FullBackupInProgress() *atomic.Bool {
 	return b.fullBackupInProgress
 }
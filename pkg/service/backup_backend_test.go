@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strconv"
 	"sync/atomic"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/stretchr/testify/require"
 )
 
 const tempFolder = "./tmp"
@@ -58,3 +61,191 @@ func TestFullBackupKeepFiles(t *testing.T) {
 		_ = os.RemoveAll(tempFolder)
 	})
 }
+
+func TestBackupStateReadWrite(t *testing.T) {
+	backend := &BackupBackend{
+		storage:              &model.LocalStorage{Path: tempFolder},
+		fullBackupsPath:      "routine/backup",
+		stateFilePath:        "routine/" + model.StateFileName,
+		fullBackupInProgress: &atomic.Bool{},
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tempFolder)
+	})
+
+	state := model.NewBackupState()
+	state.SetLastFullRun(time.UnixMilli(10).UTC())
+	state.SetLastFullRun(time.UnixMilli(20).UTC())
+	state.SetNamespaceLastSuccess("source-ns1", time.UnixMilli(20).UTC())
+	state.SetLastError(errors.New("mock error"), time.UnixMilli(15).UTC())
+	require.NoError(t, backend.writeState(context.Background(), state))
+
+	// overwrite with a shorter state to make sure the file is replaced
+	state = model.NewBackupState()
+	state.SetLastFullRun(time.UnixMilli(30).UTC())
+	require.NoError(t, backend.writeState(context.Background(), state))
+
+	restored := backend.readState()
+	require.Equal(t, time.UnixMilli(30).UTC(), restored.LastFullRun)
+	require.Equal(t, 1, restored.Performed)
+	require.Empty(t, restored.LastError)
+}
+
+func TestBackupStateCorruptFallback(t *testing.T) {
+	backend := &BackupBackend{
+		storage:              &model.LocalStorage{Path: tempFolder},
+		fullBackupsPath:      "routine/backup",
+		stateFilePath:        "routine/" + model.StateFileName,
+		fullBackupInProgress: &atomic.Bool{},
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tempFolder)
+	})
+
+	for _, t := range []int64{10, 20} {
+		path := backend.fullBackupsPath + "/" + strconv.FormatInt(t, 10) + "/data/source-ns1/"
+		_ = os.MkdirAll(path, 0744)
+		_ = backend.writeBackupMetadata(context.Background(), path, model.BackupMetadata{Created: time.UnixMilli(t)})
+	}
+	_ = os.WriteFile(tempFolder+"/"+backend.stateFilePath, []byte("last-run: [corrupt"), 0600)
+
+	restored := backend.readState()
+	require.True(t, restored.LastFullRun.Equal(time.UnixMilli(20)))
+	require.Equal(t, 2, restored.Performed)
+}
+
+func TestApplyRetention(t *testing.T) {
+	backend := &BackupBackend{
+		storage:                &model.LocalStorage{Path: tempFolder},
+		fullBackupsPath:        "routine/backup",
+		incrementalBackupsPath: "routine/incremental",
+		fullBackupInProgress:   &atomic.Bool{},
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tempFolder)
+	})
+
+	writeBackup := func(root string, created int64) {
+		path := root + "/" + strconv.FormatInt(created, 10) + "/data/source-ns1/"
+		_ = os.MkdirAll(path, 0744)
+		_ = backend.writeBackupMetadata(context.Background(), path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "source-ns1"})
+	}
+	for _, created := range []int64{10, 20, 30} {
+		writeBackup(backend.fullBackupsPath, created)
+	}
+	for _, created := range []int64{15, 16, 25, 35} {
+		writeBackup(backend.incrementalBackupsPath, created)
+	}
+
+	handler := &BackupRoutineHandler{
+		backend:          backend,
+		storage:          backend.storage,
+		routineName:      "routine",
+		backupRoutine:    &model.BackupRoutine{},
+		backupFullPolicy: &model.BackupPolicy{Retention: &model.RetentionPolicy{KeepLast: util.Ptr(2)}},
+	}
+	require.NoError(t, handler.applyRetention(context.Background(), time.UnixMilli(40)))
+
+	bounds := model.NewTimeBoundsTo(time.UnixMilli(40))
+	full, _ := backend.FullBackupList(context.Background(), bounds)
+	require.Equal(t, []int64{20, 30}, unixMillis(backupTimes(full)))
+	incremental, _ := backend.IncrementalBackupList(context.Background(), bounds)
+	require.Equal(t, []int64{25, 35}, unixMillis(backupTimes(incremental)))
+}
+
+func unixMillis(times []time.Time) []int64 {
+	result := make([]int64, 0, len(times))
+	for _, t := range times {
+		result = append(result, t.UnixMilli())
+	}
+	return result
+}
+
+func TestDeleteBackup(t *testing.T) {
+	backend := &BackupBackend{
+		storage:                &model.LocalStorage{Path: tempFolder},
+		fullBackupsPath:        "routine/backup",
+		incrementalBackupsPath: "routine/incremental",
+		stateFilePath:          "routine/state.yaml",
+		fullBackupInProgress:   &atomic.Bool{},
+		state:                  &model.BackupState{LastFullRun: time.UnixMilli(20), LastIncrRun: time.UnixMilli(25)},
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tempFolder)
+	})
+
+	writeBackup := func(root string, created int64) {
+		path := root + "/" + strconv.FormatInt(created, 10) + "/data/source-ns1/"
+		_ = os.MkdirAll(path, 0744)
+		_ = backend.writeBackupMetadata(context.Background(), path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "source-ns1"})
+	}
+	for _, created := range []int64{10, 20} {
+		writeBackup(backend.fullBackupsPath, created)
+	}
+	for _, created := range []int64{15, 25} {
+		writeBackup(backend.incrementalBackupsPath, created)
+	}
+	ctx := context.Background()
+	bounds := model.NewTimeBoundsTo(time.UnixMilli(100))
+
+	require.ErrorIs(t, backend.DeleteFullBackup(ctx, time.UnixMilli(11), false), ErrBackupNotFound)
+	require.ErrorIs(t, backend.DeleteIncrementalBackup(ctx, time.UnixMilli(16)), ErrBackupNotFound)
+	require.ErrorIs(t, backend.DeleteFullBackup(ctx, time.UnixMilli(10), false), ErrDependentBackups)
+
+	backend.fullBackupInProgress.Store(true)
+	require.ErrorIs(t, backend.DeleteFullBackup(ctx, time.UnixMilli(10), true), ErrFullBackupInProgress)
+	require.ErrorIs(t, backend.DeleteIncrementalBackup(ctx, time.UnixMilli(25)), ErrFullBackupInProgress)
+	backend.fullBackupInProgress.Store(false)
+
+	require.NoError(t, backend.DeleteFullBackup(ctx, time.UnixMilli(10), true))
+	require.True(t, backend.state.LastFullRun.Equal(time.UnixMilli(20)))
+	require.NoError(t, backend.DeleteIncrementalBackup(ctx, time.UnixMilli(25)))
+	require.True(t, backend.state.LastIncrRun.IsZero())
+	require.NoError(t, backend.DeleteFullBackup(ctx, time.UnixMilli(20), false))
+	require.True(t, backend.state.LastFullRunIsEmpty())
+	require.False(t, backend.fullBackupInProgress.Load())
+	// the state is persisted
+	require.True(t, backend.readState().LastFullRunIsEmpty())
+
+	full, _ := backend.FullBackupList(ctx, bounds)
+	require.Empty(t, full)
+	incremental, _ := backend.IncrementalBackupList(ctx, bounds)
+	require.Empty(t, incremental)
+}
+
+func TestRenamedRoutineBackend(t *testing.T) {
+	ctx := context.Background()
+	routine := &model.BackupRoutine{
+		BackupPolicy: &model.BackupPolicy{},
+		Storage:      &model.LocalStorage{Path: t.TempDir()},
+	}
+	backend := newBackend("routine", routine)
+	path := getFullPath(backend.fullBackupsPath, routine.BackupPolicy, "ns1", time.UnixMilli(10))
+	require.NoError(t, backend.writeBackupMetadata(ctx, path,
+		model.BackupMetadata{Created: time.UnixMilli(10), Namespace: "ns1"}))
+
+	// the backups of the renamed routine are listed under its previous name
+	routine.StoragePrefix = "routine"
+	renamed := newBackend("renamed", routine)
+	require.Equal(t, backend.fullBackupsPath, renamed.fullBackupsPath)
+	require.Equal(t, backend.stateFilePath, renamed.stateFilePath)
+	list, err := renamed.FullBackupList(ctx, model.NewTimeBoundsTo(time.UnixMilli(1000)))
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
+
+func TestFullBackupInProgressKeptByNewBackend(t *testing.T) {
+	routine := &model.BackupRoutine{
+		BackupPolicy: &model.BackupPolicy{},
+		Storage:      &model.LocalStorage{Path: t.TempDir()},
+	}
+	backend := newBackend("routine", routine)
+	require.True(t, backend.FullBackupInProgress().CompareAndSwap(false, true))
+	defer backend.FullBackupInProgress().Store(false)
+
+	// the backend created by a configuration change sees the running backup
+	require.True(t, newBackend("routine", routine).FullBackupInProgress().Load())
+	require.False(t, newBackend("other", routine).FullBackupInProgress().Load())
+}
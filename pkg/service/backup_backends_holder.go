@@ -1,6 +1,7 @@
 package service
 
 import (
+	"maps"
 	"sync"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
@@ -17,6 +18,8 @@ type BackendsHolder interface {
 	Get(routineName string) (*BackupBackend, bool)
 	// GetAllReaders returns all backends as a map routineName -> BackupListReader.
 	GetAllReaders() map[string]BackupListReader
+	// GetAll returns all backends as a map routineName -> BackupBackend.
+	GetAll() map[string]*BackupBackend
 }
 
 type BackendHolderImpl struct {
@@ -56,6 +59,12 @@ func (b *BackendHolderImpl) GetAllReaders() map[string]BackupListReader {
 	return readers
 }
 
+func (b *BackendHolderImpl) GetAll() map[string]*BackupBackend {
+	b.RLock()
+	defer b.RUnlock()
+	return maps.Clone(b.data)
+}
+
 func (b *BackendHolderImpl) Get(name string) (*BackupBackend, bool) {
 	b.RLock()
 	defer b.RUnlock()
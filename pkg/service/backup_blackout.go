@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// errBlackoutWindowStarted is the cause of the cancellation of a running
+// backup when a blackout window starts.
+var errBlackoutWindowStarted = fmt.Errorf("blackout window started: %w", errBackupCancelled)
+
+// activeBlackoutWindow returns the end of the blackout windows active at the
+// given time, and whether the runs should be deferred until then.
+// Runs are skipped if any of the active windows skips them.
+func (h *BackupRoutineHandler) activeBlackoutWindow(now time.Time) (end time.Time, deferRun bool, active bool) {
+	deferRun = true
+	for _, window := range h.blackoutWindows {
+		windowEnd, ok := window.ActiveAt(now)
+		if !ok {
+			continue
+		}
+		active = true
+		if windowEnd.After(end) {
+			end = windowEnd
+		}
+		deferRun = deferRun && window.Defer
+	}
+
+	return end, deferRun && active, active
+}
+
+// cancelOnBlackoutWindow cancels the run when the next blackout window
+// configured to cancel running backups starts.
+// The returned function stops the timer.
+func (h *BackupRoutineHandler) cancelOnBlackoutWindow(now time.Time, cancel context.CancelCauseFunc) func() {
+	var next time.Time
+	for _, window := range h.blackoutWindows {
+		if !window.CancelRunning {
+			continue
+		}
+		start, ok := window.NextStart(now)
+		if ok && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	if next.IsZero() {
+		return func() {}
+	}
+
+	timer := time.AfterFunc(next.Sub(now), func() {
+		slog.Info("Blackout window started, cancel running backup",
+			slog.String("routine", h.routineName))
+		cancel(errBlackoutWindowStarted)
+	})
+
+	return func() {
+		timer.Stop()
+	}
+}
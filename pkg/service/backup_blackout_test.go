@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveBlackoutWindow(t *testing.T) {
+	now := time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)
+	handler := &BackupRoutineHandler{
+		blackoutWindows: []*model.BlackoutWindow{
+			{From: time.Hour, To: 2 * time.Hour, Location: time.UTC, Defer: true},
+			{From: time.Hour, To: 3 * time.Hour, Location: time.UTC, Defer: true},
+			{From: 5 * time.Hour, To: 6 * time.Hour, Location: time.UTC},
+		},
+	}
+
+	end, deferRun, active := handler.activeBlackoutWindow(now)
+	require.True(t, active)
+	require.True(t, deferRun)
+	require.Equal(t, time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC), end)
+
+	// a skipping window takes precedence
+	handler.blackoutWindows[1].Defer = false
+	_, deferRun, active = handler.activeBlackoutWindow(now)
+	require.True(t, active)
+	require.False(t, deferRun)
+
+	_, _, active = handler.activeBlackoutWindow(now.Add(2 * time.Hour))
+	require.False(t, active)
+}
+
+func TestBackupJobSkippedInBlackoutWindow(t *testing.T) {
+	handler := &BackupRoutineHandler{
+		routineName: "blackoutRoutine",
+		blackoutWindows: []*model.BlackoutWindow{
+			{Cron: "* * * * * *", Duration: time.Hour, Location: time.UTC},
+		},
+	}
+	job := newBackupJob(handler, jobTypeIncremental)
+	skipped := incrBackupSkippedCounter.WithLabelValues(skipReasonBlackoutWindow)
+	before := testutil.ToFloat64(skipped)
+
+	require.NoError(t, job.Execute(context.Background()))
+	require.Equal(t, before+1, testutil.ToFloat64(skipped))
+}
+
+func TestCancelOnBlackoutWindow(t *testing.T) {
+	handler := &BackupRoutineHandler{
+		runs: NewBackupRuns(),
+		blackoutWindows: []*model.BlackoutWindow{
+			{Cron: "* * * * * *", Duration: time.Second, Location: time.UTC, CancelRunning: true},
+		},
+	}
+
+	// ad-hoc runs are not cancelled
+	adHocCtx, finishAdHoc := handler.trackRun(context.Background(), jobTypeIncremental, &backupJobTracker{})
+	defer finishAdHoc()
+
+	ctx, finish := handler.trackRun(context.Background(), jobTypeFull, nil)
+	defer finish()
+
+	select {
+	case <-ctx.Done():
+		require.ErrorIs(t, context.Cause(ctx), errBlackoutWindowStarted)
+		require.True(t, isCancelled(ctx))
+		require.NoError(t, adHocCtx.Err())
+	case <-time.After(3 * time.Second):
+		t.Fatal("backup was not cancelled when the blackout window started")
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// errBackupCancelled is the cause of the context cancellation when a
+	// running backup is cancelled by the user.
+	errBackupCancelled = errors.New("backup cancelled")
+	// errBackupSkipped is returned when a backup is skipped because another
+	// backup of the routine is in progress.
+	errBackupSkipped = errors.New("backup skipped")
+)
+
+// BackupCanceler cancels the running backups of the routines.
+type BackupCanceler interface {
+	// Cancel cancels the running backups of the routine and waits for them
+	// to stop. It returns false if no backup is running.
+	Cancel(ctx context.Context, routineName string) (bool, error)
+}
+
+// runningBackup allows to cancel a running backup and to wait for it to stop.
+type runningBackup struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// BackupRuns tracks the running backups of the routines. It outlives the
+// routine handlers, which are recreated on every configuration change, so
+// that the backups started by the previous handlers can still be cancelled.
+type BackupRuns struct {
+	sync.Mutex
+	runs map[string]map[jobType]*runningBackup
+}
+
+var _ BackupCanceler = (*BackupRuns)(nil)
+
+// NewBackupRuns returns a new BackupRuns instance.
+func NewBackupRuns() *BackupRuns {
+	return &BackupRuns{
+		runs: make(map[string]map[jobType]*runningBackup),
+	}
+}
+
+// track registers a running backup of the routine. It returns the context
+// to run the backup with, its cancel function and a function to call once
+// the backup has stopped and its resources are released.
+func (r *BackupRuns) track(
+	ctx context.Context, routineName string, backupType jobType,
+) (context.Context, context.CancelCauseFunc, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	run := &runningBackup{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	r.Lock()
+	if r.runs[routineName] == nil {
+		r.runs[routineName] = make(map[jobType]*runningBackup)
+	}
+	r.runs[routineName][backupType] = run
+	r.Unlock()
+
+	return ctx, cancel, func() {
+		r.Lock()
+		if r.runs[routineName][backupType] == run {
+			delete(r.runs[routineName], backupType)
+			if len(r.runs[routineName]) == 0 {
+				delete(r.runs, routineName)
+			}
+		}
+		r.Unlock()
+
+		cancel(nil)
+		close(run.done)
+	}
+}
+
+// Cancel cancels the running backups of the routine and waits for them to
+// stop. It returns false if no backup is running.
+func (r *BackupRuns) Cancel(ctx context.Context, routineName string) (bool, error) {
+	r.Lock()
+	runs := make([]*runningBackup, 0, len(r.runs[routineName]))
+	for _, run := range r.runs[routineName] {
+		runs = append(runs, run)
+	}
+	r.Unlock()
+
+	for _, run := range runs {
+		run.cancel(errBackupCancelled)
+	}
+
+	for _, run := range runs {
+		select {
+		case <-run.done:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+
+	return len(runs) > 0, nil
+}
+
+// trackRun registers a running backup of the given type. It returns the
+// context to run the backup with and a function to call once the backup
+// has stopped and its resources are released.
+// The tracker is set for ad-hoc runs, which are not cancelled by blackout
+// windows, as the windows apply to scheduled runs only.
+func (h *BackupRoutineHandler) trackRun(
+	ctx context.Context, backupType jobType, tracker *backupJobTracker,
+) (context.Context, func()) {
+	ctx, cancel, finish := h.runs.track(ctx, h.routineName, backupType)
+	if tracker != nil {
+		return ctx, finish
+	}
+
+	stopBlackoutTimer := h.cancelOnBlackoutWindow(time.Now(), cancel)
+	return ctx, func() {
+		stopBlackoutTimer()
+		finish()
+	}
+}
+
+// isCancelled returns true if the backup run was cancelled by the user.
+func isCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errBackupCancelled)
+}
+
+// waitForStop waits for the cancelled backup handlers to stop.
+func waitForStop(ctx context.Context, handlers map[string]BackupHandler) {
+	ctx = context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		_ = handler.Wait(ctx)
+	}
+}
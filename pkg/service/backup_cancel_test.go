@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelRunningBackup(t *testing.T) {
+	runs := NewBackupRuns()
+	handler := &BackupRoutineHandler{routineName: "cancelRoutine", runs: runs}
+
+	cancelled, err := runs.Cancel(context.Background(), handler.routineName)
+	require.NoError(t, err)
+	require.False(t, cancelled)
+
+	started := make(chan struct{})
+	stopped := make(chan bool, 1)
+	go func() {
+		ctx, finish := handler.trackRun(context.Background(), jobTypeFull, nil)
+		defer finish()
+		close(started)
+		<-ctx.Done()
+		stopped <- isCancelled(ctx)
+	}()
+	<-started
+
+	cancelled, err = runs.Cancel(context.Background(), handler.routineName)
+	require.NoError(t, err)
+	require.True(t, cancelled)
+	// Cancel returns only after the backup has stopped.
+	require.Len(t, stopped, 1)
+	require.True(t, <-stopped)
+
+	cancelled, err = runs.Cancel(context.Background(), handler.routineName)
+	require.NoError(t, err)
+	require.False(t, cancelled)
+}
+
+func TestCancelBackupOfReplacedHandler(t *testing.T) {
+	runs := NewBackupRuns()
+	const routineName = "replacedRoutine"
+	oldHandler := &BackupRoutineHandler{routineName: routineName, runs: runs}
+
+	ctx, finish := oldHandler.trackRun(context.Background(), jobTypeIncremental, nil)
+	go func() {
+		<-ctx.Done()
+		finish()
+	}()
+
+	// the handlers are recreated on configuration changes, the backup
+	// started by the previous handler is still cancelled by routine name
+	cancelled, err := runs.Cancel(testContext(t), routineName)
+	require.NoError(t, err)
+	require.True(t, cancelled)
+	require.True(t, isCancelled(ctx))
+
+	cancelled, err = runs.Cancel(context.Background(), "otherRoutine")
+	require.NoError(t, err)
+	require.False(t, cancelled)
+}
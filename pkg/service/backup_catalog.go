@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// backupCatalog is the index of the backups of a routine. It is stored in
+// the catalog file of the routine and updated on every backup write and
+// delete, so that the backups are listed without reading the metadata files
+// of all of them.
+type backupCatalog struct {
+	Full        []model.BackupMetadata `yaml:"full,omitempty"`
+	Incremental []model.BackupMetadata `yaml:"incremental,omitempty"`
+}
+
+// catalogCache caches the loaded catalogs by storage location, so that the
+// backends of a routine which share a catalog file, such as the ones replaced
+// by a configuration change and still used by running backups, update the
+// same catalog.
+type catalogCache struct {
+	sync.Mutex
+	entries map[string]*catalogEntry
+}
+
+// catalogEntry is the cached catalog of a storage location. Its lock guards
+// the load and update of the catalog, so that loading the catalog of one
+// routine does not block the others.
+type catalogEntry struct {
+	sync.Mutex
+	// nil if not loaded
+	catalog *backupCatalog
+}
+
+var catalogs = newCatalogCache(context.Background())
+
+func newCatalogCache(ctx context.Context) *catalogCache {
+	cache := &catalogCache{entries: make(map[string]*catalogEntry)}
+	go cache.startCleanup(ctx)
+	return cache
+}
+
+// entry returns the cache entry of the backend catalog.
+func (c *catalogCache) entry(b *BackupBackend) *catalogEntry {
+	key := fmt.Sprintf("%s/%s", b.storage, b.catalogPath)
+
+	c.Lock()
+	defer c.Unlock()
+	entry, found := c.entries[key]
+	if !found {
+		entry = &catalogEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// startCleanup drops the loaded catalogs every hour, to pick up the changes
+// made by other service instances.
+func (c *catalogCache) startCleanup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.clean()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *catalogCache) clean() {
+	c.Lock()
+	entries := make([]*catalogEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	c.Unlock()
+
+	for _, entry := range entries {
+		entry.Lock()
+		entry.catalog = nil
+		entry.Unlock()
+	}
+}
+
+func (c *backupCatalog) backups(isFullBackup bool) *[]model.BackupMetadata {
+	if isFullBackup {
+		return &c.Full
+	}
+	return &c.Incremental
+}
+
+// loadCatalog reads the catalog file of the routine.
+// If the file is missing or corrupt, the catalog is rebuilt from the backup
+// metadata files.
+func (b *BackupBackend) loadCatalog(ctx context.Context) (*backupCatalog, error) {
+	logger := slog.Default().With(slog.String("path", b.catalogPath))
+
+	data, err := storage.ReadFile(ctx, b.storage, b.catalogPath)
+	if err == nil {
+		var catalog backupCatalog
+		err = yaml.Unmarshal(data, &catalog)
+		if err == nil {
+			return &catalog, nil
+		}
+		logger.Warn("Corrupt backup catalog, rebuilding it from backup metadata",
+			slog.Any("err", err))
+	} else {
+		logger.Debug("Could not read backup catalog, rebuilding it from backup metadata",
+			slog.Any("err", err))
+	}
+
+	return b.rebuildCatalog(ctx)
+}
+
+// RebuildCatalog rebuilds the backup catalog of the routine from the
+// metadata files of its backups, to repair a catalog which is out of date.
+// It returns the number of the full and incremental namespace backups.
+func (b *BackupBackend) RebuildCatalog(ctx context.Context) (int, int, error) {
+	if b.catalogPath == "" {
+		return 0, 0, errors.New("backup catalog is not enabled")
+	}
+
+	entry := catalogs.entry(b)
+	entry.Lock()
+	defer entry.Unlock()
+
+	catalog, err := b.rebuildCatalog(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	entry.catalog = catalog
+
+	return len(catalog.Full), len(catalog.Incremental), nil
+}
+
+// rebuildCatalog reads the metadata files of the backups and writes them
+// to the catalog file.
+func (b *BackupBackend) rebuildCatalog(ctx context.Context) (*backupCatalog, error) {
+	catalog := &backupCatalog{}
+	for _, isFullBackup := range []bool{true, false} {
+		backups, err := b.scanMetadataList(ctx, b.storage, &model.TimeBounds{}, isFullBackup)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read backup metadata: %w", err)
+		}
+		list := catalog.backups(isFullBackup)
+		for i := range backups {
+			*list = append(*list, catalogMetadata(backups[i].BackupMetadata))
+		}
+		sortByCreated(*list)
+	}
+
+	if err := b.writeCatalog(ctx, catalog); err != nil {
+		// the catalog is still valid, the file is written on the next update
+		slog.Warn("Could not write backup catalog",
+			slog.String("path", b.catalogPath),
+			slog.Any("err", err))
+	}
+
+	return catalog, nil
+}
+
+func (b *BackupBackend) writeCatalog(ctx context.Context, catalog *backupCatalog) error {
+	if b.readOnly {
+		return nil // cached in memory only
+	}
+
+	data, err := yaml.Marshal(catalog)
+	if err != nil {
+		return err
+	}
+
+	return storage.ReplaceFile(ctx, b.storage, b.catalogPath, data)
+}
+
+// catalogMetadata returns the metadata to keep in the catalog. The file
+// manifest is left out to keep the catalog small, it is read from the
+// metadata file of the backup when the files are verified.
+func catalogMetadata(metadata model.BackupMetadata) model.BackupMetadata {
+	metadata.Files = nil
+	return metadata
+}
+
+// catalogBackups returns the catalog backups created within the time bounds.
+// The catalog is loaded if it is not cached.
+func (b *BackupBackend) catalogBackups(ctx context.Context, timebounds *model.TimeBounds, isFullBackup bool,
+) ([]model.BackupDetails, error) {
+	entry := catalogs.entry(b)
+	entry.Lock()
+	defer entry.Unlock()
+
+	if entry.catalog == nil {
+		catalog, err := b.loadCatalog(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entry.catalog = catalog
+	}
+
+	var backups []model.BackupDetails
+	for _, metadata := range *entry.catalog.backups(isFullBackup) {
+		if timebounds.Contains(metadata.Created) {
+			backups = append(backups, model.BackupDetails{
+				BackupMetadata: metadata,
+				Key:            b.backupKey(&metadata, isFullBackup),
+				Storage:        b.storage,
+			})
+		}
+	}
+
+	return backups, nil
+}
+
+// addToCatalog adds the metadata written to the backup folder to the
+// catalog, replacing the previous metadata of the folder.
+func (b *BackupBackend) addToCatalog(ctx context.Context, path string, metadata model.BackupMetadata) {
+	path = filepath.Clean(path)
+	isFullBackup := isSubPath(path, b.fullBackupsPath)
+	b.updateCatalog(ctx, func(catalog *backupCatalog) {
+		list := catalog.backups(isFullBackup)
+		*list = slices.DeleteFunc(*list, func(m model.BackupMetadata) bool {
+			return b.backupKey(&m, isFullBackup) == path
+		})
+		*list = append(*list, catalogMetadata(metadata))
+		sortByCreated(*list)
+	})
+}
+
+// removeFromCatalog removes the backups under the deleted path from the catalog.
+func (b *BackupBackend) removeFromCatalog(ctx context.Context, path string) {
+	path = filepath.Clean(path)
+	b.updateCatalog(ctx, func(catalog *backupCatalog) {
+		for _, isFullBackup := range []bool{true, false} {
+			list := catalog.backups(isFullBackup)
+			*list = slices.DeleteFunc(*list, func(m model.BackupMetadata) bool {
+				return isSubPath(b.backupKey(&m, isFullBackup), path)
+			})
+		}
+	})
+}
+
+// updateCatalog applies the update to the catalog and writes it.
+// The catalog file is read again before the update, so that the changes
+// written by other service instances are not overwritten.
+// If the catalog cannot be updated, it is loaded again on the next read,
+// and may miss the change until rebuilt.
+func (b *BackupBackend) updateCatalog(ctx context.Context, update func(catalog *backupCatalog)) {
+	if b.catalogPath == "" {
+		return
+	}
+
+	entry := catalogs.entry(b)
+	entry.Lock()
+	defer entry.Unlock()
+
+	catalog, err := b.loadCatalog(ctx)
+	if err == nil {
+		update(catalog)
+		err = b.writeCatalog(ctx, catalog)
+	}
+	if err != nil {
+		entry.catalog = nil
+		slog.Error("Could not update backup catalog, rebuild it if backups are missing from the list",
+			slog.String("path", b.catalogPath),
+			slog.Any("err", err))
+		return
+	}
+	entry.catalog = catalog
+}
+
+func (b *BackupBackend) backupKey(metadata *model.BackupMetadata, isFullBackup bool) string {
+	if isFullBackup {
+		return getKey(b.fullBackupsPath, metadata, b.removeFullBackup)
+	}
+	return getKey(b.incrementalBackupsPath, metadata, false)
+}
+
+// isSubPath returns true if the path is the parent path or is under it.
+func isSubPath(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, parent+"/")
+}
+
+func sortByCreated(list []model.BackupMetadata) {
+	slices.SortStableFunc(list, func(a, b model.BackupMetadata) int {
+		return a.Created.Compare(b.Created)
+	})
+}
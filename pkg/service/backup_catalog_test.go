@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newCatalogBackend(t *testing.T) *BackupBackend {
+	t.Helper()
+	return &BackupBackend{
+		storage:                &model.LocalStorage{Path: t.TempDir()},
+		fullBackupsPath:        "routine/backup",
+		incrementalBackupsPath: "routine/incremental",
+		catalogPath:            "routine/" + catalogFile,
+		fullBackupInProgress:   &atomic.Bool{},
+	}
+}
+
+func readCatalogFile(t *testing.T, backend *BackupBackend) *backupCatalog {
+	t.Helper()
+	data, err := storage.ReadFile(context.Background(), backend.storage, backend.catalogPath)
+	require.NoError(t, err)
+	var catalog backupCatalog
+	require.NoError(t, yaml.Unmarshal(data, &catalog))
+	return &catalog
+}
+
+func TestBackupCatalog(t *testing.T) {
+	ctx := context.Background()
+	backend := newCatalogBackend(t)
+	policy := &model.BackupPolicy{}
+	all := model.NewTimeBoundsTo(time.Now())
+
+	for _, created := range []int64{20, 10} {
+		path := getFullPath(backend.fullBackupsPath, policy, "ns1", time.UnixMilli(created))
+		require.NoError(t, backend.writeBackupMetadata(ctx, path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "ns1"}))
+	}
+	incrPath := getIncrementalPathForNamespace(backend.incrementalBackupsPath, "ns1", time.UnixMilli(30))
+	require.NoError(t, backend.writeBackupMetadata(ctx, incrPath,
+		model.BackupMetadata{Created: time.UnixMilli(30), Namespace: "ns1"}))
+
+	// the catalog file is updated on every write, sorted by time
+	catalog := readCatalogFile(t, backend)
+	require.Len(t, catalog.Full, 2)
+	require.Equal(t, time.UnixMilli(10), catalog.Full[0].Created.Local())
+	require.Len(t, catalog.Incremental, 1)
+
+	fullBackups, err := backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Len(t, fullBackups, 2)
+	require.Equal(t, "routine/backup/10/data/ns1", fullBackups[0].Key)
+
+	// the deleted backups are removed from the catalog
+	require.NoError(t, backend.DeleteIncrementalBackup(ctx, time.UnixMilli(30)))
+	incrBackups, err := backend.IncrementalBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Empty(t, incrBackups)
+	require.Empty(t, readCatalogFile(t, backend).Incremental)
+
+	// a backup written by another service instance is listed after rebuild
+	path := getFullPath(backend.fullBackupsPath, policy, "ns1", time.UnixMilli(40))
+	require.NoError(t, storage.WriteFile(ctx, backend.storage, filepath.Join(path, metadataFile),
+		[]byte("created: 1970-01-01T00:00:00.04Z\nnamespace: ns1\n")))
+	fullBackups, err = backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Len(t, fullBackups, 2)
+
+	full, incremental, err := backend.RebuildCatalog(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, full)
+	require.Equal(t, 0, incremental)
+	fullBackups, err = backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Len(t, fullBackups, 3)
+}
+
+func TestBackupCatalog_RemoveFullBackup(t *testing.T) {
+	ctx := context.Background()
+	backend := newCatalogBackend(t)
+	backend.removeFullBackup = true
+	policy := &model.BackupPolicy{RemoveFiles: util.Ptr(model.RemoveAll)}
+
+	// the full backup is replaced by every run
+	for _, created := range []int64{10, 20} {
+		path := getFullPath(backend.fullBackupsPath, policy, "ns1", time.UnixMilli(created))
+		require.NoError(t, backend.writeBackupMetadata(ctx, path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "ns1"}))
+	}
+
+	fullBackups, err := backend.FullBackupList(ctx, model.NewTimeBoundsTo(time.Now()))
+	require.NoError(t, err)
+	require.Len(t, fullBackups, 1)
+	require.Equal(t, time.UnixMilli(20), fullBackups[0].Created)
+	require.Equal(t, "routine/backup/data/ns1", fullBackups[0].Key)
+}
+
+func TestBackupCatalog_Corrupt(t *testing.T) {
+	ctx := context.Background()
+	backend := newCatalogBackend(t)
+	root := backend.storage.(*model.LocalStorage).Path
+
+	path := getFullPath(backend.fullBackupsPath, &model.BackupPolicy{}, "ns1", time.UnixMilli(10))
+	require.NoError(t, storage.WriteFile(ctx, backend.storage, filepath.Join(path, metadataFile),
+		[]byte("created: 1970-01-01T00:00:00.01Z\nnamespace: ns1\n")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, backend.catalogPath), []byte("full: ["), 0600))
+
+	// the catalog is rebuilt from the metadata files
+	fullBackups, err := backend.FullBackupList(ctx, model.NewTimeBoundsTo(time.Now()))
+	require.NoError(t, err)
+	require.Len(t, fullBackups, 1)
+	require.Len(t, readCatalogFile(t, backend).Full, 1)
+}
+
+func TestBackupCatalog_SharedByBackends(t *testing.T) {
+	ctx := context.Background()
+	backend := newCatalogBackend(t)
+	policy := &model.BackupPolicy{}
+	all := model.NewTimeBoundsTo(time.Now())
+
+	// the backend replaced by a configuration change keeps writing the
+	// backups which were running
+	replaced := *backend
+	stale := &replaced
+	for i, b := range []*BackupBackend{backend, stale, backend} {
+		created := time.UnixMilli(int64(10 * (i + 1)))
+		path := getFullPath(b.fullBackupsPath, policy, "ns1", created)
+		require.NoError(t, b.writeBackupMetadata(ctx, path, model.BackupMetadata{
+			Created:   created,
+			Namespace: "ns1",
+			Files:     []model.BackupFile{{Path: "ns1.asb", Size: 10}},
+		}))
+	}
+
+	for _, b := range []*BackupBackend{backend, stale} {
+		fullBackups, err := b.FullBackupList(ctx, all)
+		require.NoError(t, err)
+		require.Equal(t, []int64{10, 20, 30}, unixMillis(backupTimes(fullBackups)))
+	}
+
+	// the file manifest is kept in the metadata files only
+	catalog := readCatalogFile(t, backend)
+	require.Len(t, catalog.Full, 3)
+	for _, metadata := range catalog.Full {
+		require.Empty(t, metadata.Files)
+		require.NotEmpty(t, metadata.Checksum)
+	}
+}
+
+func TestBackupCatalog_MergedBeforeWrite(t *testing.T) {
+	ctx := context.Background()
+	backend := newCatalogBackend(t)
+	policy := &model.BackupPolicy{}
+	all := model.NewTimeBoundsTo(time.Now())
+
+	path := getFullPath(backend.fullBackupsPath, policy, "ns1", time.UnixMilli(10))
+	require.NoError(t, backend.writeBackupMetadata(ctx, path,
+		model.BackupMetadata{Created: time.UnixMilli(10), Namespace: "ns1"}))
+
+	// another service instance adds a backup to the catalog file
+	catalog := readCatalogFile(t, backend)
+	catalog.Full = append(catalog.Full, model.BackupMetadata{Created: time.UnixMilli(20), Namespace: "ns1"})
+	require.NoError(t, backend.writeCatalog(ctx, catalog))
+
+	path = getFullPath(backend.fullBackupsPath, policy, "ns1", time.UnixMilli(30))
+	require.NoError(t, backend.writeBackupMetadata(ctx, path,
+		model.BackupMetadata{Created: time.UnixMilli(30), Namespace: "ns1"}))
+
+	fullBackups, err := backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 20, 30}, unixMillis(backupTimes(fullBackups)))
+	require.Len(t, readCatalogFile(t, backend).Full, 3)
+}
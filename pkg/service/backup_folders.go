@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// foldersInUse keeps the backup folders written by the running backups or
+// read by the running restores, by storage, so that they are not deleted by
+// the garbage collector or moved to the tiering storage.
+var foldersInUse sync.Map
+
+// backupFolders is the set of the backup folders in use in a storage.
+// Its lock is held while a folder is checked and deleted.
+type backupFolders struct {
+	sync.Mutex
+	// path -> the number of its users
+	inUse map[string]int
+}
+
+// storageFolders returns the backup folders in use in the storage.
+func storageFolders(s model.Storage) *backupFolders {
+	folders, _ := foldersInUse.LoadOrStore(fmt.Sprint(s), &backupFolders{inUse: make(map[string]int)})
+	return folders.(*backupFolders)
+}
+
+// useBackupFolder marks the backup folder in the storage as in use, until
+// the returned function is called.
+func useBackupFolder(s model.Storage, path string) func() {
+	folders := storageFolders(s)
+	folders.Lock()
+	defer folders.Unlock()
+	folders.inUse[path]++
+
+	return func() {
+		folders.Lock()
+		defer folders.Unlock()
+		folders.inUse[path]--
+		if folders.inUse[path] == 0 {
+			delete(folders.inUse, path)
+		}
+	}
+}
+
+// isInUse returns true if a folder in use is the path, is under it or
+// contains it. The lock must be held.
+func (f *backupFolders) isInUse(path string) bool {
+	for folder := range f.inUse {
+		if isSubPath(folder, path) || isSubPath(path, folder) {
+			return true
+		}
+	}
+	return false
+}
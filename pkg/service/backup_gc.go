@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+)
+
+// GarbageCollector removes incomplete backups.
+type GarbageCollector interface {
+	// Collect removes the incomplete backups of all routines older than the
+	// configured minimum age and returns them. If dryRun is set, the backups
+	// are only returned.
+	Collect(ctx context.Context, dryRun bool) ([]model.IncompleteBackup, error)
+}
+
+// BackupGarbageCollector removes the folders of the backups which never
+// completed, i.e. have no metadata: the metadata is written only after the
+// backup data, so the folders without it are left by failed backups or
+// service crashes.
+type BackupGarbageCollector struct {
+	config   *model.GarbageCollector
+	backends BackendsHolder
+}
+
+var _ GarbageCollector = (*BackupGarbageCollector)(nil)
+
+// NewBackupGarbageCollector returns a new BackupGarbageCollector.
+func NewBackupGarbageCollector(config *model.GarbageCollector, backends BackendsHolder) *BackupGarbageCollector {
+	return &BackupGarbageCollector{
+		config:   config,
+		backends: backends,
+	}
+}
+
+// Start runs the garbage collector now and then at the configured interval,
+// until the context is done.
+func (gc *BackupGarbageCollector) Start(ctx context.Context) {
+	if gc.config.IsDisabled() {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(gc.config.GetIntervalOrDefault())
+		defer ticker.Stop()
+		for {
+			if _, err := gc.Collect(ctx, false); err != nil {
+				slog.Error("Could not remove incomplete backups", slog.Any("err", err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Collect removes the incomplete backups of all routines older than the
+// configured minimum age and returns them. If dryRun is set, the backups
+// are only returned.
+// The folders of the running backups are skipped.
+func (gc *BackupGarbageCollector) Collect(ctx context.Context, dryRun bool) ([]model.IncompleteBackup, error) {
+	before := time.Now().Add(-gc.config.GetMinAgeOrDefault())
+	backends := gc.backends.GetAll()
+
+	routineNames := make([]string, 0, len(backends))
+	for routineName := range backends {
+		routineNames = append(routineNames, routineName)
+	}
+	slices.Sort(routineNames)
+
+	var result []model.IncompleteBackup
+	for _, routineName := range routineNames {
+		backend := backends[routineName]
+		if dryRun {
+			backups, err := backend.findIncompleteBackups(ctx, routineName, before)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, backups...)
+			continue
+		}
+
+		backups, err := backend.deleteIncompleteBackups(ctx, routineName, before)
+		result = append(result, backups...)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// deleteIncompleteBackups deletes the incomplete backups of the routine
+// created before the given time and returns the deleted ones.
+// The folders of the running backups are not deleted.
+func (b *BackupBackend) deleteIncompleteBackups(
+	ctx context.Context, routineName string, before time.Time,
+) ([]model.IncompleteBackup, error) {
+	backups, err := b.findIncompleteBackups(ctx, routineName, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []model.IncompleteBackup
+	for _, backup := range backups {
+		ok, err := b.deleteIncompleteFolder(ctx, backup.Path)
+		if err != nil {
+			return deleted, err
+		}
+		if !ok {
+			slog.Debug("Backup is running or complete, skipping its removal",
+				slog.String("routine", routineName),
+				slog.String("path", backup.Path))
+			continue
+		}
+
+		deleted = append(deleted, backup)
+		gcDeletedCounter.WithLabelValues(routineName).Inc()
+		slog.Info("Deleted incomplete backup",
+			slog.String("routine", routineName),
+			slog.String("path", backup.Path))
+	}
+
+	return deleted, nil
+}
+
+// deleteIncompleteFolder deletes the folder of the incomplete backup, unless
+// the backup is running or its metadata was written since it was listed.
+// The backups of the storage cannot start while the folder is checked and
+// deleted. It returns true if the folder was deleted.
+func (b *BackupBackend) deleteIncompleteFolder(ctx context.Context, path string) (bool, error) {
+	folders := storageFolders(b.storage)
+	folders.Lock()
+	defer folders.Unlock()
+
+	if folders.isInUse(path) {
+		return false, nil
+	}
+	files, err := storage.ListFiles(ctx, b.storage, path)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		if filepath.Base(file) == metadataFile {
+			return false, nil
+		}
+	}
+
+	return true, b.deleteFolder(ctx, path)
+}
+
+// backupFolder is the content of a timestamped backup folder.
+type backupFolder struct {
+	path    string
+	created time.Time
+	// namespace -> the metadata file exists
+	namespaces map[string]bool
+}
+
+// findIncompleteBackups returns the backup folders of the routine without
+// metadata, created before the given time. If some namespaces of a backup
+// have metadata, the folders of the other namespaces are returned.
+// The full backups stored without a timestamp (RemoveFiles policy) are
+// replaced by every run and are not checked.
+func (b *BackupBackend) findIncompleteBackups(
+	ctx context.Context, routineName string, before time.Time,
+) ([]model.IncompleteBackup, error) {
+	roots := []string{b.incrementalBackupsPath}
+	if !b.removeFullBackup {
+		roots = append(roots, b.fullBackupsPath)
+	}
+
+	var result []model.IncompleteBackup
+	for _, root := range roots {
+		files, err := storage.ListFiles(ctx, b.storage, root)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, folder := range groupBackupFolders(root, files) {
+			if !folder.created.Before(before) {
+				continue
+			}
+			for _, path := range folder.incompletePaths() {
+				result = append(result, model.IncompleteBackup{
+					Routine: routineName,
+					Path:    path,
+					Created: folder.created,
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// incompletePaths returns the path of the folder if no namespace has
+// metadata, the paths of the namespaces without metadata otherwise.
+func (f *backupFolder) incompletePaths() []string {
+	var incomplete []string
+	for namespace, hasMetadata := range f.namespaces {
+		if !hasMetadata {
+			incomplete = append(incomplete, f.path+"/"+model.DataDirectory+"/"+namespace)
+		}
+	}
+	if len(incomplete) == len(f.namespaces) {
+		return []string{f.path}
+	}
+
+	slices.Sort(incomplete)
+	return incomplete
+}
+
+// groupBackupFolders groups the files under the root by the timestamped
+// backup folders, sorted by time. The files outside of them are ignored.
+func groupBackupFolders(root string, files []string) []*backupFolder {
+	folders := make(map[string]*backupFolder)
+	for _, file := range files {
+		parts := strings.Split(strings.TrimPrefix(file, root+"/"), "/")
+		millis, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || len(parts) < 2 {
+			continue
+		}
+
+		folder, found := folders[parts[0]]
+		if !found {
+			folder = &backupFolder{
+				path:       root + "/" + parts[0],
+				created:    time.UnixMilli(millis),
+				namespaces: make(map[string]bool),
+			}
+			folders[parts[0]] = folder
+		}
+		// <timestamp>/data/<namespace>/<file>
+		if len(parts) >= 4 && parts[1] == model.DataDirectory {
+			folder.namespaces[parts[2]] = folder.namespaces[parts[2]] ||
+				parts[len(parts)-1] == metadataFile
+		}
+	}
+
+	result := make([]*backupFolder, 0, len(folders))
+	for _, folder := range folders {
+		result = append(result, folder)
+	}
+	slices.SortFunc(result, func(a, b *backupFolder) int {
+		return a.created.Compare(b.created)
+	})
+
+	return result
+}
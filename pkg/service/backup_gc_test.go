@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type gcBackendsHolderMock struct {
+	BackendHolderMock
+	backends map[string]*BackupBackend
+}
+
+func (b *gcBackendsHolderMock) GetAll() map[string]*BackupBackend {
+	return b.backends
+}
+
+func TestGarbageCollector(t *testing.T) {
+	root := t.TempDir()
+	backend := &BackupBackend{
+		storage:                &model.LocalStorage{Path: root},
+		fullBackupsPath:        "routine/backup",
+		incrementalBackupsPath: "routine/incremental",
+		fullBackupInProgress:   &atomic.Bool{},
+	}
+	now := time.Now()
+	old := now.Add(-48 * time.Hour).UnixMilli()
+	recent := now.Add(-time.Hour).UnixMilli()
+
+	writeFile := func(path string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0744))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte("data"), 0600))
+	}
+	folder := func(rootPath string, created int64) string {
+		return rootPath + "/" + strconv.FormatInt(created, 10)
+	}
+
+	full := folder(backend.fullBackupsPath, old)
+	// complete namespace
+	writeFile(full + "/data/ns1/file.asb")
+	writeFile(full + "/data/ns1/" + metadataFile)
+	// incomplete namespace
+	writeFile(full + "/data/ns2/file.asb")
+	writeFile(full + "/configuration/aerospike_0.conf")
+	// incomplete backup
+	incomplete := folder(backend.fullBackupsPath, old+1)
+	writeFile(incomplete + "/data/ns1/file.asb")
+	writeFile(incomplete + "/configuration/aerospike_0.conf")
+	// incomplete, but too recent
+	writeFile(folder(backend.fullBackupsPath, recent) + "/data/ns1/file.asb")
+	// incomplete incremental backup
+	incremental := folder(backend.incrementalBackupsPath, old)
+	writeFile(incremental + "/data/ns1/file.asb")
+
+	gc := NewBackupGarbageCollector(&model.GarbageCollector{MinAge: 24 * time.Hour},
+		&gcBackendsHolderMock{backends: map[string]*BackupBackend{"routine": backend}})
+	expected := []model.IncompleteBackup{
+		{Routine: "routine", Path: incremental, Created: time.UnixMilli(old)},
+		{Routine: "routine", Path: full + "/data/ns2", Created: time.UnixMilli(old)},
+		{Routine: "routine", Path: incomplete, Created: time.UnixMilli(old + 1)},
+	}
+
+	ctx := context.Background()
+	backups, err := gc.Collect(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, expected, backups)
+
+	// not deleted by dry run
+	backups, err = gc.Collect(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, expected, backups)
+
+	// the folders of the running full and incremental backups are skipped
+	finishFull := useBackupFolder(backend.storage, incomplete)
+	finishIncremental := useBackupFolder(backend.storage, incremental)
+	backups, err = gc.Collect(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, expected[1:2], backups)
+	require.DirExists(t, filepath.Join(root, incomplete))
+	require.DirExists(t, filepath.Join(root, incremental))
+	require.NoDirExists(t, filepath.Join(root, full, "data/ns2"))
+	finishFull()
+	finishIncremental()
+
+	backups, err = gc.Collect(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, []model.IncompleteBackup{expected[0], expected[2]}, backups)
+	require.NoDirExists(t, filepath.Join(root, incomplete))
+	require.NoDirExists(t, filepath.Join(root, incremental))
+	require.NoDirExists(t, filepath.Join(root, full, "data/ns2"))
+	require.FileExists(t, filepath.Join(root, full, "data/ns1", metadataFile))
+
+	backups, err = gc.Collect(ctx, true)
+	require.NoError(t, err)
+	require.Empty(t, backups)
+}
+
+func TestGarbageCollector_CompletedAfterListing(t *testing.T) {
+	root := t.TempDir()
+	backend := newStorageBackend("routine", &model.LocalStorage{Path: root}, false)
+	path := getFullBackupFolder(backend.fullBackupsPath, time.UnixMilli(10), false)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, path, "data/ns1"), 0744))
+	require.NoError(t, os.WriteFile(filepath.Join(root, path, "data/ns1/file.asb"), []byte("data"), 0600))
+
+	backups, err := backend.findIncompleteBackups(context.Background(), "routine", time.Now())
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	// the metadata is written after the backups were listed
+	require.NoError(t, os.WriteFile(filepath.Join(root, path, "data/ns1", metadataFile), []byte("{}"), 0600))
+	deleted, err := backend.deleteIncompleteFolder(context.Background(), backups[0].Path)
+	require.NoError(t, err)
+	require.False(t, deleted)
+	require.DirExists(t, filepath.Join(root, path))
+}
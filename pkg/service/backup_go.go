@@ -33,7 +33,11 @@ func (b *BackupGo) BackupRun(
 	namespace string,
 	path string,
 ) (BackupHandler, error) {
-	config := makeBackupConfig(namespace, backupRoutine, backupPolicy, timebounds, secretAgent)
+	config, err := makeBackupConfig(namespace, backupRoutine, backupPolicy, timebounds, secretAgent)
+	if err != nil {
+		// an invalid configuration is not fixed by a retry
+		return nil, permanent(fmt.Errorf("failed to create backup config, %w", err))
+	}
 
 	writerFactory, err := storage.CreateWriter(ctx, s, path, false,
 		backupPolicy.RemoveFiles.RemoveFullBackup(), false)
@@ -41,12 +45,13 @@ func (b *BackupGo) BackupRun(
 		return nil, fmt.Errorf("failed to create backup writer, %w", err)
 	}
 
-	handler, err := client.Backup(ctx, config, writerFactory)
+	manifest := newManifestWriter(writerFactory)
+	handler, err := client.Backup(ctx, config, manifest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start backup, %w", err)
 	}
 
-	return handler, nil
+	return &backupGoHandler{BackupHandler: handler, manifest: manifest}, nil
 }
 
 //nolint:funlen
@@ -56,7 +61,7 @@ func makeBackupConfig(
 	backupPolicy *model.BackupPolicy,
 	timebounds model.TimeBounds,
 	secretAgent *model.SecretAgent,
-) *backup.BackupConfig {
+) (*backup.BackupConfig, error) {
 	config := backup.NewDefaultBackupConfig()
 	config.Namespace = namespace
 	config.BinList = backupRoutine.BinList
@@ -74,6 +79,14 @@ func makeBackupConfig(
 		config.SetList = backupRoutine.SetList
 	}
 
+	if backupRoutine.PartitionList != nil {
+		partitionFilters, err := model.ParsePartitionList(namespace, *backupRoutine.PartitionList)
+		if err != nil {
+			return nil, err
+		}
+		config.PartitionFilters = partitionFilters
+	}
+
 	if backupPolicy.Parallel != nil {
 		config.ParallelRead = *backupPolicy.Parallel
 		config.ParallelWrite = *backupPolicy.Parallel
@@ -99,6 +112,10 @@ func makeBackupConfig(
 		config.ScanPolicy.SocketTimeout = time.Duration(*backupPolicy.SocketTimeout) *
 			time.Millisecond
 	}
+	if len(backupRoutine.PreferRacks) > 0 {
+		// the client must be rack aware, see ClientManager.GetRackAwareClient.
+		config.ScanPolicy.ReplicaPolicy = a.PREFER_RACK
+	}
 	if backupPolicy.Bandwidth != nil {
 		config.Bandwidth = int(*backupPolicy.Bandwidth)
 	}
@@ -110,25 +127,36 @@ func makeBackupConfig(
 		}
 	}
 
-	if backupPolicy.EncryptionPolicy != nil {
-		config.EncryptionPolicy = &backup.EncryptionPolicy{
-			Mode:      backupPolicy.EncryptionPolicy.Mode,
-			KeyFile:   backupPolicy.EncryptionPolicy.KeyFile,
-			KeySecret: backupPolicy.EncryptionPolicy.KeySecret,
-			KeyEnv:    backupPolicy.EncryptionPolicy.KeyEnv,
-		}
+	config.EncryptionPolicy = makeEncryptionPolicy(backupPolicy.EncryptionPolicy)
+	config.SecretAgentConfig = makeSecretAgentConfig(secretAgent)
+
+	return config, nil
+}
+
+func makeEncryptionPolicy(policy *model.EncryptionPolicy) *backup.EncryptionPolicy {
+	if policy == nil {
+		return nil
 	}
 
-	if secretAgent != nil {
-		config.SecretAgentConfig = &backup.SecretAgentConfig{
-			ConnectionType:     secretAgent.ConnectionType,
-			Address:            secretAgent.Address,
-			Port:               secretAgent.Port,
-			TimeoutMillisecond: secretAgent.Timeout,
-			CaFile:             secretAgent.TLSCAString,
-			IsBase64:           secretAgent.IsBase64,
-		}
+	return &backup.EncryptionPolicy{
+		Mode:      policy.Mode,
+		KeyFile:   policy.KeyFile,
+		KeySecret: policy.KeySecret,
+		KeyEnv:    policy.KeyEnv,
 	}
+}
 
-	return config
+func makeSecretAgentConfig(secretAgent *model.SecretAgent) *backup.SecretAgentConfig {
+	if secretAgent == nil {
+		return nil
+	}
+
+	return &backup.SecretAgentConfig{
+		ConnectionType:     secretAgent.ConnectionType,
+		Address:            secretAgent.Address,
+		Port:               secretAgent.Port,
+		TimeoutMillisecond: secretAgent.Timeout,
+		CaFile:             secretAgent.TLSCAString,
+		IsBase64:           secretAgent.IsBase64,
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 	"github.com/reugn/go-quartz/quartz"
 )
 
@@ -14,9 +15,20 @@ import (
 type backupJob struct {
 	handler   *BackupRoutineHandler
 	jobType   jobType
-	isRunning atomic.Bool
+	overrides *model.BackupOverrides
+	// set for ad-hoc jobs only
+	tracker *backupJobTracker
+	// shared between the jobs of the same type of the routine
+	isRunning *atomic.Bool
+	// a run is deferred until the end of a blackout window
+	deferred atomic.Bool
 }
 
+const (
+	skipReasonInProgress     = "in-progress"
+	skipReasonBlackoutWindow = "blackout-window"
+)
+
 var _ quartz.Job = (*backupJob)(nil)
 
 // Execute is called by a Scheduler when the Trigger associated with this job fires.
@@ -24,30 +36,74 @@ func (j *backupJob) Execute(ctx context.Context) error {
 	logger := slog.Default().With(slog.String("routine", j.handler.routineName),
 		slog.Any("type", j.jobType))
 
+	// blackout windows apply to scheduled runs only
+	if j.tracker == nil {
+		if end, deferRun, active := j.handler.activeBlackoutWindow(time.Now()); active {
+			j.onBlackoutWindow(ctx, end, deferRun, logger)
+			return nil
+		}
+	}
+
 	if j.isRunning.CompareAndSwap(false, true) {
 		defer j.isRunning.Store(false)
 		switch j.jobType {
 		case jobTypeFull:
-			j.handler.runFullBackup(ctx, time.Now())
+			j.handler.runFullBackup(ctx, time.Now(), j.tracker)
 		case jobTypeIncremental:
-			j.handler.runIncrementalBackup(ctx, time.Now())
+			j.handler.runIncrementalBackup(ctx, time.Now(), j.overrides, j.tracker)
 		default:
 			logger.Error("Unsupported backup type")
 		}
 	} else {
 		logger.Debug("Backup is currently in progress, skipping it")
-		incrementSkippedCounters(j.jobType)
+		incrementSkippedCounters(j.jobType, skipReasonInProgress)
+		j.tracker.setSkipped()
 	}
 
 	return nil
 }
 
-func incrementSkippedCounters(jobType jobType) {
+// onBlackoutWindow defers the run until the end of the blackout window,
+// or skips it. Runs deferred to the same window are merged into one.
+func (j *backupJob) onBlackoutWindow(ctx context.Context, end time.Time, deferRun bool, logger *slog.Logger) {
+	if deferRun && j.deferred.CompareAndSwap(false, true) {
+		logger.Info("Backup is deferred until the end of the blackout window",
+			slog.Time("end", end))
+		time.AfterFunc(time.Until(end), func() {
+			j.deferred.Store(false)
+			if ctx.Err() != nil || !j.isScheduled() {
+				return
+			}
+			_ = j.Execute(ctx)
+		})
+		return
+	}
+
+	logger.Info("Backup is skipped during the blackout window",
+		slog.Time("end", end))
+	incrementSkippedCounters(j.jobType, skipReasonBlackoutWindow)
+}
+
+// isScheduled returns false if the job was replaced by a configuration change.
+func (j *backupJob) isScheduled() bool {
+	key := fullJobKey(j.handler.routineName)
+	if j.jobType == jobTypeIncremental {
+		key = incrJobKey(j.handler.routineName)
+	}
+
+	jobStore.Lock()
+	defer jobStore.Unlock()
+	detail := jobStore.jobs[key.String()]
+
+	return detail != nil && detail.Job() == j
+}
+
+func incrementSkippedCounters(jobType jobType, reason string) {
 	switch jobType {
 	case jobTypeFull:
-		backupSkippedCounter.Inc()
+		backupSkippedCounter.WithLabelValues(reason).Inc()
 	case jobTypeIncremental:
-		incrBackupSkippedCounter.Inc()
+		incrBackupSkippedCounter.WithLabelValues(reason).Inc()
 	}
 }
 
@@ -59,7 +115,21 @@ func (j *backupJob) Description() string {
 // newBackupJob creates a new backup job.
 func newBackupJob(handler *BackupRoutineHandler, jobType jobType) quartz.Job {
 	return &backupJob{
-		handler: handler,
-		jobType: jobType,
+		handler:   handler,
+		jobType:   jobType,
+		isRunning: handler.runningFlag(jobType),
+	}
+}
+
+// adHocCopy returns a copy of the job which runs with the given overrides
+// and reports its progress to the tracker. The overrides apply to the
+// incremental backups only.
+func (j *backupJob) adHocCopy(overrides *model.BackupOverrides, tracker *backupJobTracker) *backupJob {
+	return &backupJob{
+		handler:   j.handler,
+		jobType:   j.jobType,
+		overrides: overrides,
+		tracker:   tracker,
+		isRunning: j.isRunning,
 	}
 }
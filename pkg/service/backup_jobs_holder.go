@@ -0,0 +1,215 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// adHocJobs holds the ad-hoc backup jobs.
+var adHocJobs = newBackupJobsHolder()
+
+// finishedJobTTL is the time the status of a finished ad-hoc backup job is kept.
+const finishedJobTTL = 24 * time.Hour
+
+type backupJobInfo struct {
+	routine     string
+	jobType     jobType
+	status      model.JobStatus
+	handlers    map[string]BackupHandler
+	keys        map[string]string
+	err         error
+	createdTime time.Time
+	startTime   time.Time
+	endTime     time.Time
+}
+
+// BackupJobsHolder stores the status of ad-hoc backup jobs.
+type BackupJobsHolder struct {
+	sync.Mutex
+	jobs map[model.BackupJobID]*backupJobInfo
+}
+
+func newBackupJobsHolder() *BackupJobsHolder {
+	return &BackupJobsHolder{
+		jobs: make(map[model.BackupJobID]*backupJobInfo),
+	}
+}
+
+// newJob creates a new queued backup job and returns its tracker.
+func (h *BackupJobsHolder) newJob(routine string, jobType jobType) *backupJobTracker {
+	// #nosec G404
+	id := model.BackupJobID(rand.Int())
+	h.Lock()
+	defer h.Unlock()
+
+	h.evictFinished(time.Now())
+	h.jobs[id] = &backupJobInfo{
+		routine:     routine,
+		jobType:     jobType,
+		status:      model.JobStatusQueued,
+		handlers:    make(map[string]BackupHandler),
+		keys:        make(map[string]string),
+		createdTime: time.Now(),
+	}
+	return &backupJobTracker{id: id, holder: h}
+}
+
+// evictFinished removes the jobs finished more than finishedJobTTL ago.
+// The caller must hold the lock.
+func (h *BackupJobsHolder) evictFinished(now time.Time) {
+	for id, job := range h.jobs {
+		if !job.endTime.IsZero() && now.Sub(job.endTime) > finishedJobTTL {
+			delete(h.jobs, id)
+		}
+	}
+}
+
+func (h *BackupJobsHolder) update(id model.BackupJobID, f func(job *backupJobInfo)) {
+	h.Lock()
+	defer h.Unlock()
+	if job, exists := h.jobs[id]; exists {
+		f(job)
+	}
+}
+
+func (h *BackupJobsHolder) getStatus(id model.BackupJobID) (*model.BackupJobStatus, error) {
+	h.Lock()
+	defer h.Unlock()
+	job, exists := h.jobs[id]
+	if !exists {
+		return nil, fmt.Errorf("job with ID %d not found", id)
+	}
+
+	status := &model.BackupJobStatus{
+		Routine:     job.routine,
+		Type:        string(job.jobType),
+		Status:      job.status,
+		CreatedTime: job.createdTime,
+		StartTime:   job.startTime,
+		EndTime:     job.endTime,
+		Namespaces:  make(map[string]*model.BackupJobStats, len(job.handlers)),
+		Errors:      errorChain(job.err),
+	}
+	for namespace, handler := range job.handlers {
+		stats := handler.GetStats()
+		status.Namespaces[namespace] = &model.BackupJobStats{
+			RecordCount:         stats.GetReadRecords(),
+			TotalRecords:        stats.TotalRecords,
+			ByteCount:           stats.GetBytesWritten(),
+			FileCount:           stats.GetFileCount(),
+			SecondaryIndexCount: uint64(stats.GetSIndexes()),
+			UDFCount:            uint64(stats.GetUDFs()),
+		}
+	}
+	if job.status == model.JobStatusDone {
+		for _, key := range job.keys {
+			status.Keys = append(status.Keys, key)
+		}
+		slices.Sort(status.Keys)
+	}
+
+	return status, nil
+}
+
+// GetBackupJobStatus returns the status of the ad-hoc backup job.
+func GetBackupJobStatus(id model.BackupJobID) (*model.BackupJobStatus, error) {
+	return adHocJobs.getStatus(id)
+}
+
+// SetBackupJobFailed marks the ad-hoc backup job as failed, it should be
+// called if the job could not be scheduled.
+func SetBackupJobFailed(id model.BackupJobID, err error) {
+	tracker := &backupJobTracker{id: id, holder: adHocJobs}
+	tracker.setFailed(err)
+}
+
+// errorChain returns the messages of the error and the errors it wraps,
+// depth-first for the errors joined with errors.Join.
+func errorChain(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	chain := []string{err.Error()}
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		chain = append(chain, errorChain(wrapper.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			chain = append(chain, errorChain(wrapped)...)
+		}
+	}
+
+	return chain
+}
+
+// backupJobTracker reports the progress of an ad-hoc backup job.
+// All methods are no-op on a nil tracker, which is used for scheduled backups.
+type backupJobTracker struct {
+	id     model.BackupJobID
+	holder *BackupJobsHolder
+}
+
+// setRunning should be called at the start of each attempt, the progress of
+// the previous attempt is discarded.
+func (t *backupJobTracker) setRunning() {
+	if t == nil {
+		return
+	}
+	t.holder.update(t.id, func(job *backupJobInfo) {
+		job.status = model.JobStatusRunning
+		clear(job.handlers)
+		clear(job.keys)
+		if job.startTime.IsZero() {
+			job.startTime = time.Now()
+		}
+	})
+}
+
+// addHandler should be called for each started namespace backup.
+func (t *backupJobTracker) addHandler(namespace string, handler BackupHandler) {
+	if t == nil {
+		return
+	}
+	t.holder.update(t.id, func(job *backupJobInfo) {
+		job.handlers[namespace] = handler
+	})
+}
+
+// addKey should be called for each completed namespace backup.
+func (t *backupJobTracker) addKey(namespace string, key string) {
+	if t == nil {
+		return
+	}
+	t.holder.update(t.id, func(job *backupJobInfo) {
+		job.keys[namespace] = key
+	})
+}
+
+func (t *backupJobTracker) setSkipped() {
+	t.finish(model.JobStatusSkipped, nil)
+}
+
+func (t *backupJobTracker) setDone() {
+	t.finish(model.JobStatusDone, nil)
+}
+
+func (t *backupJobTracker) setFailed(err error) {
+	t.finish(model.JobStatusFailed, err)
+}
+
+func (t *backupJobTracker) finish(status model.JobStatus, err error) {
+	if t == nil {
+		return
+	}
+	t.holder.update(t.id, func(job *backupJobInfo) {
+		job.status = status
+		job.err = err
+		job.endTime = time.Now()
+	})
+}
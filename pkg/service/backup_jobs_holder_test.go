@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupJobsHolder(t *testing.T) {
+	holder := newBackupJobsHolder()
+	tracker := holder.newJob("routine", jobTypeFull)
+
+	status, err := holder.getStatus(tracker.id)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusQueued, status.Status)
+	require.Equal(t, "routine", status.Routine)
+	require.Equal(t, "full", status.Type)
+	require.True(t, status.StartTime.IsZero())
+
+	tracker.setRunning()
+	tracker.addKey("ns2", "routine/backup/1/data/ns2")
+	tracker.addKey("ns1", "routine/backup/1/data/ns1")
+	status, err = holder.getStatus(tracker.id)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusRunning, status.Status)
+	require.False(t, status.StartTime.IsZero())
+	// keys are reported only for completed jobs
+	require.Empty(t, status.Keys)
+
+	tracker.setDone()
+	status, err = holder.getStatus(tracker.id)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusDone, status.Status)
+	require.False(t, status.EndTime.IsZero())
+	require.Equal(t, []string{"routine/backup/1/data/ns1", "routine/backup/1/data/ns2"}, status.Keys)
+
+	failed := holder.newJob("routine", jobTypeIncremental)
+	failed.setFailed(fmt.Errorf("incremental backup of namespace ns1: %w", errors.New("timeout")))
+	status, err = holder.getStatus(failed.id)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusFailed, status.Status)
+	require.Equal(t, []string{"incremental backup of namespace ns1: timeout", "timeout"}, status.Errors)
+
+	joined := holder.newJob("routine", jobTypeIncremental)
+	joined.setFailed(errors.Join(
+		fmt.Errorf("namespace ns1: %w", errors.New("timeout")),
+		errors.New("namespace ns2: not found"),
+	))
+	status, err = holder.getStatus(joined.id)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"namespace ns1: timeout\nnamespace ns2: not found",
+		"namespace ns1: timeout",
+		"timeout",
+		"namespace ns2: not found",
+	}, status.Errors)
+
+	_, err = holder.getStatus(-1)
+	require.Error(t, err)
+}
+
+func TestBackupJobsHolderEviction(t *testing.T) {
+	holder := newBackupJobsHolder()
+	finished := holder.newJob("routine", jobTypeFull)
+	finished.setDone()
+	running := holder.newJob("routine", jobTypeFull)
+	running.setRunning()
+
+	holder.evictFinished(time.Now())
+	_, err := holder.getStatus(finished.id)
+	require.NoError(t, err)
+
+	holder.evictFinished(time.Now().Add(finishedJobTTL + time.Minute))
+	_, err = holder.getStatus(finished.id)
+	require.Error(t, err)
+	_, err = holder.getStatus(running.id)
+	require.NoError(t, err)
+}
+
+func TestSetBackupJobFailed(t *testing.T) {
+	tracker := adHocJobs.newJob("routine", jobTypeFull)
+	SetBackupJobFailed(tracker.id, errors.New("scheduler is stopped"))
+
+	status, err := GetBackupJobStatus(tracker.id)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusFailed, status.Status)
+	require.Equal(t, []string{"scheduler is stopped"}, status.Errors)
+}
+
+func TestNilBackupJobTracker(t *testing.T) {
+	var tracker *backupJobTracker
+	require.NotPanics(t, func() {
+		tracker.setRunning()
+		tracker.addKey("ns1", "key")
+		tracker.setFailed(errors.New("error"))
+	})
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/aerospike/backup-go"
+)
+
+// manifestWriter wraps the backup writer to record the path, size and
+// SHA-256 checksum of every file written by the backup.
+type manifestWriter struct {
+	backup.Writer
+	mu    sync.Mutex
+	files []model.BackupFile
+}
+
+var _ backup.Writer = (*manifestWriter)(nil)
+
+func newManifestWriter(writer backup.Writer) *manifestWriter {
+	return &manifestWriter{Writer: writer}
+}
+
+// NewWriter returns a writer for the file, which is added to the manifest
+// when closed.
+func (w *manifestWriter) NewWriter(ctx context.Context, filename string) (io.WriteCloser, error) {
+	writer, err := w.Writer.NewWriter(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hashingWriter{
+		WriteCloser: writer,
+		hash:        sha256.New(),
+		onClose: func(size uint64, checksum string) {
+			w.add(model.BackupFile{Path: filename, Size: size, SHA256: checksum})
+		},
+	}, nil
+}
+
+func (w *manifestWriter) add(file model.BackupFile) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files = append(w.files, file)
+}
+
+// Files returns the manifest of the closed files, sorted by path.
+func (w *manifestWriter) Files() []model.BackupFile {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	files := slices.Clone(w.files)
+	slices.SortFunc(files, func(a, b model.BackupFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return files
+}
+
+// hashingWriter computes the size and checksum of the written data.
+type hashingWriter struct {
+	io.WriteCloser
+	hash    hash.Hash
+	size    uint64
+	onClose func(size uint64, checksum string)
+}
+
+func (h *hashingWriter) Write(p []byte) (int, error) {
+	n, err := h.WriteCloser.Write(p)
+	h.hash.Write(p[:n])
+	h.size += uint64(n)
+	return n, err
+}
+
+func (h *hashingWriter) Close() error {
+	if err := h.WriteCloser.Close(); err != nil {
+		return err
+	}
+	h.onClose(h.size, hex.EncodeToString(h.hash.Sum(nil)))
+	return nil
+}
+
+// backupGoHandler is a backup-go handler with the manifest of the files
+// written by it.
+type backupGoHandler struct {
+	*backup.BackupHandler
+	manifest *manifestWriter
+}
+
+// GetFiles returns the manifest of the backup files.
+func (h *backupGoHandler) GetFiles() []model.BackupFile {
+	return h.manifest.Files()
+}
+
+// verifyBackupChecksums verifies the checksum of the backup metadata at the
+// path, and the size and checksum of every file of its manifest.
+func verifyBackupChecksums(ctx context.Context, s model.Storage, path string) error {
+	metadata, err := readBackupMetadata(ctx, s, path)
+	if err != nil {
+		return err
+	}
+
+	return verifyMetadataChecksums(ctx, s, path, metadata)
+}
+
+// readBackupMetadata reads the metadata file of the backup at the path.
+func readBackupMetadata(ctx context.Context, s model.Storage, path string) (*model.BackupMetadata, error) {
+	data, err := storage.ReadFile(ctx, s, filepath.Join(path, metadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read backup metadata: %w", err)
+	}
+
+	return model.NewMetadataFromBytes(data)
+}
+
+// verifyMetadataChecksums verifies the checksum of the metadata, and the size
+// and checksum of every file of its manifest under the path.
+func verifyMetadataChecksums(ctx context.Context, s model.Storage, path string, metadata *model.BackupMetadata,
+) error {
+	if err := metadata.VerifyChecksum(); err != nil {
+		return err
+	}
+	if uint64(len(metadata.Files)) != metadata.FileCount {
+		return fmt.Errorf("%w: manifest has %d files, expected %d",
+			model.ErrChecksumMismatch, len(metadata.Files), metadata.FileCount)
+	}
+
+	for _, file := range metadata.Files {
+		size, checksum, err := fileChecksum(ctx, s, filepath.Join(path, file.Path))
+		if err != nil {
+			return fmt.Errorf("cannot read backup file %s: %w", file.Path, err)
+		}
+		if size != file.Size || checksum != file.SHA256 {
+			return fmt.Errorf("%w: backup file %s", model.ErrChecksumMismatch, file.Path)
+		}
+	}
+
+	return nil
+}
+
+// fileChecksum returns the size and the hex encoded SHA-256 checksum of the file.
+func fileChecksum(ctx context.Context, s model.Storage, path string) (uint64, string, error) {
+	reader, err := storage.CreateReader(ctx, s, path, true, nil, "")
+	if err != nil {
+		return 0, "", err
+	}
+
+	readersCh := make(chan io.ReadCloser, 1)
+	errorsCh := make(chan error, 1)
+	go reader.StreamFiles(ctx, readersCh, errorsCh)
+
+	select {
+	case err := <-errorsCh:
+		return 0, "", err
+	case r := <-readersCh:
+		defer r.Close()
+		h := sha256.New()
+		size, err := io.Copy(h, r)
+		if err != nil {
+			return 0, "", err
+		}
+		return uint64(size), hex.EncodeToString(h.Sum(nil)), nil
+	case <-ctx.Done():
+		return 0, "", ctx.Err()
+	}
+}
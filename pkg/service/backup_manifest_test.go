@@ -0,0 +1,90 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/backup-go"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferWriterMock struct {
+	backup.Writer
+	files map[string]*bytes.Buffer
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func (w *bufferWriterMock) NewWriter(_ context.Context, filename string) (io.WriteCloser, error) {
+	buf := &bytes.Buffer{}
+	w.files[filename] = buf
+	return nopWriteCloser{buf}, nil
+}
+
+func checksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestManifestWriter(t *testing.T) {
+	manifest := newManifestWriter(&bufferWriterMock{files: make(map[string]*bytes.Buffer)})
+	ctx := context.Background()
+
+	for _, file := range []struct{ name, data string }{
+		{"ns_2.asb", "second"},
+		{"ns_1.asb", "first"},
+	} {
+		w, err := manifest.NewWriter(ctx, file.name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, file.data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	}
+	// files are added when closed
+	_, err := manifest.NewWriter(ctx, "ns_3.asb")
+	require.NoError(t, err)
+
+	require.Equal(t, []model.BackupFile{
+		{Path: "ns_1.asb", Size: 5, SHA256: checksum("first")},
+		{Path: "ns_2.asb", Size: 6, SHA256: checksum("second")},
+	}, manifest.Files())
+}
+
+func TestVerifyBackupChecksums(t *testing.T) {
+	root := t.TempDir()
+	s := &model.LocalStorage{Path: root}
+	backend := &BackupBackend{storage: s, fullBackupInProgress: &atomic.Bool{}}
+	ctx := context.Background()
+	path := "routine/backup/1/data/test"
+	require.NoError(t, os.MkdirAll(filepath.Join(root, path), 0744))
+	require.NoError(t, os.WriteFile(filepath.Join(root, path, "test_1.asb"), []byte("data"), 0600))
+	require.NoError(t, backend.writeBackupMetadata(ctx, path, model.BackupMetadata{
+		Namespace: "test",
+		FileCount: 1,
+		Files:     []model.BackupFile{{Path: "test_1.asb", Size: 4, SHA256: checksum("data")}},
+	}))
+
+	require.NoError(t, verifyBackupChecksums(ctx, s, path))
+
+	// tampered file
+	require.NoError(t, os.WriteFile(filepath.Join(root, path, "test_1.asb"), []byte("date"), 0600))
+	require.ErrorIs(t, verifyBackupChecksums(ctx, s, path), model.ErrChecksumMismatch)
+
+	// missing file
+	require.NoError(t, os.Remove(filepath.Join(root, path, "test_1.asb")))
+	require.Error(t, verifyBackupChecksums(ctx, s, path))
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+var (
+	// ErrMigrationInProgress is returned on attempt to migrate the backups
+	// of a routine which migration is running.
+	ErrMigrationInProgress = errors.New("backup migration is in progress")
+	// ErrNoHistoricalStorages is returned on attempt to migrate the backups
+	// of a routine without historical storages.
+	ErrNoHistoricalStorages = errors.New("routine has no historical storages")
+)
+
+var runningMigrations = newRunningTasks()
+
+// MigrateHistoricalBackups copies the backups of the historical storages of
+// the routine to its storage in the background. It runs after the storage
+// switch, the historical backups are listed from their storages meanwhile,
+// and the new backups are already written to the routine storage. When the
+// migration is complete, done is called with the historical storages which
+// backups were all copied, so that they can be removed from the routine.
+func (h *BackupRoutineHandler) MigrateHistoricalBackups(ctx context.Context, done func(migrated []model.Storage),
+) error {
+	if len(h.backend.history) == 0 {
+		return ErrNoHistoricalStorages
+	}
+	if !runningMigrations.start(h.routineName) {
+		return ErrMigrationInProgress
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer runningMigrations.finish(h.routineName)
+
+		migrated, err := h.backend.migrateHistory(ctx)
+		if err != nil {
+			slog.Error("Could not migrate historical backups",
+				slog.String("routine", h.routineName),
+				slog.Any("err", err))
+		}
+		if len(migrated) > 0 {
+			done(migrated)
+		}
+	}()
+
+	return nil
+}
+
+// migrateHistory copies the backups of each historical storage to the
+// storage of the routine, and returns the storages which backups were all
+// copied. The backups already in the routine storages are not copied again.
+func (b *BackupBackend) migrateHistory(ctx context.Context) ([]model.Storage, error) {
+	var migrated []model.Storage
+	var errs []error
+	for _, historical := range b.history {
+		count, err := b.migrateFrom(ctx, historical)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("storage %s: %w", historical.storage, err))
+			continue
+		}
+
+		slog.Info("Migrated historical backups",
+			slog.String("storage", fmt.Sprint(historical.storage)),
+			slog.Int("backups", count))
+		migrated = append(migrated, historical.storage)
+	}
+
+	return migrated, errors.Join(errs...)
+}
+
+// migrateFrom copies the full and incremental backups of the historical
+// storage to the storage of the routine, and returns the number of copied
+// backups. The single full backup of a routine which keeps no full backups
+// is copied only if the routine storage has no full backup yet.
+func (b *BackupBackend) migrateFrom(ctx context.Context, historical *BackupBackend) (int, error) {
+	bounds := &model.TimeBounds{}
+	var count int
+	for _, isFullBackup := range []bool{true, false} {
+		backups, err := historical.readStorageMetadataList(ctx, bounds, isFullBackup)
+		if err != nil {
+			return count, fmt.Errorf("cannot read backup list: %w", err)
+		}
+		existing, err := b.readOwnMetadataList(ctx, bounds, isFullBackup)
+		if err != nil {
+			return count, fmt.Errorf("cannot read backup list: %w", err)
+		}
+		if isFullBackup && b.removeFullBackup && len(existing) > 0 {
+			continue
+		}
+
+		for _, created := range backupTimes(backups) {
+			if backupsCreatedAt(existing, created) != nil {
+				continue
+			}
+
+			path := getIncrementalPath(b.incrementalBackupsPath, created)
+			if isFullBackup {
+				path = getFullBackupFolder(b.fullBackupsPath, created, b.removeFullBackup)
+			}
+			if _, err := copyBackupFolder(ctx, historical.storage, b.storage, path); err != nil {
+				return count, fmt.Errorf("cannot copy backup %s: %w", path, err)
+			}
+
+			namespaceBackups := backupsCreatedAt(backups, created)
+			for i := range namespaceBackups {
+				b.addToCatalog(ctx, namespaceBackups[i].Key, namespaceBackups[i].BackupMetadata)
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
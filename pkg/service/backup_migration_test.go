@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateHistoricalBackups(t *testing.T) {
+	ctx := context.Background()
+	previous := &model.LocalStorage{Path: t.TempDir()}
+	routine := &model.BackupRoutine{
+		BackupPolicy: &model.BackupPolicy{},
+		Storage:      previous,
+	}
+	config := model.NewConfig()
+	config.Storage["storage"] = previous
+	config.BackupRoutines["routine"] = routine
+
+	historical := newBackend("routine", routine)
+	for _, created := range []int64{10, 50} {
+		path := getFullPath(historical.fullBackupsPath, routine.BackupPolicy, "ns1", time.UnixMilli(created))
+		require.NoError(t, historical.writeBackupMetadata(ctx, path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "ns1"}))
+	}
+
+	// the storage is switched first, the backups of the previous storage
+	// stay readable from it until they are migrated
+	require.NoError(t, config.UpdateStorage("storage", &model.LocalStorage{Path: t.TempDir()}))
+	require.Equal(t, []model.Storage{previous}, routine.HistoricalStorages)
+	backend := newBackend("routine", routine)
+	handler := &BackupRoutineHandler{
+		backend:       backend,
+		storage:       routine.Storage,
+		routineName:   "routine",
+		backupRoutine: routine,
+	}
+
+	// the state is restored from the historical backups, so that the routine
+	// does not start with a full backup
+	state := backend.readState()
+	require.Equal(t, time.UnixMilli(50), state.LastFullRun)
+	require.Equal(t, 2, state.Performed)
+
+	// the next backups are written to the new storage
+	path := getFullPath(backend.fullBackupsPath, routine.BackupPolicy, "ns1", time.UnixMilli(100))
+	require.NoError(t, backend.writeBackupMetadata(ctx, path,
+		model.BackupMetadata{Created: time.UnixMilli(100), Namespace: "ns1"}))
+
+	// the backups of the historical storage are listed, but not deleted
+	all := model.NewTimeBoundsTo(time.UnixMilli(1000))
+	full, err := backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 50, 100}, unixMillis(backupTimes(full)))
+	require.Equal(t, previous, full[0].Storage)
+	require.ErrorIs(t, backend.DeleteFullBackup(ctx, time.UnixMilli(10), false), ErrHistoricalBackup)
+
+	migrated := make(chan []model.Storage, 1)
+	require.NoError(t, handler.MigrateHistoricalBackups(ctx, func(storages []model.Storage) {
+		migrated <- storages
+	}))
+	select {
+	case storages := <-migrated:
+		require.Equal(t, []model.Storage{previous}, storages)
+		require.NoError(t, config.RemoveHistoricalStorages("routine", storages))
+		require.Empty(t, routine.HistoricalStorages)
+	case <-time.After(5 * time.Second):
+		t.Fatal("migration did not complete")
+	}
+
+	// the migrated backups are listed from the routine storage
+	backend = newBackend("routine", routine)
+	handler.backend = backend
+	full, err = backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 50, 100}, unixMillis(backupTimes(full)))
+	require.Equal(t, routine.Storage, full[0].Storage)
+	require.ErrorIs(t, handler.MigrateHistoricalBackups(ctx, nil), ErrNoHistoricalStorages)
+}
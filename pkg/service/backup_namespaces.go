@@ -0,0 +1,120 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/aerospike/backup-go"
+)
+
+// namespaceBackup is the result of the backup of a namespace.
+type namespaceBackup struct {
+	namespace string
+	// nil if the backup could not be started
+	handler  BackupHandler
+	started  time.Time
+	finished time.Time
+	err      error
+}
+
+// runNamespaceBackups starts the backups of the namespaces in the given order,
+// at most parallel of them at a time (all of them if parallel is not positive),
+// and calls done for each namespace once its backup has finished or could not
+// be started. If done returns an error, no more backups are started and the
+// error is returned.
+// start and done are called from the calling goroutine.
+func runNamespaceBackups(
+	ctx context.Context, namespaces []string, parallel int,
+	start func(namespace string) (BackupHandler, error),
+	done func(result namespaceBackup) error,
+) error {
+	if parallel <= 0 || parallel > len(namespaces) {
+		parallel = len(namespaces)
+	}
+
+	// buffered, so that the waiting goroutines never block
+	results := make(chan namespaceBackup, len(namespaces))
+	running := 0
+	for _, namespace := range namespaces {
+		for ; running >= parallel; running-- {
+			if err := done(<-results); err != nil {
+				return err
+			}
+		}
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		started := time.Now()
+		handler, err := start(namespace)
+		if err != nil {
+			result := namespaceBackup{namespace: namespace, started: started, finished: time.Now(), err: err}
+			if err := done(result); err != nil {
+				return err
+			}
+			continue
+		}
+
+		running++
+		go func() {
+			err := handler.Wait(ctx)
+			results <- namespaceBackup{
+				namespace: namespace,
+				handler:   handler,
+				started:   started,
+				finished:  time.Now(),
+				err:       err,
+			}
+		}()
+	}
+
+	for ; running > 0; running-- {
+		if err := done(<-results); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// namespacesInBackupOrder returns the namespaces to back up in the order
+// configured in the backup policy.
+func (h *BackupRoutineHandler) namespacesInBackupOrder(namespaces []string, client backup.AerospikeClient) []string {
+	var sizes map[string]uint64
+	if h.backupFullPolicy.IsOrderedBySize() {
+		var err error
+		sizes, err = getNamespaceSizes(client, namespaces)
+		if err != nil {
+			slog.Warn("Could not read namespace sizes, back up in the configured order",
+				slog.String("routine", h.routineName),
+				slog.Any("err", err))
+		}
+	}
+
+	return orderNamespaces(namespaces, h.backupFullPolicy.NamespacePriority, sizes)
+}
+
+// orderNamespaces returns the prioritized namespaces first, in the priority
+// order, followed by the other namespaces from the largest to the smallest
+// one if the sizes are given, in the original order otherwise.
+func orderNamespaces(namespaces []string, priority []string, sizes map[string]uint64) []string {
+	rank := func(namespace string) int {
+		if i := slices.Index(priority, namespace); i >= 0 {
+			return i
+		}
+		return len(priority)
+	}
+
+	ordered := slices.Clone(namespaces)
+	slices.SortStableFunc(ordered, func(a, b string) int {
+		if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(sizes[b], sizes[a])
+	})
+
+	return ordered
+}
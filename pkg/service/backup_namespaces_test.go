@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/backup-go/models"
+	"github.com/stretchr/testify/require"
+)
+
+// backupHandlerMock is a namespace backup which runs until it is released.
+type backupHandlerMock struct {
+	release chan error
+	running *atomic.Int32
+}
+
+func (m *backupHandlerMock) GetStats() *models.BackupStats {
+	return &models.BackupStats{}
+}
+
+func (m *backupHandlerMock) GetFiles() []model.BackupFile {
+	return nil
+}
+
+func (m *backupHandlerMock) Wait(ctx context.Context) error {
+	defer m.running.Add(-1)
+	select {
+	case err := <-m.release:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRunNamespaceBackups(t *testing.T) {
+	namespaces := []string{"ns1", "ns2", "ns3", "ns4"}
+	var running, maxRunning atomic.Int32
+	var started, finished []string
+
+	err := runNamespaceBackups(testContext(t), namespaces, 2,
+		func(namespace string) (BackupHandler, error) {
+			if n := running.Add(1); n > maxRunning.Load() {
+				maxRunning.Store(n)
+			}
+			started = append(started, namespace)
+			handler := &backupHandlerMock{release: make(chan error, 1), running: &running}
+			// the backups finish in the order they were started
+			handler.release <- nil
+			return handler, nil
+		},
+		func(result namespaceBackup) error {
+			require.NoError(t, result.err)
+			require.False(t, result.finished.Before(result.started))
+			finished = append(finished, result.namespace)
+			return nil
+		})
+
+	require.NoError(t, err)
+	require.Equal(t, namespaces, started)
+	require.ElementsMatch(t, namespaces, finished)
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestRunNamespaceBackupsSequential(t *testing.T) {
+	var running atomic.Int32
+	err := runNamespaceBackups(testContext(t), []string{"ns1", "ns2", "ns3"}, 1,
+		func(_ string) (BackupHandler, error) {
+			// the previous backup has finished before the next one starts
+			require.Equal(t, int32(1), running.Add(1))
+			handler := &backupHandlerMock{release: make(chan error, 1), running: &running}
+			handler.release <- nil
+			return handler, nil
+		},
+		func(_ namespaceBackup) error {
+			return nil
+		})
+
+	require.NoError(t, err)
+}
+
+func TestRunNamespaceBackupsStopsOnError(t *testing.T) {
+	errBackup := errors.New("backup failed")
+	var running atomic.Int32
+	var started []string
+
+	err := runNamespaceBackups(testContext(t), []string{"ns1", "ns2", "ns3"}, 1,
+		func(namespace string) (BackupHandler, error) {
+			started = append(started, namespace)
+			if namespace == "ns2" {
+				return nil, errBackup
+			}
+			running.Add(1)
+			handler := &backupHandlerMock{release: make(chan error, 1), running: &running}
+			handler.release <- nil
+			return handler, nil
+		},
+		func(result namespaceBackup) error {
+			if result.handler == nil {
+				return result.err
+			}
+			return nil
+		})
+
+	require.ErrorIs(t, err, errBackup)
+	require.Equal(t, []string{"ns1", "ns2"}, started)
+}
+
+func TestOrderNamespaces(t *testing.T) {
+	namespaces := []string{"ns1", "ns2", "ns3", "ns4"}
+	sizes := map[string]uint64{"ns1": 10, "ns2": 30, "ns3": 20, "ns4": 30}
+
+	require.Equal(t, namespaces, orderNamespaces(namespaces, nil, nil))
+	require.Equal(t, []string{"ns2", "ns4", "ns3", "ns1"}, orderNamespaces(namespaces, nil, sizes))
+	require.Equal(t, []string{"ns3", "ns1", "ns2", "ns4"}, orderNamespaces(namespaces, []string{"ns3", "ns1"}, nil))
+	require.Equal(t, []string{"ns1", "ns2", "ns4", "ns3"},
+		orderNamespaces(namespaces, []string{"ns1", "unknown"}, sizes))
+	// the input is not modified
+	require.Equal(t, []string{"ns1", "ns2", "ns3", "ns4"}, namespaces)
+}
+
+func TestNamespaceUsedBytes(t *testing.T) {
+	require.Equal(t, uint64(1024), namespaceUsedBytes("objects=10;data_used_bytes=1024;device_used_bytes=7"))
+	require.Equal(t, uint64(300), namespaceUsedBytes("device_used_bytes=100;memory_used_bytes=200;ns_cluster_size=3"))
+	require.Zero(t, namespaceUsedBytes(""))
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// replicationLock guards the read-modify-write of the replication status
+// files, which are updated by the copies to all the replicas of a backup.
+var replicationLock sync.Mutex
+
+// replicateBackup copies the backup folder to the replica storages of the
+// routine in the background. Each replica is copied and retried on its own,
+// the status of the copies is stored in the backup folder.
+func (h *BackupRoutineHandler) replicateBackup(ctx context.Context, created time.Time, path string) {
+	if len(h.backupRoutine.ReplicateTo) == 0 {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	policy := h.retryPolicy()
+	for _, name := range replicaNames(h.backupRoutine.ReplicateTo) {
+		replica := h.backupRoutine.ReplicateTo[name]
+		h.backend.setReplicaStatus(ctx, path, created, model.ReplicaStatus{
+			Storage: name,
+			Status:  model.ReplicationPending,
+			Updated: time.Now(),
+		})
+
+		retry := NewRetryService(fmt.Sprintf("%s-replicate-%d-%s", h.routineName, created.UnixMilli(), name))
+		var attempt int32
+		go retry.retry(
+			func() error {
+				attempt++
+				fileCount, err := copyBackupFolder(ctx, h.storage, replica, path)
+				status := model.ReplicaStatus{
+					Storage:   name,
+					Status:    model.ReplicationReplicated,
+					Attempts:  attempt,
+					Updated:   time.Now(),
+					FileCount: fileCount,
+				}
+				switch {
+				case err == nil:
+					replicationCounter.WithLabelValues(h.routineName, name, string(status.Status)).Inc()
+				case attempt > policy.maxRetries || !isRetryable(err):
+					status.Status = model.ReplicationFailed
+					status.Error = err.Error()
+					replicationCounter.WithLabelValues(h.routineName, name, string(status.Status)).Inc()
+					slog.Error("Could not replicate backup",
+						slog.String("routine", h.routineName),
+						slog.String("replica", name),
+						slog.String("path", path),
+						slog.Any("err", err))
+				default:
+					status.Status = model.ReplicationPending
+					status.Error = err.Error()
+				}
+
+				h.backend.setReplicaStatus(ctx, path, created, status)
+				return err
+			},
+			policy,
+		)
+	}
+}
+
+// GetBackupReplication returns the replication status of the backup created
+// at the given time.
+func (h *BackupRoutineHandler) GetBackupReplication(ctx context.Context, created time.Time,
+) (*model.BackupReplication, error) {
+	return h.backend.ReadReplicationStatus(ctx, created)
+}
+
+// copyBackupFolder copies the files of the backup folder to the same path in
+// the replica storage, replacing its previous copy. The metadata files are
+// copied last, so that the namespace backups listed from the replica are
+// complete. It returns the number of the copied files.
+func copyBackupFolder(ctx context.Context, from, to model.Storage, path string) (int, error) {
+	files, err := storage.ListFiles(ctx, from, path)
+	if err != nil {
+		return 0, fmt.Errorf("cannot list backup files: %w", err)
+	}
+	files = slices.DeleteFunc(files, func(file string) bool {
+		return filepath.Base(file) == replicationFile
+	})
+	if len(files) == 0 {
+		return 0, permanent(fmt.Errorf("%w: no files in %s", ErrBackupNotFound, path))
+	}
+	slices.SortStableFunc(files, func(a, b string) int {
+		return compareBool(filepath.Base(a) == metadataFile, filepath.Base(b) == metadataFile)
+	})
+
+	if err := storage.DeleteFolder(ctx, to, path); err != nil {
+		return 0, fmt.Errorf("cannot delete previous copy: %w", err)
+	}
+
+	for i, file := range files {
+		if err := storage.CopyFile(ctx, from, to, file); err != nil {
+			return i, fmt.Errorf("cannot copy %s: %w", file, err)
+		}
+	}
+
+	return len(files), nil
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// replicaNames returns the sorted names of the replica storages.
+func replicaNames(replicas map[string]model.Storage) []string {
+	names := make([]string, 0, len(replicas))
+	for name := range replicas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// setReplicaStatus updates the status of the replica in the replication
+// status file of the backup folder. Errors are only logged, as the status
+// does not affect the replicas.
+func (b *BackupBackend) setReplicaStatus(ctx context.Context, path string, created time.Time,
+	status model.ReplicaStatus) {
+	replicationLock.Lock()
+	defer replicationLock.Unlock()
+
+	statusPath := filepath.Join(path, replicationFile)
+	replication := &model.BackupReplication{Created: created}
+	if data, err := storage.ReadFile(ctx, b.storage, statusPath); err == nil {
+		if previous, err := model.NewBackupReplicationFromBytes(data); err == nil && previous.Created.Equal(created) {
+			replication = previous
+		}
+	}
+	replication.SetReplica(status)
+
+	data, err := yaml.Marshal(replication)
+	if err == nil {
+		err = storage.ReplaceFile(ctx, b.storage, statusPath, data)
+	}
+	if err != nil {
+		slog.Error("Could not write replication status",
+			slog.String("path", statusPath),
+			slog.Any("err", err))
+	}
+}
+
+// ReadReplicationStatus returns the replication status of the full or
+// incremental backup created at the given time.
+func (b *BackupBackend) ReadReplicationStatus(ctx context.Context, created time.Time,
+) (*model.BackupReplication, error) {
+	backups, err := b.findBackup(ctx, created)
+	if err != nil {
+		return nil, err
+	}
+
+	// the namespace backups are in the data directory of the backup folder
+	path := filepath.Dir(filepath.Dir(backups[0].Key))
+	data, err := storage.ReadFile(ctx, backups[0].Storage, filepath.Join(path, replicationFile))
+	if err != nil {
+		return &model.BackupReplication{Created: created}, nil // not replicated
+	}
+
+	replication, err := model.NewBackupReplicationFromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding replication status: %w", err)
+	}
+	return replication, nil
+}
+
+// deleteReplicaFolders deletes the copies of the deleted folder from the
+// replicas. Errors are only logged, the copies left are replaced when the
+// backup folder is replicated again.
+func (b *BackupBackend) deleteReplicaFolders(ctx context.Context, path string) {
+	for _, name := range replicaNames(b.replicas) {
+		if err := storage.DeleteFolder(ctx, b.replicas[name], path); err != nil {
+			slog.Warn("Could not delete replica folder",
+				slog.String("replica", name),
+				slog.String("path", path),
+				slog.Any("err", err))
+		}
+	}
+}
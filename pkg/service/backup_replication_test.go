@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicateBackup(t *testing.T) {
+	ctx := context.Background()
+	primary := &model.LocalStorage{Path: t.TempDir()}
+	replica := &model.LocalStorage{Path: t.TempDir()}
+	routine := &model.BackupRoutine{
+		Storage:     primary,
+		ReplicateTo: map[string]model.Storage{"replica": replica},
+	}
+	backend := &BackupBackend{
+		storage:                primary,
+		replicas:               routine.ReplicateTo,
+		fullBackupsPath:        "routine/backup",
+		incrementalBackupsPath: "routine/incremental",
+		fullBackupInProgress:   &atomic.Bool{},
+	}
+	handler := &BackupRoutineHandler{
+		backend:          backend,
+		storage:          primary,
+		routineName:      "routine",
+		backupRoutine:    routine,
+		backupFullPolicy: &model.BackupPolicy{},
+	}
+
+	created := time.UnixMilli(10)
+	policy := &model.BackupPolicy{}
+	path := getFullPath(backend.fullBackupsPath, policy, "ns1", created)
+	require.NoError(t, storage.WriteFile(ctx, primary, filepath.Join(path, "ns1_1.asb"), []byte("data")))
+	require.NoError(t, backend.writeBackupMetadata(ctx, path,
+		model.BackupMetadata{Created: created, Namespace: "ns1"}))
+
+	folder := getFullBackupFolder(backend.fullBackupsPath, created, false)
+	handler.replicateBackup(ctx, created, folder)
+	require.Eventually(t, func() bool {
+		replication, err := handler.GetBackupReplication(ctx, created)
+		require.NoError(t, err)
+		return len(replication.Replicas) == 1 &&
+			replication.Replicas[0].Status == model.ReplicationReplicated
+	}, 5*time.Second, 10*time.Millisecond)
+
+	replication, err := handler.GetBackupReplication(ctx, created)
+	require.NoError(t, err)
+	require.Equal(t, "replica", replication.Replicas[0].Storage)
+	require.Equal(t, int32(1), replication.Replicas[0].Attempts)
+	require.Equal(t, 2, replication.Replicas[0].FileCount)
+	data, err := storage.ReadFile(ctx, replica, filepath.Join(path, "ns1_1.asb"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+	// the replication status is not copied
+	_, err = storage.ReadFile(ctx, replica, filepath.Join(folder, replicationFile))
+	require.Error(t, err)
+
+	// the backups are listed from the replica when the storage is unreachable
+	unreachable := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(unreachable, nil, 0o600))
+	backend.storage = &model.LocalStorage{Path: unreachable}
+	backups, err := backend.FullBackupList(ctx, model.NewTimeBoundsTo(time.Now()))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	require.Equal(t, replica, backups[0].Storage)
+	backend.storage = primary
+
+	// the deleted backups are deleted from the replica
+	require.NoError(t, backend.deleteFullBackup(ctx, created))
+	files, err := storage.ListFiles(ctx, replica, folder)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestCopyBackupFolder_NotFound(t *testing.T) {
+	from := &model.LocalStorage{Path: t.TempDir()}
+	to := &model.LocalStorage{Path: t.TempDir()}
+
+	_, err := copyBackupFolder(context.Background(), from, to, "routine/backup/10")
+	require.ErrorIs(t, err, ErrBackupNotFound)
+	require.False(t, isRetryable(err))
+}
+
+func TestRestoreSourceStorage(t *testing.T) {
+	ctx := context.Background()
+	unreachable := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(unreachable, nil, 0o600))
+	primary := &model.LocalStorage{Path: unreachable}
+	replica := &model.LocalStorage{Path: t.TempDir()}
+	restorer := &dataRestorer{config: &model.Config{
+		BackupRoutines: map[string]*model.BackupRoutine{
+			"routine": {
+				Storage:     primary,
+				ReplicateTo: map[string]model.Storage{"replica": replica},
+			},
+		},
+	}}
+
+	details := &model.BackupDetails{Key: "routine/backup/10/data/ns1", Storage: primary}
+	// the primary storage is used if the backup is not replicated
+	require.Equal(t, primary, restorer.sourceStorage(ctx, "routine", details))
+
+	require.NoError(t, storage.WriteFile(ctx, replica, filepath.Join(details.Key, metadataFile),
+		[]byte("namespace: ns1\n")))
+	require.Equal(t, replica, restorer.sourceStorage(ctx, "routine", details))
+}
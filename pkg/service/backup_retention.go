@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// applyRetention deletes full backups expired by the retention policy
+// together with the incremental backups depending on them.
+func (h *BackupRoutineHandler) applyRetention(ctx context.Context, now time.Time) error {
+	retention := h.backupFullPolicy.Retention
+	if retention == nil || h.backend.removeFullBackup {
+		return nil
+	}
+
+	logger := slog.Default().With(slog.String("routine", h.routineName))
+
+	fullBackups, err := h.backend.readOwnMetadataList(ctx, model.NewTimeBoundsTo(now), true)
+	if err != nil {
+		return fmt.Errorf("cannot read full backup list: %w", err)
+	}
+	incrBackups, err := h.backend.readOwnMetadataList(ctx, model.NewTimeBoundsTo(now), false)
+	if err != nil {
+		return fmt.Errorf("cannot read incremental backup list: %w", err)
+	}
+
+	fullTimes := backupTimes(fullBackups)
+	incrTimes := backupTimes(incrBackups)
+
+	for _, expired := range retention.Expired(fullTimes, now, h.backupRoutine.Location()) {
+		for _, incr := range dependentIncrementalBackups(fullTimes, incrTimes, expired) {
+			if err := h.backend.deleteIncrementalBackup(ctx, incr); err != nil {
+				return err
+			}
+			logger.Info("Deleted expired incremental backup",
+				slog.Time("created", incr))
+			retentionDeletedCounter.WithLabelValues(h.routineName, "Incremental").Inc()
+		}
+
+		if err := h.backend.deleteFullBackup(ctx, expired); err != nil {
+			return err
+		}
+		logger.Info("Deleted expired full backup",
+			slog.Time("created", expired))
+		retentionDeletedCounter.WithLabelValues(h.routineName, "Full").Inc()
+	}
+
+	return nil
+}
+
+// backupTimes returns distinct creation times of the backups, sorted
+// (each backup has an entry per namespace).
+func backupTimes(backups []model.BackupDetails) []time.Time {
+	times := make([]time.Time, 0, len(backups))
+	for i := range backups {
+		times = append(times, backups[i].Created)
+	}
+	slices.SortFunc(times, time.Time.Compare)
+
+	return slices.CompactFunc(times, time.Time.Equal)
+}
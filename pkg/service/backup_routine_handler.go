@@ -2,14 +2,20 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	abs "github.com/aerospike/aerospike-backup-service/v2"
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
 	"github.com/aerospike/backup-go"
-	"github.com/aerospike/backup-go/models"
 )
 
 // BackupRoutineHandler implements backup logic for single routine.
@@ -20,16 +26,27 @@ type BackupRoutineHandler struct {
 	backupIncrPolicy *model.BackupPolicy
 	backupRoutine    *model.BackupRoutine
 	routineName      string
-	namespaces       []string
 	storage          model.Storage
 	secretAgent      *model.SecretAgent
 	state            *model.BackupState
 	retry            *RetryService
 	clientManager    ClientManager
+	// routine and service level blackout windows
+	blackoutWindows []*model.BlackoutWindow
+	// limits of concurrent backup jobs shared with other routines
+	concurrencyLimits []concurrencyLimit
 
-	// backup handlers by namespace
+	// backup handlers by namespace, guarded by handlersLock
 	fullBackupHandlers map[string]BackupHandler
 	incrBackupHandlers map[string]BackupHandler
+	handlersLock       sync.Mutex
+	// set while a full or incremental backup of the routine runs, shared by
+	// the scheduled and the ad-hoc jobs
+	fullRunning atomic.Bool
+	incrRunning atomic.Bool
+
+	// running backups of all routines, to be able to cancel them
+	runs *BackupRuns
 }
 
 // BackupHandlerHolder stores backupHandlers by routine name
@@ -42,10 +59,13 @@ func newBackupRoutineHandler(
 	backupService Backup,
 	routineName string,
 	backupBackend *BackupBackend,
+	runs *BackupRuns,
 ) *BackupRoutineHandler {
 	backupRoutine := config.BackupRoutines[routineName]
 	backupPolicy := backupRoutine.BackupPolicy
 	secretAgent := backupRoutine.SecretAgent
+	blackoutWindows := append(slices.Clone(backupRoutine.BlackoutWindows),
+		config.ServiceConfig.BlackoutWindows...)
 
 	return &BackupRoutineHandler{
 		backupService:      backupService,
@@ -54,14 +74,16 @@ func newBackupRoutineHandler(
 		backupFullPolicy:   backupPolicy,
 		backupIncrPolicy:   backupPolicy.CopySMDDisabled(), // incremental backups should not contain metadata
 		routineName:        routineName,
-		namespaces:         backupRoutine.Namespaces,
 		storage:            backupRoutine.Storage,
 		secretAgent:        secretAgent,
-		state:              backupBackend.readState(),
+		state:              backupBackend.loadState(),
 		retry:              NewRetryService(routineName),
 		fullBackupHandlers: make(map[string]BackupHandler),
 		incrBackupHandlers: make(map[string]BackupHandler),
 		clientManager:      clientManager,
+		blackoutWindows:    blackoutWindows,
+		concurrencyLimits:  makeConcurrencyLimits(config, backupRoutine),
+		runs:               runs,
 	}
 }
 
@@ -73,26 +95,98 @@ func getNamespacesToBackup(namespaces []string, client backup.AerospikeClient) (
 	return namespaces, nil
 }
 
-func (h *BackupRoutineHandler) runFullBackup(ctx context.Context, now time.Time) {
-	h.retry.retry(
-		func() error { return h.runFullBackupInternal(ctx, now) },
-		time.Duration(h.backupFullPolicy.GetRetryDelayOrDefault())*time.Millisecond,
-		h.backupFullPolicy.GetMaxRetriesOrDefault(),
+// getClient returns a backup client for the routine source cluster,
+// rack aware if the routine has preferred racks.
+func (h *BackupRoutineHandler) getClient() (*backup.Client, error) {
+	if len(h.backupRoutine.PreferRacks) > 0 {
+		return h.clientManager.GetRackAwareClient(h.backupRoutine.SourceCluster, h.backupRoutine.PreferRacks)
+	}
+
+	return h.clientManager.GetClient(h.backupRoutine.SourceCluster)
+}
+
+// runFullBackup runs a full backup of the routine with retries.
+// The tracker is set for ad-hoc jobs only.
+func (h *BackupRoutineHandler) runFullBackup(ctx context.Context, now time.Time, tracker *backupJobTracker) {
+	policy := h.retryPolicy()
+	// an ad-hoc job retries on its own, the next scheduled run must not
+	// cancel its pending retry
+	retry := h.retry
+	if tracker != nil {
+		retry = NewRetryService(fmt.Sprintf("%s-adhoc-%d", h.routineName, tracker.id))
+	}
+	var attempt int32
+	retry.retry(
+		func() error {
+			if attempt > 0 {
+				backupRetryCounter.WithLabelValues(h.routineName).Inc()
+			}
+			tracker.setRunning()
+			err := h.runFullBackupInternal(ctx, now, tracker)
+			switch {
+			case err == nil:
+				tracker.setDone()
+				return nil
+			case errors.Is(err, errBackupSkipped):
+				tracker.setSkipped()
+				return nil
+			}
+
+			h.state.SetLastError(err, time.Now())
+			h.writeState(ctx)
+			if errors.Is(err, errBackupCancelled) {
+				tracker.setFailed(err)
+				return nil // do not retry cancelled backup
+			}
+			if attempt == policy.maxRetries || !isRetryable(err) {
+				tracker.setFailed(err)
+			}
+			attempt++
+			return err
+		},
+		policy,
 	)
 }
 
-func (h *BackupRoutineHandler) runFullBackupInternal(ctx context.Context, now time.Time) error {
+// retryPolicy returns the retry policy of the full backups of the routine.
+func (h *BackupRoutineHandler) retryPolicy() retryPolicy {
+	return retryPolicy{
+		delay:      time.Duration(h.backupFullPolicy.GetRetryDelayOrDefault()) * time.Millisecond,
+		maxDelay:   time.Duration(h.backupFullPolicy.GetRetryMaxDelayOrDefault()) * time.Millisecond,
+		multiplier: h.backupFullPolicy.GetRetryMultiplierOrDefault(),
+		maxRetries: h.backupFullPolicy.GetMaxRetriesOrDefault(),
+	}
+}
+
+func (h *BackupRoutineHandler) runFullBackupInternal(
+	ctx context.Context, now time.Time, tracker *backupJobTracker,
+) error {
 	logger := slog.Default().With(slog.String("routine", h.routineName))
-	var err error
+	if h.backend.FullBackupInProgress().Load() {
+		logger.Info("Full backup is currently in progress, skipping full backup")
+		return errBackupSkipped
+	}
+
+	// the job waiting for the limits is not in progress yet
+	release, err := h.acquireConcurrencyLimits(ctx, jobTypeFull)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if !h.backend.FullBackupInProgress().CompareAndSwap(false, true) {
 		logger.Info("Full backup is currently in progress, skipping full backup")
-		return nil
+		return errBackupSkipped
 	}
 
 	logger.Debug("Acquire fullBackupInProgress lock")
-	defer h.backend.FullBackupInProgress().Store(false)
+	ctx, finish := h.trackRun(ctx, jobTypeFull, tracker)
+	defer func() {
+		h.backend.FullBackupInProgress().Store(false)
+		finish()
+	}()
 
-	client, err := h.clientManager.GetClient(h.backupRoutine.SourceCluster)
+	client, err := h.getClient()
 	if err != nil {
 		return err
 	}
@@ -100,16 +194,16 @@ func (h *BackupRoutineHandler) runFullBackupInternal(ctx context.Context, now ti
 	// release the lock
 	defer func() {
 		h.clientManager.Close(client)
-		clear(h.fullBackupHandlers)
+		h.clearBackupHandlers(h.fullBackupHandlers)
 	}()
 
-	err = h.startFullBackupForAllNamespaces(ctx, now, client)
-	if err != nil {
-		return err
-	}
-
-	err = h.waitForFullBackups(ctx, now)
+	err = h.runFullBackupForAllNamespaces(ctx, now, client, tracker)
 	if err != nil {
+		if isCancelled(ctx) {
+			h.cleanupCancelledFullBackup(ctx, now, logger)
+			return context.Cause(ctx)
+		}
+		h.cleanupFailedFullBackup(ctx, now, logger)
 		return err
 	}
 
@@ -118,60 +212,117 @@ func (h *BackupRoutineHandler) runFullBackupInternal(ctx context.Context, now ti
 
 	// update the state
 	h.state.SetLastFullRun(now)
+	h.writeState(ctx)
 
 	if h.backupFullPolicy.RemoveFiles.RemoveIncrementalBackup() {
 		h.deleteFolder(ctx, h.backend.incrementalBackupsPath, logger)
 	}
 
 	h.writeClusterConfiguration(ctx, client.AerospikeClient(), now)
+	h.replicateBackup(ctx, now, getFullBackupFolder(h.backend.fullBackupsPath, now, h.backend.removeFullBackup))
+
+	if err := h.applyRetention(ctx, now); err != nil {
+		logger.Error("Could not apply retention policy", slog.Any("err", err))
+	}
 	return nil
 }
 
-func (h *BackupRoutineHandler) startFullBackupForAllNamespaces(
-	ctx context.Context, upperBound time.Time, client *backup.Client) error {
-	clear(h.fullBackupHandlers)
+// cleanupCancelledFullBackup waits for the cancelled backup to stop and
+// deletes its partial output.
+func (h *BackupRoutineHandler) cleanupCancelledFullBackup(ctx context.Context, now time.Time, logger *slog.Logger) {
+	waitForStop(ctx, h.runningBackupHandlers(h.fullBackupHandlers))
+	if err := h.backend.deleteFullBackup(context.WithoutCancel(ctx), now); err != nil {
+		logger.Error("Could not delete cancelled backup", slog.Any("err", err))
+	}
+	backupCancelledCounter.Inc()
+	logger.Info("Full backup cancelled")
+}
+
+// cleanupFailedFullBackup waits for the stopped backups of the other
+// namespaces and deletes the output of the failed backup, which is never
+// committed.
+func (h *BackupRoutineHandler) cleanupFailedFullBackup(ctx context.Context, now time.Time, logger *slog.Logger) {
+	waitForStop(ctx, h.runningBackupHandlers(h.fullBackupHandlers))
+	if err := h.backend.deleteFullBackup(ctx, now); err != nil {
+		logger.Error("Could not delete failed backup", slog.Any("err", err))
+	}
+}
+
+// runFullBackupForAllNamespaces backs up the namespaces of the routine,
+// at most the configured number of them in parallel.
+// The backup is committed by writing the metadata of the namespaces once all
+// of them succeed, until then it is not listed. If a namespace fails, the
+// backups of the other namespaces are stopped.
+func (h *BackupRoutineHandler) runFullBackupForAllNamespaces(
+	ctx context.Context, upperBound time.Time, client *backup.Client, tracker *backupJobTracker,
+) error {
+	h.clearBackupHandlers(h.fullBackupHandlers)
+	defer useBackupFolder(h.storage, getFullBackupFolder(h.backend.fullBackupsPath, upperBound,
+		h.backend.removeFullBackup))()
+	// stops the running namespaces when one of them fails
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
 
 	timebounds := model.TimeBounds{}
 	if h.backupFullPolicy.IsSealed() {
 		timebounds.ToTime = &upperBound
 	}
 
-	namespaces, err := getNamespacesToBackup(h.namespaces, client.AerospikeClient())
+	namespaces, err := getNamespacesToBackup(h.backupRoutine.Namespaces, client.AerospikeClient())
 	if err != nil {
 		return err
 	}
+	namespaces = h.namespacesInBackupOrder(namespaces, client.AerospikeClient())
 
-	for _, namespace := range namespaces {
-		backupFolder := getFullPath(h.backend.fullBackupsPath, h.backupFullPolicy, namespace, upperBound)
-		handler, err := h.backupService.BackupRun(ctx, h.backupRoutine, h.backupFullPolicy, client,
-			h.storage, h.secretAgent, timebounds, namespace, backupFolder)
-		if err != nil {
-			backupFailureCounter.Inc()
-			return fmt.Errorf("could not start backup of namespace %s, routine %s: %w",
-				namespace, h.routineName, err)
-		}
-
-		h.fullBackupHandlers[namespace] = handler
-	}
-
-	return nil
-}
-
-func (h *BackupRoutineHandler) waitForFullBackups(ctx context.Context, backupTimestamp time.Time) error {
+	run := h.newBackupRun(upperBound, model.BackupTypeFull, h.backupRoutine, h.backupFullPolicy,
+		client.AerospikeClient(), tracker)
 	startTime := time.Now() // startTime is only used to measure backup time
-	for namespace, handler := range h.fullBackupHandlers {
-		err := handler.Wait(ctx)
-		if err != nil {
-			backupFailureCounter.Inc()
-			return fmt.Errorf("error during backup namespace %s, routine %s: %w",
-				namespace, h.routineName, err)
-		}
+	var completed []namespaceBackup
+	err = runNamespaceBackups(ctx, namespaces, util.ValueOrZero(h.backupFullPolicy.ParallelNamespaces),
+		func(namespace string) (BackupHandler, error) {
+			backupFolder := getFullPath(h.backend.fullBackupsPath, h.backupFullPolicy, namespace, upperBound)
+			handler, err := h.backupService.BackupRun(ctx, h.backupRoutine, h.backupFullPolicy, client,
+				h.storage, h.secretAgent, timebounds, namespace, backupFolder)
+			if err != nil {
+				return nil, err
+			}
+
+			h.setBackupHandler(h.fullBackupHandlers, namespace, handler)
+			run.timebounds[namespace] = timebounds
+			tracker.addHandler(namespace, handler)
+			return handler, nil
+		},
+		func(result namespaceBackup) error {
+			if result.handler == nil {
+				backupFailureCounter.Inc()
+				return fmt.Errorf("could not start backup of namespace %s, routine %s: %w",
+					result.namespace, h.routineName, result.err)
+			}
+			if result.err != nil {
+				if !isCancelled(ctx) {
+					backupFailureCounter.Inc()
+				}
+				return fmt.Errorf("error during backup namespace %s, routine %s: %w",
+					result.namespace, h.routineName, result.err)
+			}
+
+			completed = append(completed, result)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
 
-		backupFolder := getFullPath(h.backend.fullBackupsPath, h.backupFullPolicy, namespace, backupTimestamp)
-		if err := h.writeBackupMetadata(ctx, handler.GetStats(), backupTimestamp, namespace, backupFolder); err != nil {
+	// commit the backup
+	for _, result := range completed {
+		backupFolder := getFullPath(h.backend.fullBackupsPath, h.backupFullPolicy, result.namespace, upperBound)
+		if err := h.writeBackupMetadata(ctx, result, run, backupFolder); err != nil {
 			return err
 		}
+		h.state.SetNamespaceLastSuccess(result.namespace, upperBound)
+		tracker.addKey(result.namespace, backupFolder)
 	}
+
 	backupDurationGauge.Set(float64(time.Since(startTime).Milliseconds()))
 	return nil
 }
@@ -197,144 +348,470 @@ func (h *BackupRoutineHandler) writeClusterConfiguration(
 	}
 }
 
+// backupRun describes a backup run of the routine, recorded in the metadata
+// of its namespace backups.
+type backupRun struct {
+	created    time.Time
+	backupType model.BackupType
+	adHoc      bool
+	routine    *model.BackupRoutine
+	policy     *model.BackupPolicy
+	// the name reported by the source cluster, empty if it could not be read
+	clusterName string
+	// the time bounds of the namespace backups
+	timebounds map[string]model.TimeBounds
+	// the keys of the full backups the incremental backups are based on, by namespace
+	parents map[string]string
+}
+
+func (h *BackupRoutineHandler) newBackupRun(
+	created time.Time, backupType model.BackupType, routine *model.BackupRoutine, policy *model.BackupPolicy,
+	client backup.AerospikeClient, tracker *backupJobTracker,
+) *backupRun {
+	clusterName, err := getClusterName(client)
+	if err != nil {
+		slog.Warn("Could not read cluster name",
+			slog.String("routine", h.routineName),
+			slog.Any("err", err))
+	}
+
+	return &backupRun{
+		created:     created,
+		backupType:  backupType,
+		adHoc:       tracker != nil,
+		routine:     routine,
+		policy:      policy,
+		clusterName: clusterName,
+		timebounds:  make(map[string]model.TimeBounds),
+		parents:     make(map[string]string),
+	}
+}
+
 func (h *BackupRoutineHandler) writeBackupMetadata(
-	ctx context.Context, stats *models.BackupStats, created time.Time, namespace string, backupFolder string,
+	ctx context.Context, result namespaceBackup, run *backupRun, backupFolder string,
 ) error {
-	metadata := model.BackupMetadata{
-		From:                time.Time{},
-		Created:             created,
-		Namespace:           namespace,
+	metadata := h.newBackupMetadata(result, run)
+	if err := h.backend.writeBackupMetadata(ctx, backupFolder, metadata); err != nil {
+		slog.Error("Could not Write backup metadata",
+			slog.String("routine", h.routineName),
+			slog.String("folder", backupFolder),
+			slog.Any("err", err))
+		return err
+	}
+
+	return nil
+}
+
+func (h *BackupRoutineHandler) newBackupMetadata(result namespaceBackup, run *backupRun) model.BackupMetadata {
+	stats := result.handler.GetStats()
+	timebounds := run.timebounds[result.namespace]
+	// no records modified after the backup finished are backed up
+	to := result.finished
+	if timebounds.ToTime != nil {
+		to = *timebounds.ToTime
+	}
+	duration := result.finished.Sub(result.started)
+
+	return model.BackupMetadata{
+		Created:             run.created,
+		From:                util.ValueOrZero(timebounds.FromTime),
+		To:                  to,
+		Namespace:           result.namespace,
+		Routine:             h.routineName,
+		ClusterLabel:        util.ValueOrZero(run.routine.SourceCluster.ClusterLabel),
+		ClusterName:         run.clusterName,
+		ServiceVersion:      abs.Version,
+		Type:                run.backupType,
+		AdHoc:               run.adHoc,
+		Parent:              run.parents[result.namespace],
+		Compression:         compressionMode(run.policy.CompressionPolicy),
+		Encryption:          encryptionMode(run.policy.EncryptionPolicy),
+		SetList:             run.routine.SetList,
+		BinList:             run.routine.BinList,
 		RecordCount:         stats.GetReadRecords(),
 		FileCount:           stats.GetFileCount(),
 		ByteCount:           stats.GetBytesWritten(),
 		SecondaryIndexCount: uint64(stats.GetSIndexes()),
 		UDFCount:            uint64(stats.GetUDFs()),
+		PartitionList:       util.ValueOrZero(run.routine.PartitionList),
+		Started:             result.started,
+		Finished:            result.finished,
+		Duration:            duration.Milliseconds(),
+		RecordsPerSecond:    perSecond(stats.GetReadRecords(), duration),
+		BytesPerSecond:      perSecond(stats.GetBytesWritten(), duration),
+		Files:               result.handler.GetFiles(),
 	}
+}
 
-	if err := h.backend.writeBackupMetadata(ctx, backupFolder, metadata); err != nil {
-		slog.Error("Could not Write backup metadata",
+func compressionMode(policy *model.CompressionPolicy) string {
+	if policy == nil {
+		return backup.CompressNone
+	}
+	return policy.Mode
+}
+
+func encryptionMode(policy *model.EncryptionPolicy) string {
+	if policy == nil {
+		return model.EncryptNone
+	}
+	return policy.Mode
+}
+
+func perSecond(count uint64, duration time.Duration) uint64 {
+	if duration <= 0 {
+		return 0
+	}
+	return uint64(float64(count) / duration.Seconds())
+}
+
+// parentBackups returns the keys of the latest full backups before the time,
+// by namespace.
+func (h *BackupRoutineHandler) parentBackups(upperBound time.Time) map[string]string {
+	parents := make(map[string]string)
+	fullBackups, err := h.backend.FindLastFullBackup(upperBound)
+	if err != nil {
+		slog.Warn("Could not find parent full backup",
 			slog.String("routine", h.routineName),
-			slog.String("folder", backupFolder),
 			slog.Any("err", err))
-		return err
+		return parents
 	}
 
-	return nil
+	for _, b := range fullBackups {
+		parents[b.Namespace] = b.Key
+	}
+	return parents
 }
 
 func (h *BackupRoutineHandler) deleteFolder(ctx context.Context, path string, logger *slog.Logger) {
-	err := storage.DeleteFolder(ctx, h.storage, path)
+	err := h.backend.deleteFolder(ctx, path)
 	if err != nil {
 		logger.Error("Could not delete folder", slog.Any("err", err))
 	}
 }
 
-func (h *BackupRoutineHandler) runIncrementalBackup(ctx context.Context, now time.Time) {
-	logger := slog.Default().With(slog.String("routine", h.routineName))
+// runIncrementalBackup runs an incremental backup of the routine.
+// The overrides are optional, the routine state is updated only by the runs
+// without overrides.
+// The tracker is set for ad-hoc jobs only.
+func (h *BackupRoutineHandler) runIncrementalBackup(
+	ctx context.Context, now time.Time, overrides *model.BackupOverrides, tracker *backupJobTracker,
+) {
+	tracker.setRunning()
+	err := h.runIncrementalBackupInternal(ctx, now, overrides, tracker)
+	switch {
+	case err == nil:
+		tracker.setDone()
+	case errors.Is(err, errBackupSkipped):
+		tracker.setSkipped()
+	default:
+		tracker.setFailed(err)
+	}
+}
 
-	if h.state.LastFullRunIsEmpty() {
-		logger.Debug("Skip incremental backup until initial full backup is done")
-		return
+func (h *BackupRoutineHandler) runIncrementalBackupInternal(
+	ctx context.Context, now time.Time, overrides *model.BackupOverrides, tracker *backupJobTracker,
+) error {
+	logger := slog.Default().With(slog.String("routine", h.routineName))
+	if h.skipIncrementalBackup(logger) {
+		return errBackupSkipped
 	}
-	if h.backend.FullBackupInProgress().Load() {
-		logger.Debug("Full backup is currently in progress, skipping incremental backup")
-		return
+
+	// the job waiting for the limits is not in progress yet
+	release, err := h.acquireConcurrencyLimits(ctx, jobTypeIncremental)
+	if err != nil {
+		return err
 	}
-	if len(h.incrBackupHandlers) > 0 {
-		logger.Debug("Incremental backup is currently in progress, skipping incremental backup")
-		return
+	defer release()
+
+	// the state could change while waiting for the limits
+	if h.skipIncrementalBackup(logger) {
+		return errBackupSkipped
 	}
 
-	client, err := h.clientManager.GetClient(h.backupRoutine.SourceCluster)
+	ctx, finish := h.trackRun(ctx, jobTypeIncremental, tracker)
+	defer finish()
+
+	client, err := h.getClient()
 	if err != nil {
 		logger.Error("cannot create backup client", slog.Any("err", err))
-		return
+		return fmt.Errorf("cannot create backup client: %w", err)
 	}
-
 	defer func() {
 		h.clientManager.Close(client)
-		clear(h.incrBackupHandlers)
+		h.clearBackupHandlers(h.incrBackupHandlers)
 	}()
 
-	h.startIncrementalBackupForAllNamespaces(ctx, client, now)
-
-	h.waitForIncrementalBackups(ctx, now, logger)
+	namespaces, failed, err := h.runIncrementalBackupForAllNamespaces(ctx, client, now, overrides, logger, tracker)
+	if err != nil && isCancelled(ctx) {
+		h.cleanupCancelledIncrementalBackup(ctx, now, logger)
+		return context.Cause(ctx)
+	}
 	// increment incrBackupCounter metric
 	incrBackupCounter.Inc()
 
-	// update the state
-	h.state.SetLastIncrRun(now)
+	if overrides != nil {
+		logger.Info("Incremental backup with overrides completed")
+		return err
+	}
+
+	// update the state, unless the namespaces could not be read
+	if namespaces != nil {
+		h.state.SetLastIncrRun(now, namespaces, failed)
+		h.writeState(ctx)
+	}
+	return err
 }
 
-func (h *BackupRoutineHandler) startIncrementalBackupForAllNamespaces(
-	ctx context.Context, client *backup.Client, upperBound time.Time) {
-	timebounds := model.NewTimeBoundsFrom(h.state.LastRun())
-	if h.backupFullPolicy.IsSealed() {
-		timebounds.ToTime = &upperBound
+// skipIncrementalBackup returns true if the incremental backup cannot run now.
+func (h *BackupRoutineHandler) skipIncrementalBackup(logger *slog.Logger) bool {
+	switch {
+	case h.state.LastFullRunIsEmpty():
+		logger.Debug("Skip incremental backup until initial full backup is done")
+	case h.backend.FullBackupInProgress().Load():
+		logger.Debug("Full backup is currently in progress, skipping incremental backup")
+	case len(h.runningBackupHandlers(h.incrBackupHandlers)) > 0:
+		logger.Debug("Incremental backup is currently in progress, skipping incremental backup")
+	default:
+		return false
 	}
 
-	clear(h.incrBackupHandlers)
+	return true
+}
 
-	namespaces, err := getNamespacesToBackup(h.namespaces, client.AerospikeClient())
-	if err != nil {
-		return
+// cleanupCancelledIncrementalBackup waits for the cancelled backup to stop,
+// deletes its partial output and records the cancellation in the state.
+func (h *BackupRoutineHandler) cleanupCancelledIncrementalBackup(
+	ctx context.Context, now time.Time, logger *slog.Logger,
+) {
+	waitForStop(ctx, h.runningBackupHandlers(h.incrBackupHandlers))
+	ctx = context.WithoutCancel(ctx)
+	if err := h.backend.deleteIncrementalBackup(ctx, now); err != nil {
+		logger.Error("Could not delete cancelled backup", slog.Any("err", err))
 	}
+	incrBackupCancelledCounter.Inc()
+	h.state.SetLastError(errBackupCancelled, time.Now())
+	h.writeState(ctx)
+	logger.Info("Incremental backup cancelled")
+}
 
-	for _, namespace := range namespaces {
-		backupFolder := getIncrementalPathForNamespace(h.backend.incrementalBackupsPath, namespace, upperBound)
-		handler, err := h.backupService.BackupRun(ctx,
-			h.backupRoutine, h.backupIncrPolicy, client, h.storage, h.secretAgent,
-			*timebounds, namespace, backupFolder)
-		if err != nil {
-			incrBackupFailureCounter.Inc()
-			slog.Warn("could not start backup",
-				slog.String("namespace", namespace),
-				slog.String("routine", h.routineName),
-				slog.Any("err", err))
-		}
-		h.incrBackupHandlers[namespace] = handler
+// writeState persists the routine state, errors are only logged as the
+// state can be restored from the backup list.
+func (h *BackupRoutineHandler) writeState(ctx context.Context) {
+	if err := h.backend.writeState(ctx, h.state); err != nil {
+		slog.Error("Could not write backup state",
+			slog.String("routine", h.routineName),
+			slog.Any("err", err))
 	}
 }
 
-func (h *BackupRoutineHandler) waitForIncrementalBackups(
-	ctx context.Context, backupTimestamp time.Time, logger *slog.Logger,
-) {
+// runIncrementalBackupForAllNamespaces backs up the namespaces of the routine,
+// at most the configured number of them in parallel, each one from its own
+// last successful backup. The namespaces which backup could not be started
+// or failed are skipped, their errors are joined.
+// It returns the namespaces of the routine and the failed ones, nil namespaces
+// if they could not be read.
+func (h *BackupRoutineHandler) runIncrementalBackupForAllNamespaces(
+	ctx context.Context, client *backup.Client, upperBound time.Time,
+	overrides *model.BackupOverrides, logger *slog.Logger, tracker *backupJobTracker,
+) ([]string, []string, error) {
+	h.clearBackupHandlers(h.incrBackupHandlers)
+	defer useBackupFolder(h.storage, getIncrementalPath(h.backend.incrementalBackupsPath, upperBound))()
+
+	routine := overrides.Apply(h.backupRoutine)
+	namespaces, err := getNamespacesToBackup(routine.Namespaces, client.AerospikeClient())
+	if err != nil {
+		return nil, nil, err
+	}
+	namespaces = h.namespacesInBackupOrder(namespaces, client.AerospikeClient())
+
+	run := h.newBackupRun(upperBound, model.BackupTypeIncremental, routine, h.backupIncrPolicy,
+		client.AerospikeClient(), tracker)
+	run.parents = h.parentBackups(upperBound)
 	startTime := time.Now() // startTime is only used to measure backup time
 	hasBackup := false
-	for namespace, handler := range h.incrBackupHandlers {
-		err := handler.Wait(ctx)
-		if err != nil {
-			slog.Warn("Failed incremental backup",
-				slog.String("routine", h.routineName),
-				slog.Any("err", err))
-			incrBackupFailureCounter.Inc()
-		}
+	var errs []error
+	var failed []string
+	err = runNamespaceBackups(ctx, namespaces, util.ValueOrZero(h.backupIncrPolicy.ParallelNamespaces),
+		func(namespace string) (BackupHandler, error) {
+			backupFolder := getIncrementalPathForNamespace(h.backend.incrementalBackupsPath, namespace, upperBound)
+			timebounds := h.incrementalTimeBounds(namespace, upperBound, overrides)
+			handler, err := h.backupService.BackupRun(ctx,
+				routine, h.backupIncrPolicy, client, h.storage, h.secretAgent,
+				timebounds, namespace, backupFolder)
+			if err != nil {
+				return nil, err
+			}
+
+			h.setBackupHandler(h.incrBackupHandlers, namespace, handler)
+			run.timebounds[namespace] = timebounds
+			tracker.addHandler(namespace, handler)
+			return handler, nil
+		},
+		func(result namespaceBackup) error {
+			if isCancelled(ctx) {
+				return context.Cause(ctx)
+			}
+			if result.handler == nil {
+				incrBackupFailureCounter.Inc()
+				slog.Warn("could not start backup",
+					slog.String("namespace", result.namespace),
+					slog.String("routine", h.routineName),
+					slog.Any("err", result.err))
+				errs = append(errs, fmt.Errorf("could not start incremental backup of namespace %s: %w",
+					result.namespace, result.err))
+				failed = append(failed, result.namespace)
+				return nil
+			}
+
+			backupFolder := getIncrementalPathForNamespace(h.backend.incrementalBackupsPath, result.namespace, upperBound)
+			kept, err := h.commitIncrementalBackup(ctx, result, run, backupFolder, logger)
+			if err != nil {
+				slog.Warn("Failed incremental backup",
+					slog.String("routine", h.routineName),
+					slog.Any("err", err))
+				incrBackupFailureCounter.Inc()
+				err := fmt.Errorf("incremental backup of namespace %s: %w", result.namespace, err)
+				h.state.SetLastError(err, time.Now())
+				errs = append(errs, err)
+				failed = append(failed, result.namespace)
+				return nil
+			}
+			if kept {
+				tracker.addKey(result.namespace, backupFolder)
+				hasBackup = true
+			}
+			return nil
+		})
+	if err != nil {
+		return namespaces, failed, err
+	}
+
+	if hasBackup {
+		h.replicateBackup(ctx, upperBound, getIncrementalPath(h.backend.incrementalBackupsPath, upperBound))
+	} else {
+		h.deleteFolder(ctx, getIncrementalPath(h.backend.incrementalBackupsPath, upperBound), logger)
+	}
+
+	incrBackupDurationGauge.Set(float64(time.Since(startTime).Milliseconds()))
+	return namespaces, failed, errors.Join(errs...)
+}
+
+// commitIncrementalBackup writes the metadata of the finished namespace
+// backup, and only then advances the namespace to the backup time, so that
+// the next run starts from it. The folder of a failed backup is deleted, for
+// the next run to back up the namespace changes again, and the folder of an
+// empty backup as it has nothing to restore.
+// It returns true if the backup folder is kept.
+func (h *BackupRoutineHandler) commitIncrementalBackup(
+	ctx context.Context, result namespaceBackup, run *backupRun, backupFolder string, logger *slog.Logger,
+) (bool, error) {
+	if result.err == nil && result.handler.GetStats().IsEmpty() {
+		h.deleteFolder(ctx, backupFolder, logger)
+		h.state.SetNamespaceLastSuccess(result.namespace, run.created)
+		return false, nil
+	}
+
+	err := result.err
+	if err == nil {
+		err = h.writeBackupMetadata(ctx, result, run, backupFolder)
+	}
+	if err != nil {
+		h.deleteFolder(ctx, backupFolder, logger)
+		return false, err
+	}
+
+	h.state.SetNamespaceLastSuccess(result.namespace, run.created)
+	return true, nil
+}
+
+// incrementalTimeBounds returns the time bounds of the incremental backup of
+// the namespace, which starts from the last successful backup of the namespace
+// unless overridden.
+func (h *BackupRoutineHandler) incrementalTimeBounds(
+	namespace string, upperBound time.Time, overrides *model.BackupOverrides,
+) model.TimeBounds {
+	timebounds := model.NewTimeBoundsFrom(h.state.IncrementalFrom(namespace))
+	if overrides != nil && overrides.From != nil {
+		timebounds.FromTime = overrides.From
+	}
+	if h.backupFullPolicy.IsSealed() {
+		timebounds.ToTime = &upperBound
+	}
+
+	return *timebounds
+}
 
-		backupFolder := getIncrementalPathForNamespace(h.backend.incrementalBackupsPath, namespace, backupTimestamp)
-		// delete if the backup file is empty
-		if handler.GetStats().IsEmpty() {
-			h.deleteFolder(ctx, backupFolder, logger)
-			continue
+// runningFlag returns the flag set while a backup of the given type runs.
+func (h *BackupRoutineHandler) runningFlag(jobType jobType) *atomic.Bool {
+	if jobType == jobTypeIncremental {
+		return &h.incrRunning
+	}
+	return &h.fullRunning
+}
+
+// HoldBackups prevents the backups of the routine from starting, including
+// the scheduled, ad-hoc and retried ones, until the returned function is
+// called. It returns ErrBackupInProgress if a backup of the routine is running
+// or waiting to run.
+func (h *BackupRoutineHandler) HoldBackups() (func(), error) {
+	flags := []*atomic.Bool{&h.fullRunning, &h.incrRunning, h.backend.FullBackupInProgress()}
+	release := func(held []*atomic.Bool) {
+		for _, flag := range held {
+			flag.Store(false)
 		}
-		if err := h.writeBackupMetadata(ctx, handler.GetStats(), backupTimestamp, namespace, backupFolder); err != nil {
-			slog.Error("Could not Write backup metadata",
-				slog.String("routine", h.routineName),
-				slog.String("folder", backupFolder),
-				slog.Any("err", err))
+	}
+	for i, flag := range flags {
+		if !flag.CompareAndSwap(false, true) {
+			release(flags[:i])
+			return nil, ErrBackupInProgress
 		}
-		hasBackup = true
 	}
 
-	if !hasBackup {
-		h.deleteFolder(ctx, getIncrementalPath(h.backend.incrementalBackupsPath, backupTimestamp), logger)
-	}
+	return func() { release(flags) }, nil
+}
 
-	incrBackupDurationGauge.Set(float64(time.Since(startTime).Milliseconds()))
+// setBackupHandler records the running backup of the namespace.
+func (h *BackupRoutineHandler) setBackupHandler(handlers map[string]BackupHandler, namespace string,
+	handler BackupHandler) {
+	h.handlersLock.Lock()
+	defer h.handlersLock.Unlock()
+	handlers[namespace] = handler
+}
+
+// clearBackupHandlers removes the backups of the finished run.
+func (h *BackupRoutineHandler) clearBackupHandlers(handlers map[string]BackupHandler) {
+	h.handlersLock.Lock()
+	defer h.handlersLock.Unlock()
+	clear(handlers)
+}
+
+// runningBackupHandlers returns a copy of the running backups by namespace.
+func (h *BackupRoutineHandler) runningBackupHandlers(handlers map[string]BackupHandler) map[string]BackupHandler {
+	h.handlersLock.Lock()
+	defer h.handlersLock.Unlock()
+	return maps.Clone(handlers)
 }
 
 func (h *BackupRoutineHandler) GetCurrentStat() *model.CurrentBackups {
 	return &model.CurrentBackups{
-		Full:        currentBackupStatus(h.fullBackupHandlers),
-		Incremental: currentBackupStatus(h.incrBackupHandlers),
+		Full:        currentBackupStatus(h.runningBackupHandlers(h.fullBackupHandlers)),
+		Incremental: currentBackupStatus(h.runningBackupHandlers(h.incrBackupHandlers)),
+		Retry:       h.retryStatus(),
+	}
+}
+
+// retryStatus returns the state of the scheduled full backup retries,
+// nil if the last full backup is not being retried.
+func (h *BackupRoutineHandler) retryStatus() *model.RetryStatus {
+	attempt, nextRetry := h.retry.status()
+	if attempt == 0 && nextRetry.IsZero() {
+		return nil
+	}
+
+	status := &model.RetryStatus{Attempt: attempt}
+	if !nextRetry.IsZero() {
+		status.Attempt++ // the pending attempt
+		status.NextRetryTime = &nextRetry
 	}
+	return status
 }
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	abs "github.com/aerospike/aerospike-backup-service/v2"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/aerospike/backup-go/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBackupMetadata(t *testing.T) {
+	handler := &BackupRoutineHandler{routineName: "routine"}
+	created := time.UnixMilli(1707915600000)
+	from := created.Add(-time.Hour)
+	run := &backupRun{
+		created:    created,
+		backupType: model.BackupTypeIncremental,
+		adHoc:      true,
+		routine: &model.BackupRoutine{
+			SourceCluster: &model.AerospikeCluster{ClusterLabel: util.Ptr("label")},
+			SetList:       []string{"set1"},
+		},
+		policy: &model.BackupPolicy{
+			EncryptionPolicy: &model.EncryptionPolicy{Mode: "AES256"},
+		},
+		clusterName: "name",
+		timebounds:  map[string]model.TimeBounds{"test": {FromTime: &from}},
+		parents:     map[string]string{"test": "routine/backup/1707912000000/data/test"},
+	}
+	result := namespaceBackup{
+		namespace: "test",
+		handler:   &backupHandlerMock{},
+		started:   created,
+		finished:  created.Add(2 * time.Second),
+	}
+
+	metadata := handler.newBackupMetadata(result, run)
+
+	require.Equal(t, model.BackupMetadata{
+		Created:        created,
+		From:           from,
+		To:             result.finished,
+		Namespace:      "test",
+		Routine:        "routine",
+		ClusterLabel:   "label",
+		ClusterName:    "name",
+		ServiceVersion: abs.Version,
+		Type:           model.BackupTypeIncremental,
+		AdHoc:          true,
+		Parent:         "routine/backup/1707912000000/data/test",
+		Compression:    "NONE",
+		Encryption:     "AES256",
+		SetList:        []string{"set1"},
+		Started:        result.started,
+		Finished:       result.finished,
+		Duration:       2000,
+	}, metadata)
+
+	// the upper bound of a sealed backup
+	to := created.Add(-time.Minute)
+	run.timebounds["test"] = model.TimeBounds{ToTime: &to}
+	metadata = handler.newBackupMetadata(result, run)
+	require.True(t, metadata.From.IsZero())
+	require.Equal(t, to, metadata.To)
+}
+
+// statsHandlerMock is a finished namespace backup with the given stats.
+type statsHandlerMock struct {
+	backupHandlerMock
+	stats *models.BackupStats
+}
+
+func (m *statsHandlerMock) GetStats() *models.BackupStats {
+	return m.stats
+}
+
+func TestCommitIncrementalBackup(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend := newStorageBackend("routine", &model.LocalStorage{Path: root}, false)
+	handler := &BackupRoutineHandler{
+		backend:     backend,
+		routineName: "routine",
+		state:       model.NewBackupState(),
+	}
+	created := time.UnixMilli(1707915600000)
+	run := &backupRun{
+		created:    created,
+		backupType: model.BackupTypeIncremental,
+		routine:    &model.BackupRoutine{SourceCluster: &model.AerospikeCluster{}},
+		policy:     &model.BackupPolicy{},
+		timebounds: make(map[string]model.TimeBounds),
+	}
+	notEmpty := &models.BackupStats{}
+	notEmpty.AddUDFs(1)
+	logger := slog.Default()
+
+	commit := func(namespace string, stats *models.BackupStats, backupErr error) (string, bool, error) {
+		folder := getIncrementalPathForNamespace(backend.incrementalBackupsPath, namespace, created)
+		require.NoError(t, os.MkdirAll(filepath.Join(root, folder), 0744))
+		kept, err := handler.commitIncrementalBackup(ctx, namespaceBackup{
+			namespace: namespace,
+			handler:   &statsHandlerMock{stats: stats},
+			err:       backupErr,
+		}, run, folder, logger)
+		return folder, kept, err
+	}
+
+	// the namespace is advanced after the metadata is written
+	folder, kept, err := commit("ns1", notEmpty, nil)
+	require.NoError(t, err)
+	require.True(t, kept)
+	require.FileExists(t, filepath.Join(root, folder, metadataFile))
+	require.Equal(t, created, handler.state.GetNamespaceLastSuccess()["ns1"])
+
+	// the empty backup is deleted, the namespace is advanced
+	folder, kept, err = commit("ns2", &models.BackupStats{}, nil)
+	require.NoError(t, err)
+	require.False(t, kept)
+	require.NoDirExists(t, filepath.Join(root, folder))
+	require.Equal(t, created, handler.state.GetNamespaceLastSuccess()["ns2"])
+
+	// the failed backup is deleted, the namespace is not advanced
+	folder, kept, err = commit("ns3", notEmpty, errors.New("backup failed"))
+	require.Error(t, err)
+	require.False(t, kept)
+	require.NoDirExists(t, filepath.Join(root, folder))
+	require.NotContains(t, handler.state.GetNamespaceLastSuccess(), "ns3")
+
+	// the backup which metadata cannot be written is deleted as failed
+	folder = getIncrementalPathForNamespace(backend.incrementalBackupsPath, "ns4", created)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, folder, metadataFile), 0744))
+	_, kept, err = commit("ns4", notEmpty, nil)
+	require.Error(t, err)
+	require.False(t, kept)
+	require.NoDirExists(t, filepath.Join(root, folder))
+	require.NotContains(t, handler.state.GetNamespaceLastSuccess(), "ns4")
+}
+
+func TestHoldBackups(t *testing.T) {
+	backend := newStorageBackend("routine", &model.LocalStorage{Path: t.TempDir()}, false)
+	handler := &BackupRoutineHandler{backend: backend, routineName: "routine"}
+
+	// the backups cannot start while held
+	resume, err := handler.HoldBackups()
+	require.NoError(t, err)
+	require.True(t, handler.fullRunning.Load())
+	require.True(t, handler.incrRunning.Load())
+	require.True(t, backend.FullBackupInProgress().Load())
+	_, err = handler.HoldBackups()
+	require.ErrorIs(t, err, ErrBackupInProgress)
+
+	resume()
+	require.False(t, handler.fullRunning.Load())
+	require.False(t, handler.incrRunning.Load())
+	require.False(t, backend.FullBackupInProgress().Load())
+
+	// the running backup is not released on failure
+	handler.incrRunning.Store(true)
+	_, err = handler.HoldBackups()
+	require.ErrorIs(t, err, ErrBackupInProgress)
+	require.True(t, handler.incrRunning.Load())
+	require.False(t, handler.fullRunning.Load())
+	require.False(t, backend.FullBackupInProgress().Load())
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
 	"github.com/reugn/go-quartz/quartz"
 )
 
@@ -37,20 +38,48 @@ func (b *backupJobs) put(key string, value *quartz.JobDetail) {
 	b.jobs[key] = value
 }
 
-// NewAdHocFullBackupJobForRoutine returns a new full backup job for the routine name.
-func NewAdHocFullBackupJobForRoutine(routineName string) *quartz.JobDetail {
+// NewAdHocFullBackupJobForRoutine returns a new full backup job for the routine name
+// and the id to query the job status with.
+func NewAdHocFullBackupJobForRoutine(routineName string) (*quartz.JobDetail, model.BackupJobID) {
 	jobStore.Lock()
 	defer jobStore.Unlock()
 
-	key := fullJobKey(routineName).String()
-	job := jobStore.jobs[key]
+	job := jobStore.jobs[fullJobKey(routineName).String()]
 	if job == nil {
-		return nil
+		return nil, 0
+	}
+
+	return newAdHocJobDetail(job.Job().(*backupJob), routineName, nil)
+}
+
+// NewAdHocIncrementalBackupJobForRoutine returns a new incremental backup job
+// for the routine name and the id to query the job status with.
+// The overrides are optional.
+func NewAdHocIncrementalBackupJobForRoutine(routineName string, overrides *model.BackupOverrides,
+) (*quartz.JobDetail, model.BackupJobID) {
+	jobStore.Lock()
+	defer jobStore.Unlock()
+
+	if job := jobStore.jobs[incrJobKey(routineName).String()]; job != nil {
+		return newAdHocJobDetail(job.Job().(*backupJob), routineName, overrides)
 	}
 
-	jobKey := adhocKey(routineName)
+	// the routine has no scheduled incremental backups.
+	fullJob := jobStore.jobs[fullJobKey(routineName).String()]
+	if fullJob == nil {
+		return nil, 0
+	}
+	handler := fullJob.Job().(*backupJob).handler
+	job := newBackupJob(handler, jobTypeIncremental).(*backupJob)
 
-	return quartz.NewJobDetail(job.Job(), jobKey)
+	return newAdHocJobDetail(job, routineName, overrides)
+}
+
+func newAdHocJobDetail(job *backupJob, routineName string, overrides *model.BackupOverrides,
+) (*quartz.JobDetail, model.BackupJobID) {
+	tracker := adHocJobs.newJob(routineName, job.jobType)
+
+	return quartz.NewJobDetail(job.adHocCopy(overrides, tracker), adhocKey(routineName, tracker.id)), tracker.id
 }
 
 // NewScheduler creates a new running quartz.Scheduler
@@ -71,25 +100,61 @@ func scheduleRoutines(scheduler quartz.Scheduler, config *model.Config,
 	for routineName, routine := range config.BackupRoutines {
 		handler := handlers[routineName]
 
+		if routine.Disabled {
+			slog.Info("Routine is disabled, skip scheduling", slog.String("name", routineName))
+			registerDisabledRoutine(handler, routine, routineName)
+			continue
+		}
+
 		// schedule a full backup job for the routine
-		if err := scheduleFullBackup(scheduler, handler, routine.IntervalCron, routineName); err != nil {
+		if err := scheduleFullBackup(scheduler, handler, routine.IntervalCron, routine.Location(),
+			routineName); err != nil {
 			return fmt.Errorf("failed to schedule full backup: %w", err)
 		}
 
 		if routine.IncrIntervalCron != "" {
 			// schedule an incremental backup job for the routine
-			if err := scheduleIncrementalBackup(scheduler, handler, routine.IncrIntervalCron, routineName); err != nil {
+			if err := scheduleIncrementalBackup(scheduler, handler, routine.IncrIntervalCron, routine.Location(),
+				routineName); err != nil {
 				return fmt.Errorf("failed to schedule incremental backup: %w", err)
 			}
 		}
+
+		if routine.VerifyIntervalCron != "" {
+			// schedule a verification job of the latest full backup
+			if err := scheduleVerification(scheduler, handler, routine.VerifyIntervalCron, routine.Location(),
+				routineName); err != nil {
+				return fmt.Errorf("failed to schedule backup verification: %w", err)
+			}
+		}
+
+		if routine.Tiering != nil {
+			// schedule a job moving the aged backups to the tiering storage
+			if err := scheduleTiering(scheduler, handler, routineName); err != nil {
+				return fmt.Errorf("failed to schedule backup tiering: %w", err)
+			}
+		}
 	}
 	return nil
 }
 
+// registerDisabledRoutine stores the jobs of the disabled routine without
+// scheduling them, so that ad-hoc backups can still be run.
+func registerDisabledRoutine(handler *BackupRoutineHandler, routine *model.BackupRoutine, routineName string) {
+	fullJobDetail := quartz.NewJobDetail(newBackupJob(handler, jobTypeFull), fullJobKey(routineName))
+	jobStore.put(fullJobDetail.JobKey().String(), fullJobDetail)
+
+	if routine.IncrIntervalCron != "" {
+		incrJobDetail := quartz.NewJobDetail(newBackupJob(handler, jobTypeIncremental), incrJobKey(routineName))
+		jobStore.put(incrJobDetail.JobKey().String(), incrJobDetail)
+	}
+}
+
 func scheduleFullBackup(
-	scheduler quartz.Scheduler, handler *BackupRoutineHandler, interval string, routineName string,
+	scheduler quartz.Scheduler, handler *BackupRoutineHandler, interval string, location *time.Location,
+	routineName string,
 ) error {
-	fullCronTrigger, err := quartz.NewCronTrigger(interval)
+	fullCronTrigger, err := quartz.NewCronTriggerWithLoc(interval, location)
 	if err != nil {
 		return err
 	}
@@ -102,11 +167,11 @@ func scheduleFullBackup(
 	}
 
 	jobStore.put(fullJobDetail.JobKey().String(), fullJobDetail)
-	if needToRunFullBackupNow(handler.state.LastFullRun, fullCronTrigger) {
+	if needToRunFullBackupNow(handler.state.GetLastFullRun(), fullCronTrigger) {
 		slog.Debug("Schedule initial full backup", "name", routineName)
 		fullJobDetail := quartz.NewJobDetail(
 			fullJob,
-			quartz.NewJobKey(routineName),
+			catchUpJobKey(routineName),
 		)
 		if err = scheduler.ScheduleJob(fullJobDetail, quartz.NewRunOnceTrigger(0)); err != nil {
 			return err
@@ -116,9 +181,10 @@ func scheduleFullBackup(
 }
 
 func scheduleIncrementalBackup(
-	scheduler quartz.Scheduler, handler *BackupRoutineHandler, interval string, routineName string,
+	scheduler quartz.Scheduler, handler *BackupRoutineHandler, interval string, location *time.Location,
+	routineName string,
 ) error {
-	incrCronTrigger, err := quartz.NewCronTrigger(interval)
+	incrCronTrigger, err := quartz.NewCronTriggerWithLoc(interval, location)
 	if err != nil {
 		return err
 	}
@@ -147,8 +213,15 @@ func fullJobKey(routineName string) *quartz.JobKey {
 	return quartz.NewJobKeyWithGroup(jobName, string(quartzGroupScheduled))
 }
 
-func adhocKey(name string) *quartz.JobKey {
-	jobName := fmt.Sprintf("%s-adhoc-%d", name, time.Now().UnixMilli())
+// catchUpJobKey is the key of the full backup job scheduled on startup
+// if the last full backup is older than the schedule interval.
+func catchUpJobKey(routineName string) *quartz.JobKey {
+	return quartz.NewJobKey(routineName)
+}
+
+// adhocKey returns the key of an ad-hoc job, which is unique per job id.
+func adhocKey(name string, id model.BackupJobID) *quartz.JobKey {
+	jobName := fmt.Sprintf("%s-adhoc-%d", name, id)
 	return quartz.NewJobKeyWithGroup(jobName, string(quartzGroupAdHoc))
 }
 
@@ -167,3 +240,72 @@ func needToRunFullBackupNow(lastFullRun time.Time, trigger *quartz.CronTrigger)
 
 	return false
 }
+
+// NextBackupTimes returns the next scheduled full and incremental backup
+// times of the routine after the given time, nil if the backup is not scheduled.
+func NextBackupTimes(routine *model.BackupRoutine, now time.Time) (full *time.Time, incremental *time.Time) {
+	if routine.Disabled {
+		return nil, nil
+	}
+
+	return nextFireTime(routine.IntervalCron, routine.Location(), now),
+		nextFireTime(routine.IncrIntervalCron, routine.Location(), now)
+}
+
+func nextFireTime(interval string, location *time.Location, now time.Time) *time.Time {
+	if interval == "" {
+		return nil
+	}
+	trigger, err := quartz.NewCronTriggerWithLoc(interval, location)
+	if err != nil {
+		return nil
+	}
+	fireTimeNano, err := trigger.NextFireTime(now.UnixNano())
+	if err != nil {
+		return nil
+	}
+
+	return util.Ptr(time.Unix(0, fireTimeNano).In(location))
+}
+
+// RoutineSchedules returns the schedule of the routines with the next count
+// fire times of their scheduled backup jobs.
+func RoutineSchedules(scheduler quartz.Scheduler, handlers BackupHandlerHolder, count int,
+) map[string]*model.RoutineSchedule {
+	schedules := make(map[string]*model.RoutineSchedule, len(handlers))
+	for routineName, handler := range handlers {
+		location := handler.backupRoutine.Location()
+		_, err := scheduler.GetScheduledJob(catchUpJobKey(routineName))
+		schedules[routineName] = &model.RoutineSchedule{
+			Disabled:               handler.backupRoutine.Disabled,
+			NextFullBackups:        nextRunTimes(scheduler, fullJobKey(routineName), location, count),
+			NextIncrementalBackups: nextRunTimes(scheduler, incrJobKey(routineName), location, count),
+			CatchUpPending:         err == nil,
+			Running:                handler.GetCurrentStat(),
+			State:                  handler.state.Copy(),
+		}
+	}
+
+	return schedules
+}
+
+// nextRunTimes returns the next count run times of the scheduled job,
+// nil if the job is not scheduled.
+func nextRunTimes(scheduler quartz.Scheduler, key *quartz.JobKey, location *time.Location, count int) []time.Time {
+	job, err := scheduler.GetScheduledJob(key)
+	if err != nil {
+		return nil
+	}
+
+	times := make([]time.Time, 0, count)
+	next := job.NextRunTime()
+	for len(times) < count {
+		times = append(times, time.Unix(0, next).In(location))
+		next, err = job.Trigger().NextFireTime(next)
+		if err != nil {
+			break
+		}
+	}
+
+	return times
+}
@@ -1,10 +1,17 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/backup-go"
 	"github.com/reugn/go-quartz/quartz"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNeedToRunFullBackupNow(t *testing.T) {
@@ -47,3 +54,205 @@ func newTrigger(expression string) *quartz.CronTrigger {
 	trigger, _ := quartz.NewCronTrigger(expression)
 	return trigger
 }
+
+func TestNewAdHocBackupJobForRoutine(t *testing.T) {
+	const routineName = "adHocRoutine"
+	handler := &BackupRoutineHandler{routineName: routineName}
+	fullJob := newBackupJob(handler, jobTypeFull)
+	jobStore.put(fullJobKey(routineName).String(), quartz.NewJobDetail(fullJob, fullJobKey(routineName)))
+
+	if job, _ := NewAdHocIncrementalBackupJobForRoutine("unknown", nil); job != nil {
+		t.Error("Expected no job for unknown routine")
+	}
+
+	fullDetail, fullID := NewAdHocFullBackupJobForRoutine(routineName)
+	full := fullDetail.Job().(*backupJob)
+	if full.jobType != jobTypeFull || full.overrides != nil || full.isRunning != fullJob.(*backupJob).isRunning {
+		t.Errorf("Unexpected full job %+v", full)
+	}
+	if full.tracker == nil || full.tracker.id != fullID {
+		t.Errorf("Unexpected full job tracker %+v", full.tracker)
+	}
+
+	overrides := &model.BackupOverrides{Namespaces: []string{"source-ns1"}}
+	incrementalDetail, incrementalID := NewAdHocIncrementalBackupJobForRoutine(routineName, overrides)
+	incremental := incrementalDetail.Job().(*backupJob)
+	if incremental.jobType != jobTypeIncremental || incremental.handler != handler || incremental.overrides != overrides {
+		t.Errorf("Unexpected incremental job %+v", incremental)
+	}
+	if incrementalID == fullID {
+		t.Error("Expected distinct job ids")
+	}
+	if incrementalDetail.JobKey().Equals(fullDetail.JobKey()) {
+		t.Error("Expected distinct job keys")
+	}
+}
+
+// blockingClientManager blocks the backups until released, and fails them.
+type blockingClientManager struct {
+	MockClientManager
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (m *blockingClientManager) GetClient(_ *model.AerospikeCluster) (*backup.Client, error) {
+	m.calls.Add(1)
+	<-m.release
+	return nil, errors.New("no client")
+}
+
+func TestConcurrentAdHocIncrementalBackups(t *testing.T) {
+	const routineName = "concurrentRoutine"
+	clientManager := &blockingClientManager{release: make(chan struct{})}
+	state := model.NewBackupState()
+	state.SetLastFullRun(time.Now())
+	handler := &BackupRoutineHandler{
+		routineName:        routineName,
+		backupRoutine:      &model.BackupRoutine{},
+		backend:            &BackupBackend{fullBackupInProgress: &atomic.Bool{}},
+		state:              state,
+		clientManager:      clientManager,
+		runs:               NewBackupRuns(),
+		fullBackupHandlers: make(map[string]BackupHandler),
+		incrBackupHandlers: make(map[string]BackupHandler),
+	}
+	// the routine has no scheduled incremental backups
+	jobStore.put(fullJobKey(routineName).String(),
+		quartz.NewJobDetail(newBackupJob(handler, jobTypeFull), fullJobKey(routineName)))
+
+	var wg sync.WaitGroup
+	ids := make([]model.BackupJobID, 2)
+	for i := range ids {
+		detail, id := NewAdHocIncrementalBackupJobForRoutine(routineName, nil)
+		ids[i] = id
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = detail.Job().Execute(context.Background())
+		}()
+	}
+
+	// one of the backups runs, the other one is skipped
+	require.Eventually(t, func() bool {
+		return clientManager.calls.Load() == 1 && (jobStatus(t, ids[0]) == model.JobStatusSkipped ||
+			jobStatus(t, ids[1]) == model.JobStatusSkipped)
+	}, 5*time.Second, 10*time.Millisecond)
+	close(clientManager.release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), clientManager.calls.Load())
+	statuses := []model.JobStatus{jobStatus(t, ids[0]), jobStatus(t, ids[1])}
+	require.ElementsMatch(t, []model.JobStatus{model.JobStatusSkipped, model.JobStatusFailed}, statuses)
+}
+
+func jobStatus(t *testing.T, id model.BackupJobID) model.JobStatus {
+	t.Helper()
+	status, err := adHocJobs.getStatus(id)
+	require.NoError(t, err)
+	return status.Status
+}
+
+func TestScheduleDisabledRoutine(t *testing.T) {
+	const routineName = "disabledRoutine"
+	config := model.NewConfig()
+	config.BackupRoutines[routineName] = &model.BackupRoutine{
+		IntervalCron:     "@daily",
+		IncrIntervalCron: "@hourly",
+		Disabled:         true,
+	}
+	handler := &BackupRoutineHandler{routineName: routineName}
+	scheduler := quartz.NewStdScheduler()
+
+	if err := scheduleRoutines(scheduler, config, BackupHandlerHolder{routineName: handler}); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := scheduler.GetJobKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("Expected no scheduled jobs, got %v", keys)
+	}
+	// ad-hoc backups of the disabled routine are allowed
+	if job, _ := NewAdHocIncrementalBackupJobForRoutine(routineName, nil); job == nil {
+		t.Error("Expected ad-hoc job for disabled routine")
+	}
+}
+
+func TestNextBackupTimes(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	routine := &model.BackupRoutine{
+		IntervalCron: "0 0 2 * * *",
+		TimeZone:     newYork,
+	}
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	full, incremental := NextBackupTimes(routine, now)
+	if full == nil || !full.Equal(time.Date(2024, 7, 2, 2, 0, 0, 0, newYork)) {
+		t.Errorf("Unexpected next full backup time %v", full)
+	}
+	if incremental != nil {
+		t.Errorf("Expected no incremental backup, got %v", incremental)
+	}
+
+	routine.TimeZone = nil
+	routine.IncrIntervalCron = "0 */10 * * * *"
+	full, incremental = NextBackupTimes(routine, now)
+	if full == nil || !full.Equal(time.Date(2024, 7, 2, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next full backup time %v", full)
+	}
+	if incremental == nil || !incremental.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("Unexpected next incremental backup time %v", incremental)
+	}
+
+	routine.Disabled = true
+	if full, incremental = NextBackupTimes(routine, now); full != nil || incremental != nil {
+		t.Error("Expected no backups for disabled routine")
+	}
+}
+
+func TestRoutineSchedules(t *testing.T) {
+	const routineName = "scheduleRoutine"
+	routine := &model.BackupRoutine{
+		IntervalCron:     "0 0 2 * * *",
+		IncrIntervalCron: "0 */10 * * * *",
+	}
+	config := model.NewConfig()
+	config.BackupRoutines[routineName] = routine
+	state := model.NewBackupState()
+	state.SetLastError(errors.New("failed"), time.Now())
+	handler := &BackupRoutineHandler{
+		routineName:   routineName,
+		backupRoutine: routine,
+		state:         state,
+		retry:         NewRetryService(routineName),
+	}
+	handlers := BackupHandlerHolder{routineName: handler}
+	scheduler := quartz.NewStdScheduler()
+
+	if err := scheduleRoutines(scheduler, config, handlers); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule := RoutineSchedules(scheduler, handlers, 3)[routineName]
+	if schedule == nil {
+		t.Fatal("Expected routine schedule")
+	}
+	if len(schedule.NextFullBackups) != 3 || len(schedule.NextIncrementalBackups) != 3 {
+		t.Errorf("Unexpected next fire times %v, %v", schedule.NextFullBackups, schedule.NextIncrementalBackups)
+	}
+	if got := schedule.NextFullBackups[1].Sub(schedule.NextFullBackups[0]); got != 24*time.Hour {
+		t.Errorf("Unexpected full backup interval %v", got)
+	}
+	// no full backup was performed yet
+	if !schedule.CatchUpPending {
+		t.Error("Expected pending catch-up backup")
+	}
+	if schedule.State.LastError != "failed" {
+		t.Errorf("Unexpected state %v", schedule.State)
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/reugn/go-quartz/quartz"
+)
+
+const jobTypeTiering jobType = "tiering"
+
+// tieringInterval is the interval of the tiering jobs.
+const tieringInterval = time.Hour
+
+// tieringJob implements the quartz.Job interface.
+// It moves the aged backups of the routine to the storage of its tiering policy.
+type tieringJob struct {
+	handler *BackupRoutineHandler
+}
+
+var _ quartz.Job = (*tieringJob)(nil)
+
+// Execute is called by a Scheduler when the Trigger associated with this job fires.
+func (j *tieringJob) Execute(ctx context.Context) error {
+	if err := j.handler.moveAgedBackups(ctx, time.Now()); err != nil {
+		slog.Error("Could not move backups to tiering storage",
+			slog.String("routine", j.handler.routineName),
+			slog.Any("err", err))
+	}
+
+	return nil
+}
+
+// Description returns the description of the tiering job.
+func (j *tieringJob) Description() string {
+	return fmt.Sprintf("%s %s job", j.handler.routineName, jobTypeTiering)
+}
+
+func tieringJobKey(routineName string) *quartz.JobKey {
+	jobName := fmt.Sprintf("%s-%s", routineName, jobTypeTiering)
+	return quartz.NewJobKeyWithGroup(jobName, string(quartzGroupScheduled))
+}
+
+func scheduleTiering(scheduler quartz.Scheduler, handler *BackupRoutineHandler, routineName string) error {
+	tieringJobDetail := quartz.NewJobDetail(&tieringJob{handler: handler}, tieringJobKey(routineName))
+
+	return scheduler.ScheduleJob(tieringJobDetail, quartz.NewSimpleTrigger(tieringInterval))
+}
+
+// moveAgedBackups moves the backups older than the tiering policy allows
+// to the tiering storage.
+func (h *BackupRoutineHandler) moveAgedBackups(ctx context.Context, now time.Time) error {
+	tiering := h.backupRoutine.Tiering
+	if tiering == nil || h.backend.tier == nil {
+		return nil
+	}
+
+	moved, err := h.backend.moveToTier(ctx, now.Add(-tiering.After))
+	if moved > 0 {
+		tieringMovedCounter.WithLabelValues(h.routineName).Add(float64(moved))
+		slog.Info("Moved backups to tiering storage",
+			slog.String("routine", h.routineName),
+			slog.Int("backups", moved))
+	}
+
+	return err
+}
+
+// moveToTier moves the full and incremental backups created before the given
+// time to the tiering storage and returns the number of moved backups.
+// Each backup is copied before it is deleted, so that it is listed from one
+// of the storages at any time. The single full backup of a routine which
+// keeps no full backups is not moved, as it is overwritten in place.
+// The backups are moved while no full backup runs and no backup is deleted,
+// the remaining ones are moved by the next run otherwise.
+func (b *BackupBackend) moveToTier(ctx context.Context, before time.Time) (int, error) {
+	bounds := model.NewTimeBoundsTo(before)
+	var moved int
+	for _, isFullBackup := range []bool{true, false} {
+		if isFullBackup && b.removeFullBackup {
+			continue
+		}
+
+		backups, err := b.readStorageMetadataList(ctx, bounds, isFullBackup)
+		if err != nil {
+			return moved, fmt.Errorf("cannot read backup list: %w", err)
+		}
+
+		for _, created := range backupTimes(backups) {
+			ok, err := b.moveBackupToTier(ctx, created, isFullBackup)
+			if errors.Is(err, ErrFullBackupInProgress) {
+				slog.Debug("Full backup is in progress, postponing tiering",
+					slog.String("path", b.fullBackupsPath))
+				return moved, nil
+			}
+			if err != nil {
+				return moved, err
+			}
+			if ok {
+				moved++
+			}
+		}
+	}
+
+	return moved, nil
+}
+
+// moveBackupToTier copies the folder of the namespace backups created at the
+// given time to the tiering storage, adds them to its catalog and deletes them
+// from the storage. The replicas of the backup are kept.
+// The deletion is postponed to the next run while a restore reads the backup,
+// the backup is listed from the storage until then.
+// It returns true if the backup was moved.
+func (b *BackupBackend) moveBackupToTier(ctx context.Context, created time.Time, isFullBackup bool,
+) (bool, error) {
+	// hold the flag, so that the backup is not deleted or overwritten while moving
+	if !b.fullBackupInProgress.CompareAndSwap(false, true) {
+		return false, ErrFullBackupInProgress
+	}
+	defer b.fullBackupInProgress.Store(false)
+
+	bounds, _ := model.NewTimeBounds(&created, &created)
+	backups, err := b.readStorageMetadataList(ctx, bounds, isFullBackup)
+	if err != nil {
+		return false, fmt.Errorf("cannot read backup list: %w", err)
+	}
+	if len(backups) == 0 {
+		return false, nil // deleted since listed
+	}
+
+	path := getIncrementalPath(b.incrementalBackupsPath, created)
+	if isFullBackup {
+		path = getFullBackupFolder(b.fullBackupsPath, created, false)
+	}
+
+	if _, err := copyBackupFolder(ctx, b.storage, b.tier.storage, path); err != nil {
+		return false, fmt.Errorf("cannot copy backup %s to tiering storage: %w", path, err)
+	}
+	for i := range backups {
+		b.tier.addToCatalog(ctx, backups[i].Key, backups[i].BackupMetadata)
+	}
+
+	folders := storageFolders(b.storage)
+	folders.Lock()
+	defer folders.Unlock()
+	if folders.isInUse(path) {
+		slog.Debug("Backup is being restored, postponing its deletion",
+			slog.String("path", path))
+		return false, nil
+	}
+
+	if err := storage.DeleteFolder(ctx, b.storage, path); err != nil {
+		return false, fmt.Errorf("cannot delete moved backup %s: %w", path, err)
+	}
+	b.removeFromCatalog(ctx, path)
+
+	return true, nil
+}
+
+// backupsCreatedAt returns the namespace backups created at the given time.
+func backupsCreatedAt(backups []model.BackupDetails, created time.Time) []model.BackupDetails {
+	var result []model.BackupDetails
+	for i := range backups {
+		if backups[i].Created.Equal(created) {
+			result = append(result, backups[i])
+		}
+	}
+
+	return result
+}
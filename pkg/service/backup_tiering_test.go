@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveAgedBackups(t *testing.T) {
+	ctx := context.Background()
+	tierStorage := &model.LocalStorage{Path: t.TempDir()}
+	routine := &model.BackupRoutine{
+		BackupPolicy: &model.BackupPolicy{},
+		Storage:      &model.LocalStorage{Path: t.TempDir()},
+		Tiering:      &model.TieringPolicy{After: time.Hour, MoveTo: tierStorage},
+	}
+	backend := newBackend("routine", routine)
+	handler := &BackupRoutineHandler{
+		backend:       backend,
+		storage:       routine.Storage,
+		routineName:   "routine",
+		backupRoutine: routine,
+	}
+
+	now := time.UnixMilli(100).Add(routine.Tiering.After)
+	for _, created := range []int64{10, 50} {
+		path := getFullPath(backend.fullBackupsPath, routine.BackupPolicy, "ns1", time.UnixMilli(created))
+		require.NoError(t, backend.writeBackupMetadata(ctx, path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "ns1"}))
+	}
+	for _, created := range []int64{20, 120} {
+		path := getIncrementalPathForNamespace(backend.incrementalBackupsPath, "ns1", time.UnixMilli(created))
+		require.NoError(t, backend.writeBackupMetadata(ctx, path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "ns1"}))
+	}
+
+	require.NoError(t, handler.moveAgedBackups(ctx, now))
+
+	// the aged backups are listed from the tiering storage
+	all := model.NewTimeBoundsTo(now)
+	full, err := backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 50}, unixMillis(backupTimes(full)))
+	require.Equal(t, tierStorage, full[0].Storage)
+	incremental, err := backend.IncrementalBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{20, 120}, unixMillis(backupTimes(incremental)))
+	require.Equal(t, tierStorage, incremental[0].Storage)
+	require.Equal(t, routine.Storage, incremental[1].Storage)
+
+	files, err := storage.ListFiles(ctx, routine.Storage, getFullBackupFolder(backend.fullBackupsPath,
+		time.UnixMilli(10), false))
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	lastFull, err := backend.FindLastFullBackup(time.UnixMilli(30))
+	require.NoError(t, err)
+	require.Equal(t, tierStorage, lastFull[0].Storage)
+
+	// the backups are deleted from the tiering storage
+	require.NoError(t, backend.DeleteFullBackup(ctx, time.UnixMilli(10), true))
+	full, err = backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{50}, unixMillis(backupTimes(full)))
+	incremental, err = backend.IncrementalBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{120}, unixMillis(backupTimes(incremental)))
+}
+
+func TestMoveAgedBackups_Postponed(t *testing.T) {
+	ctx := context.Background()
+	tierStorage := &model.LocalStorage{Path: t.TempDir()}
+	routine := &model.BackupRoutine{
+		BackupPolicy: &model.BackupPolicy{},
+		Storage:      &model.LocalStorage{Path: t.TempDir()},
+		Tiering:      &model.TieringPolicy{After: time.Hour, MoveTo: tierStorage},
+	}
+	backend := newBackend("routine", routine)
+	handler := &BackupRoutineHandler{
+		backend:       backend,
+		storage:       routine.Storage,
+		routineName:   "routine",
+		backupRoutine: routine,
+	}
+
+	now := time.UnixMilli(100).Add(routine.Tiering.After)
+	var keys []string
+	for _, created := range []int64{10, 50} {
+		path := getFullPath(backend.fullBackupsPath, routine.BackupPolicy, "ns1", time.UnixMilli(created))
+		require.NoError(t, backend.writeBackupMetadata(ctx, path,
+			model.BackupMetadata{Created: time.UnixMilli(created), Namespace: "ns1"}))
+		keys = append(keys, path)
+	}
+	all := model.NewTimeBoundsTo(now)
+
+	// nothing is moved while a full backup runs or a backup is deleted
+	require.True(t, backend.fullBackupInProgress.CompareAndSwap(false, true))
+	require.NoError(t, handler.moveAgedBackups(ctx, now))
+	backend.fullBackupInProgress.Store(false)
+	full, err := backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, routine.Storage, full[0].Storage)
+	require.Equal(t, routine.Storage, full[1].Storage)
+
+	// the restored backup is kept in the storage until the restore finishes
+	release := useBackupFolder(routine.Storage, keys[0])
+	require.NoError(t, handler.moveAgedBackups(ctx, now))
+	full, err = backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 50}, unixMillis(backupTimes(full)))
+	require.Equal(t, routine.Storage, full[0].Storage)
+	require.Equal(t, tierStorage, full[1].Storage)
+	require.True(t, backupReadable(ctx, routine.Storage, keys[0]))
+
+	release()
+	require.NoError(t, handler.moveAgedBackups(ctx, now))
+	full, err = backend.FullBackupList(ctx, all)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 50}, unixMillis(backupTimes(full)))
+	require.Equal(t, tierStorage, full[0].Storage)
+	require.False(t, backupReadable(ctx, routine.Storage, keys[0]))
+}
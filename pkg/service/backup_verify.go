@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/service/storage"
+	"github.com/aerospike/backup-go"
+	"github.com/aerospike/backup-go/io/encoding/asb"
+	"github.com/aerospike/backup-go/io/encryption"
+	"github.com/aerospike/backup-go/models"
+	"github.com/klauspost/compress/zstd"
+	"github.com/reugn/go-quartz/quartz"
+)
+
+// ErrVerificationInProgress is returned on attempt to verify a backup
+// which verification is running.
+var ErrVerificationInProgress = errors.New("backup verification is in progress")
+
+const jobTypeVerify jobType = "verify"
+
+var runningVerifications = newRunningTasks()
+
+func verificationKey(routineName string, created time.Time) string {
+	return fmt.Sprintf("%s-%d", routineName, created.UnixMilli())
+}
+
+// verifyJob implements the quartz.Job interface.
+// It verifies the backup created at the given time, or the latest full
+// backup of the routine if the time is not set.
+type verifyJob struct {
+	handler *BackupRoutineHandler
+	created *time.Time
+}
+
+var _ quartz.Job = (*verifyJob)(nil)
+
+// Execute is called by a Scheduler when the Trigger associated with this job fires.
+func (j *verifyJob) Execute(ctx context.Context) error {
+	if err := j.handler.verifyBackup(ctx, j.created); err != nil {
+		slog.Error("Backup verification failed",
+			slog.String("routine", j.handler.routineName),
+			slog.Any("err", err))
+	}
+
+	return nil
+}
+
+// Description returns the description of the verify job.
+func (j *verifyJob) Description() string {
+	return fmt.Sprintf("%s %s job", j.handler.routineName, jobTypeVerify)
+}
+
+func verifyJobKey(routineName string) *quartz.JobKey {
+	jobName := fmt.Sprintf("%s-%s", routineName, jobTypeVerify)
+	return quartz.NewJobKeyWithGroup(jobName, string(quartzGroupScheduled))
+}
+
+func adhocVerifyKey(routineName string, created time.Time) *quartz.JobKey {
+	jobName := fmt.Sprintf("%s-adhoc-%s-%d", routineName, jobTypeVerify, created.UnixMilli())
+	return quartz.NewJobKeyWithGroup(jobName, string(quartzGroupAdHoc))
+}
+
+func scheduleVerification(
+	scheduler quartz.Scheduler, handler *BackupRoutineHandler, interval string, location *time.Location,
+	routineName string,
+) error {
+	verifyCronTrigger, err := quartz.NewCronTriggerWithLoc(interval, location)
+	if err != nil {
+		return err
+	}
+
+	verifyJobDetail := quartz.NewJobDetail(&verifyJob{handler: handler}, verifyJobKey(routineName))
+
+	return scheduler.ScheduleJob(verifyJobDetail, verifyCronTrigger)
+}
+
+// NewAdHocVerifyJob returns a job verifying the backup of the routine created
+// at the given time, or the latest full backup if the time is nil, and the
+// creation time of the backup to query the verification results with.
+func (h *BackupRoutineHandler) NewAdHocVerifyJob(ctx context.Context, created *time.Time,
+) (*quartz.JobDetail, time.Time, error) {
+	backups, err := h.backupsToVerify(ctx, created)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	backupTime := backups[0].Created
+	if runningVerifications.isRunning(verificationKey(h.routineName, backupTime)) {
+		return nil, time.Time{}, ErrVerificationInProgress
+	}
+
+	job := &verifyJob{handler: h, created: &backupTime}
+	return quartz.NewJobDetail(job, adhocVerifyKey(h.routineName, backupTime)), backupTime, nil
+}
+
+// GetBackupVerification returns the verification results of the backup
+// created at the given time.
+func (h *BackupRoutineHandler) GetBackupVerification(ctx context.Context, created time.Time,
+) (*model.BackupVerification, error) {
+	results, err := h.backend.ReadVerificationResults(ctx, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BackupVerification{
+		Running: runningVerifications.isRunning(verificationKey(h.routineName, created)),
+		Results: results,
+	}, nil
+}
+
+// backupsToVerify returns the namespace backups created at the given time,
+// or of the latest full backup if the time is nil.
+func (h *BackupRoutineHandler) backupsToVerify(ctx context.Context, created *time.Time,
+) ([]model.BackupDetails, error) {
+	if created == nil {
+		return h.backend.FindLastFullBackup(time.Now())
+	}
+
+	return h.backend.findBackup(ctx, *created)
+}
+
+// verifyBackup reads back the namespace backups and stores the verification
+// result of each next to its metadata.
+func (h *BackupRoutineHandler) verifyBackup(ctx context.Context, created *time.Time) error {
+	backups, err := h.backupsToVerify(ctx, created)
+	if err != nil {
+		return err
+	}
+
+	// the single full backup is overwritten by a running full backup
+	if h.backend.removeFullBackup && h.backend.fullBackupInProgress.Load() {
+		return ErrFullBackupInProgress
+	}
+
+	key := verificationKey(h.routineName, backups[0].Created)
+	if !runningVerifications.start(key) {
+		return ErrVerificationInProgress
+	}
+	defer runningVerifications.finish(key)
+
+	logger := slog.Default().With(slog.String("routine", h.routineName),
+		slog.Time("created", backups[0].Created))
+	logger.Info("Verify backup")
+
+	for i := range backups {
+		result := verifyNamespaceBackup(ctx, &backups[i], h.backupFullPolicy, h.secretAgent)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		verificationCounter.WithLabelValues(h.routineName, string(result.Status)).Inc()
+		if result.Status == model.VerificationFailed {
+			logger.Warn("Backup verification failed",
+				slog.String("namespace", result.Namespace),
+				slog.String("reason", result.Error))
+		}
+
+		if err := h.backend.writeVerificationResult(ctx, &backups[i], result); err != nil {
+			return fmt.Errorf("cannot write verification result of namespace %s: %w",
+				result.Namespace, err)
+		}
+	}
+
+	logger.Info("Backup verification finished")
+	return nil
+}
+
+// verifyNamespaceBackup verifies the checksums of the backup files of the
+// namespace, reads them and compares the number of records and bytes read
+// with the metadata file of the backup. The files are decompressed and
+// decrypted with the modes of the backup metadata, the policy provides the
+// encryption key only, and the modes of the backups created by older versions.
+func verifyNamespaceBackup(ctx context.Context, details *model.BackupDetails,
+	policy *model.BackupPolicy, secretAgent *model.SecretAgent) *model.VerificationResult {
+	result := &model.VerificationResult{
+		Namespace:           details.Namespace,
+		Created:             details.Created,
+		ExpectedRecordCount: details.RecordCount,
+		ExpectedByteCount:   details.ByteCount,
+	}
+
+	var stats backupFileStats
+	metadata, err := readBackupMetadata(ctx, details.Storage, details.Key)
+	if err == nil {
+		result.ExpectedRecordCount = metadata.RecordCount
+		result.ExpectedByteCount = metadata.ByteCount
+		// the backups created by older versions have no checksums
+		if metadata.Checksum != "" {
+			err = verifyMetadataChecksums(ctx, details.Storage, details.Key, metadata)
+		}
+	}
+	if err == nil {
+		stats, err = readBackupFiles(ctx, details.Storage, details.Key,
+			backupReadModes(metadata, policy), secretAgent)
+	}
+	result.Verified = time.Now()
+	result.RecordCount = stats.records
+	result.ByteCount = stats.bytes
+	result.FileCount = stats.files
+
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case stats.records != result.ExpectedRecordCount:
+		result.Error = fmt.Sprintf("record count mismatch: expected %d, read %d",
+			result.ExpectedRecordCount, stats.records)
+	case stats.bytes != result.ExpectedByteCount:
+		result.Error = fmt.Sprintf("byte count mismatch: expected %d, read %d",
+			result.ExpectedByteCount, stats.bytes)
+	}
+
+	result.Status = model.VerificationPassed
+	if result.Error != "" {
+		result.Status = model.VerificationFailed
+	}
+
+	return result
+}
+
+// backupReadModes returns the compression and encryption of the backup files.
+// The modes are taken from the metadata, the policy is used for the key
+// material, and for the modes missing in the metadata of older backups.
+func backupReadModes(metadata *model.BackupMetadata, policy *model.BackupPolicy) *model.BackupPolicy {
+	compression := metadata.Compression
+	if compression == "" {
+		compression = compressionMode(policy.CompressionPolicy)
+	}
+	encryption := metadata.Encryption
+	if encryption == "" {
+		encryption = encryptionMode(policy.EncryptionPolicy)
+	}
+
+	modes := &model.BackupPolicy{
+		CompressionPolicy: &model.CompressionPolicy{Mode: compression},
+	}
+	if encryption != model.EncryptNone {
+		modes.EncryptionPolicy = &model.EncryptionPolicy{Mode: encryption}
+		if policy.EncryptionPolicy != nil {
+			modes.EncryptionPolicy.KeyFile = policy.EncryptionPolicy.KeyFile
+			modes.EncryptionPolicy.KeyEnv = policy.EncryptionPolicy.KeyEnv
+			modes.EncryptionPolicy.KeySecret = policy.EncryptionPolicy.KeySecret
+		}
+	}
+
+	return modes
+}
+
+type backupFileStats struct {
+	records uint64
+	// the bytes read from the storage, i.e. compressed and encrypted
+	bytes uint64
+	files uint64
+}
+
+// readBackupFiles reads the backup files under the path, decrypting and
+// decompressing them with the backup policy.
+func readBackupFiles(ctx context.Context, s model.Storage, path string,
+	policy *model.BackupPolicy, secretAgent *model.SecretAgent) (backupFileStats, error) {
+	var stats backupFileStats
+
+	key, err := readEncryptionKey(policy.EncryptionPolicy, secretAgent)
+	if err != nil {
+		return stats, err
+	}
+	compressed := policy.CompressionPolicy != nil && policy.CompressionPolicy.Mode != backup.CompressNone
+
+	reader, err := storage.CreateReader(ctx, s, path, false, asb.NewValidator(), "")
+	if err != nil {
+		return stats, fmt.Errorf("failed to create backup reader: %w", err)
+	}
+
+	readersCh := make(chan io.ReadCloser, 1)
+	errorsCh := make(chan error, 1)
+	go reader.StreamFiles(ctx, readersCh, errorsCh)
+
+	for {
+		select {
+		case err := <-errorsCh:
+			if errors.Is(err, io.EOF) || strings.Contains(err.Error(), "is empty") {
+				return stats, nil
+			}
+			return stats, err
+		case r, ok := <-readersCh:
+			if !ok {
+				return stats, nil
+			}
+			counter := &countingReader{ReadCloser: r}
+			records, err := countRecords(counter, key, compressed)
+			stats.bytes += counter.count
+			stats.records += records
+			stats.files++
+			if err != nil {
+				return stats, fmt.Errorf("failed to read backup file %d: %w", stats.files, err)
+			}
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		}
+	}
+}
+
+// readEncryptionKey returns the key to decrypt the backup files,
+// nil if they are not encrypted.
+func readEncryptionKey(policy *model.EncryptionPolicy, secretAgent *model.SecretAgent) ([]byte, error) {
+	if policy == nil || policy.Mode == model.EncryptNone {
+		return nil, nil
+	}
+
+	key, err := backup.ReadPrivateKey(makeEncryptionPolicy(policy), makeSecretAgentConfig(secretAgent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key: %w", err)
+	}
+
+	return key, nil
+}
+
+// countRecords decodes the backup file and returns the number of records in it.
+// The reader is closed.
+func countRecords(r io.ReadCloser, key []byte, compressed bool) (uint64, error) {
+	defer r.Close()
+
+	var err error
+	if key != nil {
+		if r, err = encryption.NewEncryptedReader(r, key); err != nil {
+			return 0, err
+		}
+	}
+	if compressed {
+		zstdDecoder, err := zstd.NewReader(r)
+		if err != nil {
+			return 0, err
+		}
+		defer zstdDecoder.Close()
+		r = zstdDecoder.IOReadCloser()
+	}
+
+	decoder, err := asb.NewDecoder(r)
+	if err != nil {
+		return 0, err
+	}
+
+	var records uint64
+	for {
+		token, err := decoder.NextToken()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		if token.Type == models.TokenTypeRecord {
+			records++
+		}
+	}
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	io.ReadCloser
+	count uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.count += uint64(n)
+	return n, err
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	a "github.com/aerospike/aerospike-client-go/v7"
+	"github.com/aerospike/backup-go/io/encoding/asb"
+	"github.com/aerospike/backup-go/models"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// writeBackupFile writes a backup file with the given number of records,
+// compressed if set, and returns its size.
+func writeBackupFile(t *testing.T, path string, records int, compressed bool) uint64 {
+	t.Helper()
+	encoder := asb.NewEncoder("test", false)
+	data := bytes.NewBuffer(encoder.GetHeader())
+	for i := range records {
+		key, aerr := a.NewKey("test", "set", i)
+		require.NoError(t, aerr)
+		record := &models.Record{Record: &a.Record{Key: key, Bins: a.BinMap{"bin": i}, Generation: 1}}
+		token, err := encoder.EncodeToken(models.NewRecordToken(record, 0))
+		require.NoError(t, err)
+		data.Write(token)
+	}
+
+	content := data.Bytes()
+	if compressed {
+		zstdEncoder, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		content = zstdEncoder.EncodeAll(content, nil)
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0744))
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	return uint64(len(content))
+}
+
+// writeMetadataFile writes the metadata file of the backup under the root.
+func writeMetadataFile(t *testing.T, root, path string, metadata model.BackupMetadata) {
+	t.Helper()
+	data, err := yaml.Marshal(metadata)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, path), 0744))
+	require.NoError(t, os.WriteFile(filepath.Join(root, path, metadataFile), data, 0600))
+}
+
+func TestVerifyNamespaceBackup(t *testing.T) {
+	tests := []struct {
+		name       string
+		compressed bool
+		records    uint64
+		bytesDiff  uint64
+		status     model.VerificationStatus
+		err        string
+	}{
+		{name: "passed", records: 5, status: model.VerificationPassed},
+		{name: "compressed", compressed: true, records: 5, status: model.VerificationPassed},
+		{name: "record count mismatch", records: 6, status: model.VerificationFailed,
+			err: "record count mismatch: expected 6, read 5"},
+		{name: "byte count mismatch", records: 5, bytesDiff: 1, status: model.VerificationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			size := writeBackupFile(t, filepath.Join(root, "data/test/test_0.asb"), 5, tt.compressed)
+			// the compression is read from the backup metadata, not the policy
+			compression := "NONE"
+			if tt.compressed {
+				compression = "ZSTD"
+			}
+			metadata := model.BackupMetadata{
+				Namespace:   "test",
+				RecordCount: tt.records,
+				ByteCount:   size + tt.bytesDiff,
+				Compression: compression,
+				Encryption:  model.EncryptNone,
+			}
+			writeMetadataFile(t, root, "data/test", metadata)
+			details := &model.BackupDetails{
+				BackupMetadata: metadata,
+				Key:            "data/test",
+				Storage:        &model.LocalStorage{Path: root},
+			}
+
+			result := verifyNamespaceBackup(context.Background(), details, &model.BackupPolicy{}, nil)
+
+			require.Equal(t, tt.status, result.Status)
+			require.Equal(t, uint64(5), result.RecordCount)
+			require.Equal(t, size, result.ByteCount)
+			require.Equal(t, uint64(1), result.FileCount)
+			if tt.err != "" {
+				require.Equal(t, tt.err, result.Error)
+			}
+		})
+	}
+}
+
+func TestVerifyNamespaceBackup_Checksums(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "data/test/test_0.asb")
+	size := writeBackupFile(t, path, 5, false)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	metadata := model.BackupMetadata{
+		Namespace:   "test",
+		RecordCount: 5,
+		ByteCount:   size,
+		FileCount:   1,
+		Files:       []model.BackupFile{{Path: "test_0.asb", Size: size, SHA256: hex.EncodeToString(sum[:])}},
+	}
+	metadata.Checksum, err = metadata.ComputeChecksum()
+	require.NoError(t, err)
+	writeMetadataFile(t, root, "data/test", metadata)
+
+	// the listed metadata has no file manifest, the checksums are verified
+	// against the metadata file
+	details := &model.BackupDetails{
+		BackupMetadata: catalogMetadata(metadata),
+		Key:            "data/test",
+		Storage:        &model.LocalStorage{Path: root},
+	}
+	result := verifyNamespaceBackup(context.Background(), details, &model.BackupPolicy{}, nil)
+	require.Equal(t, model.VerificationPassed, result.Status, result.Error)
+
+	writeBackupFile(t, path, 4, false)
+	result = verifyNamespaceBackup(context.Background(), details, &model.BackupPolicy{}, nil)
+	require.Equal(t, model.VerificationFailed, result.Status)
+	require.Contains(t, result.Error, model.ErrChecksumMismatch.Error())
+}
+
+func TestVerifyNamespaceBackup_Corrupt(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "data/test/test_0.asb")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0744))
+	require.NoError(t, os.WriteFile(path, []byte("not a backup"), 0600))
+	writeMetadataFile(t, root, "data/test", model.BackupMetadata{Namespace: "test"})
+	details := &model.BackupDetails{
+		BackupMetadata: model.BackupMetadata{Namespace: "test"},
+		Key:            "data/test",
+		Storage:        &model.LocalStorage{Path: root},
+	}
+
+	result := verifyNamespaceBackup(context.Background(), details, &model.BackupPolicy{}, nil)
+
+	require.Equal(t, model.VerificationFailed, result.Status)
+	require.NotEmpty(t, result.Error)
+}
+
+func TestVerifyNamespaceBackup_MissingMetadata(t *testing.T) {
+	root := t.TempDir()
+	writeBackupFile(t, filepath.Join(root, "data/test/test_0.asb"), 5, false)
+	details := &model.BackupDetails{
+		BackupMetadata: model.BackupMetadata{Namespace: "test", RecordCount: 5},
+		Key:            "data/test",
+		Storage:        &model.LocalStorage{Path: root},
+	}
+
+	result := verifyNamespaceBackup(context.Background(), details, &model.BackupPolicy{}, nil)
+
+	require.Equal(t, model.VerificationFailed, result.Status)
+	require.Contains(t, result.Error, "cannot read backup metadata")
+}
+
+func TestVerifyBackup(t *testing.T) {
+	root := t.TempDir()
+	backend := &BackupBackend{
+		storage:                &model.LocalStorage{Path: root},
+		fullBackupsPath:        "routine/backup",
+		incrementalBackupsPath: "routine/incremental",
+		fullBackupInProgress:   &atomic.Bool{},
+	}
+	handler := &BackupRoutineHandler{
+		backend:          backend,
+		backupFullPolicy: &model.BackupPolicy{},
+		routineName:      "routine",
+	}
+	ctx := context.Background()
+
+	created := time.UnixMilli(time.Now().UnixMilli())
+	path := getFullPath(backend.fullBackupsPath, handler.backupFullPolicy, "test", created)
+	size := writeBackupFile(t, filepath.Join(root, path, "test_0.asb"), 3, false)
+	require.NoError(t, backend.writeBackupMetadata(ctx, path, model.BackupMetadata{
+		Created:     created,
+		Namespace:   "test",
+		RecordCount: 3,
+		ByteCount:   size,
+	}))
+
+	// not verified yet
+	verification, err := handler.GetBackupVerification(ctx, created)
+	require.NoError(t, err)
+	require.Empty(t, verification.Results)
+
+	// the latest full backup is verified
+	require.NoError(t, handler.verifyBackup(ctx, nil))
+
+	verification, err = handler.GetBackupVerification(ctx, created)
+	require.NoError(t, err)
+	require.False(t, verification.Running)
+	require.Len(t, verification.Results, 1)
+	require.Equal(t, model.VerificationPassed, verification.Results[0].Status)
+	require.Equal(t, uint64(3), verification.Results[0].RecordCount)
+	require.True(t, verification.Results[0].Created.Equal(created))
+
+	// the verification result is not listed as a backup
+	backups, err := backend.FullBackupList(ctx, model.NewTimeBoundsTo(time.Now()))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	_, err = handler.GetBackupVerification(ctx, created.Add(time.Second))
+	require.ErrorIs(t, err, ErrBackupNotFound)
+
+	_, _, err = handler.NewAdHocVerifyJob(ctx, util.Ptr(created.Add(time.Second)))
+	require.ErrorIs(t, err, ErrBackupNotFound)
+
+	runningVerifications.start(verificationKey("routine", created))
+	defer runningVerifications.finish(verificationKey("routine", created))
+	_, _, err = handler.NewAdHocVerifyJob(ctx, nil)
+	require.ErrorIs(t, err, ErrVerificationInProgress)
+	require.ErrorIs(t, handler.verifyBackup(ctx, &created), ErrVerificationInProgress)
+}
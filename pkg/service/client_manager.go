@@ -14,6 +14,9 @@ import (
 type ClientManager interface {
 	// GetClient returns a backup client by aerospike cluster name (new or cached).
 	GetClient(*model.AerospikeCluster) (*backup.Client, error)
+	// GetRackAwareClient returns a backup client (new or cached) which prefers
+	// to read from the given racks.
+	GetRackAwareClient(cluster *model.AerospikeCluster, rackIDs []int) (*backup.Client, error)
 	// Close ensures that the specified backup client is closed.
 	Close(*backup.Client)
 }
@@ -37,8 +40,17 @@ func (f *DefaultClientFactory) NewClientWithPolicyAndHost(
 // Is responsible for creating and closing backup clients.
 type ClientManagerImpl struct {
 	mu            sync.Mutex
-	clients       map[*model.AerospikeCluster]*clientInfo
+	clients       map[clientKey]*clientInfo
 	clientFactory AerospikeClientFactory
+	// scan limiters shared by all the clients of the cluster
+	scanLimiters map[*model.AerospikeCluster]*semaphore.Weighted
+}
+
+// clientKey identifies a cached client: rack aware clients are cached
+// separately for every list of preferred racks.
+type clientKey struct {
+	cluster *model.AerospikeCluster
+	racks   string
 }
 
 type clientInfo struct {
@@ -49,27 +61,44 @@ type clientInfo struct {
 // NewClientManager creates a new ClientManagerImpl.
 func NewClientManager(aerospikeClientFactory AerospikeClientFactory) *ClientManagerImpl {
 	return &ClientManagerImpl{
-		clients:       make(map[*model.AerospikeCluster]*clientInfo),
+		clients:       make(map[clientKey]*clientInfo),
 		clientFactory: aerospikeClientFactory,
+		scanLimiters:  make(map[*model.AerospikeCluster]*semaphore.Weighted),
 	}
 }
 
 // GetClient returns a backup client by aerospike cluster name (new or cached).
 func (cm *ClientManagerImpl) GetClient(cluster *model.AerospikeCluster) (*backup.Client, error) {
+	return cm.getClient(cluster, nil)
+}
+
+// GetRackAwareClient returns a backup client (new or cached) which prefers
+// to read from the given racks, in order of preference.
+func (cm *ClientManagerImpl) GetRackAwareClient(cluster *model.AerospikeCluster, rackIDs []int,
+) (*backup.Client, error) {
+	return cm.getClient(cluster, rackIDs)
+}
+
+func (cm *ClientManagerImpl) getClient(cluster *model.AerospikeCluster, rackIDs []int) (*backup.Client, error) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	if info, exists := cm.clients[cluster]; exists {
+	key := clientKey{cluster: cluster}
+	if len(rackIDs) > 0 {
+		key.racks = fmt.Sprint(rackIDs)
+	}
+
+	if info, exists := cm.clients[key]; exists {
 		info.count++
 		return info.client, nil
 	}
 
-	client, err := cm.createClient(cluster)
+	client, err := cm.createClient(cluster, rackIDs)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create backup client: %w", err)
 	}
 
-	cm.clients[cluster] = &clientInfo{
+	cm.clients[key] = &clientInfo{
 		client: client,
 		count:  1,
 	}
@@ -78,17 +107,22 @@ func (cm *ClientManagerImpl) GetClient(cluster *model.AerospikeCluster) (*backup
 }
 
 // createClient creates a new backup client given the aerospike cluster configuration.
-func (cm *ClientManagerImpl) createClient(cluster *model.AerospikeCluster) (*backup.Client, error) {
-	aeroClient, err := cm.clientFactory.NewClientWithPolicyAndHost(cluster.ASClientPolicy(),
-		cluster.ASClientHosts()...)
+func (cm *ClientManagerImpl) createClient(cluster *model.AerospikeCluster, rackIDs []int,
+) (*backup.Client, error) {
+	policy := cluster.ASClientPolicy()
+	if len(rackIDs) > 0 {
+		policy.RackAware = true
+		policy.RackIds = rackIDs
+	}
+
+	aeroClient, err := cm.clientFactory.NewClientWithPolicyAndHost(policy, cluster.ASClientHosts()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to aerospike cluster, %w", err)
 	}
 
 	var options []backup.ClientOpt
 	if cluster.MaxParallelScans != nil {
-		options = append(options, backup.WithScanLimiter(
-			semaphore.NewWeighted(int64(*cluster.MaxParallelScans))))
+		options = append(options, backup.WithScanLimiter(cm.scanLimiter(cluster)))
 	}
 	if cluster.ClusterLabel != nil {
 		options = append(options, backup.WithID(*cluster.ClusterLabel))
@@ -97,6 +131,19 @@ func (cm *ClientManagerImpl) createClient(cluster *model.AerospikeCluster) (*bac
 	return backup.NewClient(aeroClient, options...)
 }
 
+// scanLimiter returns the scan limiter of the cluster, so that the
+// max parallel scans limit is shared by the plain and rack aware clients.
+// Must be called with the mutex held.
+func (cm *ClientManagerImpl) scanLimiter(cluster *model.AerospikeCluster) *semaphore.Weighted {
+	limiter, ok := cm.scanLimiters[cluster]
+	if !ok {
+		limiter = semaphore.NewWeighted(int64(*cluster.MaxParallelScans))
+		cm.scanLimiters[cluster] = limiter
+	}
+
+	return limiter
+}
+
 // Close ensures that the specified backup client is closed.
 func (cm *ClientManagerImpl) Close(client *backup.Client) {
 	cm.mu.Lock()
@@ -108,6 +155,7 @@ func (cm *ClientManagerImpl) Close(client *backup.Client) {
 			if info.count == 0 {
 				info.client.AerospikeClient().Close()
 				delete(cm.clients, id)
+				cm.releaseScanLimiter(id.cluster)
 			}
 			return
 		}
@@ -116,3 +164,15 @@ func (cm *ClientManagerImpl) Close(client *backup.Client) {
 	// close client even it was not found
 	client.AerospikeClient().Close()
 }
+
+// releaseScanLimiter removes the scan limiter of the cluster once it has
+// no clients left. Must be called with the mutex held.
+func (cm *ClientManagerImpl) releaseScanLimiter(cluster *model.AerospikeCluster) {
+	for id := range cm.clients {
+		if id.cluster == cluster {
+			return
+		}
+	}
+
+	delete(cm.scanLimiters, cluster)
+}
@@ -49,12 +49,56 @@ func Test_GetClient(t *testing.T) {
 	assert.Equal(t, client, client2)
 }
 
+func Test_GetRackAwareClient(t *testing.T) {
+	clientManager := NewClientManager(
+		&MockClientFactory{},
+	)
+
+	client, err := clientManager.GetClient(cluster)
+	assert.NoError(t, err)
+
+	// Rack aware client is cached separately
+	rackAwareClient, err := clientManager.GetRackAwareClient(cluster, []int{1, 2})
+	assert.NoError(t, err)
+	assert.NotSame(t, client, rackAwareClient)
+
+	rackAwareClient2, err := clientManager.GetRackAwareClient(cluster, []int{1, 2})
+	assert.NoError(t, err)
+	assert.Same(t, rackAwareClient, rackAwareClient2)
+
+	otherRacksClient, err := clientManager.GetRackAwareClient(cluster, []int{2})
+	assert.NoError(t, err)
+	assert.NotSame(t, rackAwareClient, otherRacksClient)
+}
+
+func Test_ScanLimiterSharedByCluster(t *testing.T) {
+	clientManager := NewClientManager(
+		&MockClientFactory{},
+	)
+	limitedCluster := &model.AerospikeCluster{MaxParallelScans: ptr.Int(2)}
+
+	client, err := clientManager.GetClient(limitedCluster)
+	assert.NoError(t, err)
+	limiter := clientManager.scanLimiters[limitedCluster]
+	assert.NotNil(t, limiter)
+
+	rackAwareClient, err := clientManager.GetRackAwareClient(limitedCluster, []int{1})
+	assert.NoError(t, err)
+	assert.Len(t, clientManager.scanLimiters, 1)
+	assert.Same(t, limiter, clientManager.scanLimiters[limitedCluster])
+
+	clientManager.Close(client)
+	assert.Len(t, clientManager.scanLimiters, 1)
+	clientManager.Close(rackAwareClient)
+	assert.Empty(t, clientManager.scanLimiters)
+}
+
 func Test_CreateClient(t *testing.T) {
 	clientManager := NewClientManager(
 		&MockClientFactory{},
 	)
 
-	client, err := clientManager.createClient(&model.AerospikeCluster{})
+	client, err := clientManager.createClient(&model.AerospikeCluster{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, client)
 }
@@ -67,7 +111,7 @@ func Test_CreateClient_Errors(t *testing.T) {
 		mockClientFactory,
 	)
 
-	client, err := clientManager.createClient(aeroCluster)
+	client, err := clientManager.createClient(aeroCluster, nil)
 	assert.Nil(t, client)
 	assert.ErrorContains(t, err, "failed to connect to aerospike")
 }
@@ -84,7 +128,7 @@ func Test_Close(t *testing.T) {
 	clientManager.Close(client)
 
 	// Verify that client is removed from clients map
-	_, exists := clientManager.clients[cluster]
+	_, exists := clientManager.clients[clientKey{cluster: cluster}]
 	assert.False(t, exists)
 }
 
@@ -102,12 +146,12 @@ func Test_Close_Multiple(t *testing.T) {
 
 	clientManager.Close(client)
 
-	_, exists := clientManager.clients[cluster]
+	_, exists := clientManager.clients[clientKey{cluster: cluster}]
 	assert.True(t, exists)
 
 	clientManager.Close(client)
 
-	_, exists = clientManager.clients[cluster]
+	_, exists = clientManager.clients[clientKey{cluster: cluster}]
 	assert.False(t, exists)
 }
 
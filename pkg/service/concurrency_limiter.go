@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// backupLimiter limits the number of concurrently running backup jobs
+// across all routines.
+var backupLimiter = newConcurrencyLimiter()
+
+// concurrencyLimit is the maximum number of concurrent jobs sharing the key.
+type concurrencyLimit struct {
+	key string
+	max int
+}
+
+// concurrencyLimiter admits jobs when all their limits allow it.
+// Waiting jobs are admitted in the order of arrival per limit: a job is not
+// admitted ahead of an earlier waiting job on a limit that has no capacity
+// left, while the jobs that do not need the saturated limits are admitted.
+type concurrencyLimiter struct {
+	mu      sync.Mutex
+	running map[string]int
+	queue   []*limiterWaiter
+}
+
+type limiterWaiter struct {
+	limits []concurrencyLimit
+	ready  chan struct{}
+}
+
+func newConcurrencyLimiter() *concurrencyLimiter {
+	return &concurrencyLimiter{
+		running: make(map[string]int),
+	}
+}
+
+// acquire waits until the job is admitted by all the limits, or the context
+// is done. The returned function must be called to release the limits once
+// the job is finished.
+func (l *concurrencyLimiter) acquire(ctx context.Context, limits []concurrencyLimit) (func(), error) {
+	waiter := &limiterWaiter{
+		limits: limits,
+		ready:  make(chan struct{}),
+	}
+
+	l.mu.Lock()
+	l.queue = append(l.queue, waiter)
+	l.dispatch()
+	l.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return l.releaseFunc(limits), nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-waiter.ready:
+		// admitted concurrently with the cancellation
+		l.release(limits)
+	default:
+		l.queue = slices.DeleteFunc(l.queue, func(w *limiterWaiter) bool {
+			return w == waiter
+		})
+		l.dispatch()
+	}
+
+	return nil, context.Cause(ctx)
+}
+
+// queueDepth returns the number of waiting jobs.
+func (l *concurrencyLimiter) queueDepth() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queue)
+}
+
+func (l *concurrencyLimiter) releaseFunc(limits []concurrencyLimit) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.release(limits)
+		})
+	}
+}
+
+func (l *concurrencyLimiter) release(limits []concurrencyLimit) {
+	for _, limit := range limits {
+		l.running[limit.key]--
+		if l.running[limit.key] <= 0 {
+			delete(l.running, limit.key)
+		}
+	}
+	l.dispatch()
+}
+
+// dispatch admits the waiting jobs that fit the limits. Must be called with
+// the mutex held.
+func (l *concurrencyLimiter) dispatch() {
+	blocked := make(map[string]bool)
+	waiting := l.queue[:0]
+	for _, waiter := range l.queue {
+		if l.admissible(waiter.limits, blocked) {
+			for _, limit := range waiter.limits {
+				l.running[limit.key]++
+			}
+			close(waiter.ready)
+			continue
+		}
+		// keep the order of the jobs waiting for the saturated limits
+		for _, limit := range waiter.limits {
+			if l.running[limit.key] >= limit.max {
+				blocked[limit.key] = true
+			}
+		}
+		waiting = append(waiting, waiter)
+	}
+	clear(l.queue[len(waiting):])
+	l.queue = waiting
+	backupQueueDepthGauge.Set(float64(len(l.queue)))
+}
+
+func (l *concurrencyLimiter) admissible(limits []concurrencyLimit, blocked map[string]bool) bool {
+	for _, limit := range limits {
+		if blocked[limit.key] || l.running[limit.key] >= limit.max {
+			return false
+		}
+	}
+	return true
+}
+
+// makeConcurrencyLimits returns the service, cluster and storage limits
+// of the routine backup jobs.
+func makeConcurrencyLimits(config *model.Config, routine *model.BackupRoutine) []concurrencyLimit {
+	limits := config.ServiceConfig.ConcurrencyLimits
+	if limits == nil {
+		return nil
+	}
+
+	var result []concurrencyLimit
+	if limit := limits.ServiceLimit(); limit > 0 {
+		result = append(result, concurrencyLimit{key: "service", max: limit})
+	}
+	for name, cluster := range config.AerospikeClusters {
+		if cluster != routine.SourceCluster {
+			continue
+		}
+		if limit := limits.ClusterLimit(name); limit > 0 {
+			result = append(result, concurrencyLimit{key: "cluster:" + name, max: limit})
+		}
+	}
+	for name, storage := range config.Storage {
+		if storage != routine.Storage {
+			continue
+		}
+		if limit := limits.StorageLimit(name); limit > 0 {
+			result = append(result, concurrencyLimit{key: "storage:" + name, max: limit})
+		}
+	}
+
+	return result
+}
+
+// acquireConcurrencyLimits waits until the backup job is admitted by the
+// concurrency limits. The returned function releases the limits.
+func (h *BackupRoutineHandler) acquireConcurrencyLimits(ctx context.Context, backupType jobType) (func(), error) {
+	if len(h.concurrencyLimits) == 0 {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	release, err := backupLimiter.acquire(ctx, h.concurrencyLimits)
+	wait := time.Since(start)
+	backupQueueWaitHistogram.WithLabelValues(h.routineName, string(backupType)).Observe(wait.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("cancelled while waiting for concurrency limits: %w", err)
+	}
+	if wait > time.Second {
+		slog.Info("Backup job waited for concurrency limits",
+			slog.String("routine", h.routineName),
+			slog.Any("type", backupType),
+			slog.Duration("wait", wait))
+	}
+
+	return release, nil
+}
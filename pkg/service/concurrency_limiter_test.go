@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync acquires the limits in a goroutine, the returned channel
+// receives the release function, or nil if the limits were not acquired.
+func acquireAsync(ctx context.Context, l *concurrencyLimiter, limits []concurrencyLimit) chan func() {
+	acquired := make(chan func(), 1)
+	go func() {
+		release, _ := l.acquire(ctx, limits)
+		acquired <- release
+	}()
+	return acquired
+}
+
+func waitForQueueDepth(t *testing.T, l *concurrencyLimiter, depth int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return l.queueDepth() == depth
+	}, time.Second, time.Millisecond)
+}
+
+// testContext returns a context with a deadline, so that a test blocked
+// on the limiter fails instead of hanging.
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestConcurrencyLimiter_FairQueue(t *testing.T) {
+	limiter := newConcurrencyLimiter()
+	limits := []concurrencyLimit{{key: "service", max: 1}}
+	ctx := testContext(t)
+
+	release, err := limiter.acquire(ctx, limits)
+	require.NoError(t, err)
+
+	second := acquireAsync(ctx, limiter, limits)
+	waitForQueueDepth(t, limiter, 1)
+	third := acquireAsync(ctx, limiter, limits)
+	waitForQueueDepth(t, limiter, 2)
+
+	release()
+	release() // release is idempotent
+	releaseSecond := <-second
+	require.NotNil(t, releaseSecond)
+	waitForQueueDepth(t, limiter, 1)
+	require.Empty(t, third)
+
+	releaseSecond()
+	releaseThird := <-third
+	require.NotNil(t, releaseThird)
+	releaseThird()
+	require.Equal(t, 0, limiter.queueDepth())
+	require.Empty(t, limiter.running)
+}
+
+func TestConcurrencyLimiter_NoHeadOfLineBlocking(t *testing.T) {
+	limiter := newConcurrencyLimiter()
+	cluster1 := []concurrencyLimit{{key: "service", max: 2}, {key: "cluster:1", max: 1}}
+	cluster2 := []concurrencyLimit{{key: "service", max: 2}, {key: "cluster:2", max: 1}}
+	ctx := testContext(t)
+
+	release1, err := limiter.acquire(ctx, cluster1)
+	require.NoError(t, err)
+
+	// waits for the cluster limit
+	second := acquireAsync(ctx, limiter, cluster1)
+	waitForQueueDepth(t, limiter, 1)
+
+	// the other cluster is not blocked by the job waiting for cluster 1
+	release2, err := limiter.acquire(ctx, cluster2)
+	require.NoError(t, err)
+
+	// waits for the service limit
+	third := acquireAsync(ctx, limiter, cluster2)
+	waitForQueueDepth(t, limiter, 2)
+
+	// the free service slot goes to the job that can use it
+	release2()
+	releaseThird := <-third
+	require.NotNil(t, releaseThird)
+	require.Empty(t, second)
+
+	release1()
+	releaseSecond := <-second
+	require.NotNil(t, releaseSecond)
+
+	releaseSecond()
+	releaseThird()
+	require.Equal(t, 0, limiter.queueDepth())
+	require.Empty(t, limiter.running)
+}
+
+func TestConcurrencyLimiter_Cancel(t *testing.T) {
+	limiter := newConcurrencyLimiter()
+	limits := []concurrencyLimit{{key: "storage:s3", max: 1}}
+
+	release, err := limiter.acquire(testContext(t), limits)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancelCause(testContext(t))
+	cancel(errBackupCancelled)
+	_, err = limiter.acquire(ctx, limits)
+	require.ErrorIs(t, err, errBackupCancelled)
+	require.Equal(t, 0, limiter.queueDepth())
+
+	release()
+	require.Empty(t, limiter.running)
+}
+
+func TestMakeConcurrencyLimits(t *testing.T) {
+	cluster := &model.AerospikeCluster{}
+	storage := &model.LocalStorage{Path: "backups"}
+	config := model.NewConfig()
+	config.AerospikeClusters["cluster"] = cluster
+	config.Storage["local"] = storage
+	routine := &model.BackupRoutine{SourceCluster: cluster, Storage: storage}
+
+	require.Empty(t, makeConcurrencyLimits(config, routine))
+
+	config.ServiceConfig.ConcurrencyLimits = &model.ConcurrencyLimits{
+		MaxBackups:           util.Ptr(4),
+		MaxBackupsPerCluster: util.Ptr(2),
+		Storage:              map[string]int{"local": 1},
+	}
+	require.Equal(t, []concurrencyLimit{
+		{key: "service", max: 4},
+		{key: "cluster:cluster", max: 2},
+		{key: "storage:local", max: 1},
+	}, makeConcurrencyLimits(config, routine))
+}
@@ -22,6 +22,7 @@ type DefaultConfigApplier struct {
 	backends      BackendsHolder
 	manager       ClientManager
 	handlerHolder *BackupHandlerHolder
+	runs          *BackupRuns
 }
 
 func NewDefaultConfigApplier(
@@ -30,6 +31,7 @@ func NewDefaultConfigApplier(
 	backends BackendsHolder,
 	manager ClientManager,
 	handlerHolder *BackupHandlerHolder,
+	runs *BackupRuns,
 ) ConfigApplier {
 	return &DefaultConfigApplier{
 		scheduler:     scheduler,
@@ -37,6 +39,7 @@ func NewDefaultConfigApplier(
 		backends:      backends,
 		manager:       manager,
 		handlerHolder: handlerHolder,
+		runs:          runs,
 	}
 }
 
@@ -53,7 +56,7 @@ func (a *DefaultConfigApplier) ApplyNewConfig() error {
 	clear(*a.handlerHolder)
 
 	// Refill handlers
-	newHandlers := makeHandlers(a.manager, a.config, a.backends)
+	newHandlers := makeHandlers(a.manager, a.config, a.backends, a.runs)
 	for k, v := range newHandlers {
 		(*a.handlerHolder)[k] = v
 	}
@@ -87,12 +90,13 @@ func (a *DefaultConfigApplier) clearPeriodicSchedulerJobs() error {
 func makeHandlers(clientManager ClientManager,
 	config *model.Config,
 	backends BackendsHolder,
+	runs *BackupRuns,
 ) BackupHandlerHolder {
 	handlers := make(BackupHandlerHolder)
 	backupService := NewBackupGo()
 	for routineName := range config.BackupRoutines {
 		backend, _ := backends.Get(routineName)
-		handlers[routineName] = newBackupRoutineHandler(config, clientManager, backupService, routineName, backend)
+		handlers[routineName] = newBackupRoutineHandler(config, clientManager, backupService, routineName, backend, runs)
 	}
 	return handlers
 }
@@ -17,20 +17,16 @@ func currentBackupStatus(handlers map[string]BackupHandler) *model.RunningJob {
 		total += handler.GetStats().TotalRecords
 	}
 
-	// These are the backups of multiple namespaces in the same routine.
-	// Therefore, picking any of those is valid, since they started at
-	// the same time.
-	startTime := getAnyHandler(handlers).GetStats().StartTime
-
-	return NewRunningJob(startTime, done, total)
-}
-
-func getAnyHandler(m map[string]BackupHandler) BackupHandler {
-	for _, value := range m {
-		return value
+	// These are the backups of multiple namespaces in the same routine,
+	// which are started one after another if their parallelism is limited.
+	var startTime time.Time
+	for _, handler := range handlers {
+		if start := handler.GetStats().StartTime; startTime.IsZero() || start.Before(startTime) {
+			startTime = start
+		}
 	}
 
-	return nil
+	return NewRunningJob(startTime, done, total)
 }
 
 // RestoreJobStatus returns the status of a restore job.
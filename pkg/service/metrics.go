@@ -20,18 +20,22 @@ var (
 			Name: "aerospike_backup_service_incremental_runs_total",
 			Help: "Incremental backup runs counter.",
 		})
-	// a counter metric for backup skip number
-	backupSkippedCounter = prometheus.NewCounter(
+	// a counter metric for backup skip number by reason
+	backupSkippedCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "aerospike_backup_service_skip_total",
 			Help: "Backup skip counter.",
-		})
-	// a counter metric for incremental backup skip number
-	incrBackupSkippedCounter = prometheus.NewCounter(
+		},
+		[]string{"reason"},
+	)
+	// a counter metric for incremental backup skip number by reason
+	incrBackupSkippedCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "aerospike_backup_service_incremental_skip_total",
 			Help: "Incremental backup skip counter.",
-		})
+		},
+		[]string{"reason"},
+	)
 	// a counter metric for backup failure number
 	backupFailureCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
@@ -44,6 +48,18 @@ var (
 			Name: "aerospike_backup_service_incremental_failure_total",
 			Help: "Incremental backup failure counter.",
 		})
+	// a counter metric for cancelled backup number
+	backupCancelledCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_cancel_total",
+			Help: "Backup cancel counter.",
+		})
+	// a counter metric for cancelled incremental backup number
+	incrBackupCancelledCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_incremental_cancel_total",
+			Help: "Incremental backup cancel counter.",
+		})
 	// a gauge metric for full backup duration
 	backupDurationGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -56,6 +72,83 @@ var (
 			Name: "aerospike_backup_service_incremental_duration_millis",
 			Help: "Incremental backup duration in milliseconds.",
 		})
+	// a counter metric for backups deleted by the retention policy
+	retentionDeletedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_retention_deleted_total",
+			Help: "Backups deleted by the retention policy.",
+		},
+		[]string{"routine", "type"},
+	)
+	// a counter metric for the incomplete backups deleted by the garbage collector
+	gcDeletedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_gc_deleted_total",
+			Help: "Incomplete backups deleted by the garbage collector.",
+		},
+		[]string{"routine"},
+	)
+	// a counter metric for the verified namespace backups
+	verificationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_verification_total",
+			Help: "Verified namespace backups by verification status.",
+		},
+		[]string{"routine", "status"},
+	)
+	// a counter metric for the backup copies to the replica storages
+	replicationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_replication_total",
+			Help: "Backup copies to replica storages by replication status.",
+		},
+		[]string{"routine", "storage", "status"},
+	)
+	// a counter metric for the backups moved to the tiering storage
+	tieringMovedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_tiering_moved_total",
+			Help: "Backups moved to the tiering storage.",
+		},
+		[]string{"routine"},
+	)
+	// a gauge metric for the number of backup jobs waiting for the concurrency limits
+	backupQueueDepthGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "aerospike_backup_service_queue_depth",
+			Help: "Number of backup jobs waiting for the concurrency limits.",
+		})
+	// a histogram metric for the time backup jobs wait for the concurrency limits
+	backupQueueWaitHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "aerospike_backup_service_queue_wait_seconds",
+			Help:    "Time backup jobs wait for the concurrency limits in seconds.",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+		},
+		[]string{"routine", "type"},
+	)
+	// a counter metric for the retry attempts of the failed full backups
+	backupRetryCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aerospike_backup_service_retry_total",
+			Help: "Retry attempts of the failed full backups.",
+		},
+		[]string{"routine"},
+	)
+	backupRetryAttempt = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "aerospike_backup_service_retry_attempt",
+			Help: "The current retry attempt of the failed full backup.",
+		},
+		[]string{"routine"},
+	)
+	backupNextRetry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "aerospike_backup_service_next_retry_timestamp_seconds",
+			Help: "Unix time of the pending retry of the failed full backup.",
+		},
+		[]string{"routine"},
+	)
 	backupProgress = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "aerospike_backup_service_backup_progress_pct",
@@ -79,9 +172,18 @@ func init() {
 	prometheus.MustRegister(incrBackupSkippedCounter)
 	prometheus.MustRegister(backupFailureCounter)
 	prometheus.MustRegister(incrBackupFailureCounter)
+	prometheus.MustRegister(backupCancelledCounter)
+	prometheus.MustRegister(incrBackupCancelledCounter)
 	prometheus.MustRegister(backupDurationGauge)
 	prometheus.MustRegister(incrBackupDurationGauge)
+	prometheus.MustRegister(retentionDeletedCounter)
+	prometheus.MustRegister(gcDeletedCounter)
+	prometheus.MustRegister(verificationCounter)
+	prometheus.MustRegister(replicationCounter)
+	prometheus.MustRegister(tieringMovedCounter)
+	prometheus.MustRegister(backupQueueDepthGauge, backupQueueWaitHistogram)
 	prometheus.MustRegister(backupProgress, restoreProgress)
+	prometheus.MustRegister(backupRetryCounter, backupRetryAttempt, backupNextRetry)
 }
 
 type MetricsCollector struct {
@@ -119,6 +221,8 @@ func (mc *MetricsCollector) collectMetrics() {
 
 func (mc *MetricsCollector) collectBackupMetrics() {
 	backupProgress.Reset()
+	backupRetryAttempt.Reset()
+	backupNextRetry.Reset()
 
 	for routineName, handler := range mc.backupHandler {
 		currentStat := handler.GetCurrentStat()
@@ -132,6 +236,14 @@ func (mc *MetricsCollector) collectBackupMetrics() {
 		if currentStat.Incremental != nil {
 			backupProgress.WithLabelValues(routineName, "Incremental").Set(float64(currentStat.Incremental.PercentageDone))
 		}
+
+		// Update retry metrics if the full backup is being retried
+		if currentStat.Retry != nil {
+			backupRetryAttempt.WithLabelValues(routineName).Set(float64(currentStat.Retry.Attempt))
+			if currentStat.Retry.NextRetryTime != nil {
+				backupNextRetry.WithLabelValues(routineName).Set(float64(currentStat.Retry.NextRetryTime.Unix()))
+			}
+		}
 	}
 }
 
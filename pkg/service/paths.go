@@ -10,8 +10,11 @@ import (
 )
 
 const (
-	metadataFile = "metadata.yaml"
-	configExt    = ".conf"
+	metadataFile     = "metadata.yaml"
+	verificationFile = "verification.yaml"
+	catalogFile      = "catalog.yaml"
+	replicationFile  = "replication.yaml"
+	configExt        = ".conf"
 )
 
 func getFullPath(fullBackupsPath string, backupPolicy *model.BackupPolicy, namespace string,
@@ -23,6 +26,16 @@ func getFullPath(fullBackupsPath string, backupPolicy *model.BackupPolicy, names
 	return fmt.Sprintf("%s/%s/%s/%s", fullBackupsPath, formatTime(now), model.DataDirectory, namespace)
 }
 
+// getFullBackupFolder returns the folder of the full backup (data, metadata
+// and cluster configuration).
+func getFullBackupFolder(fullBackupsPath string, created time.Time, noTimestampInPath bool) string {
+	if noTimestampInPath {
+		return fullBackupsPath
+	}
+
+	return fmt.Sprintf("%s/%s", fullBackupsPath, formatTime(created))
+}
+
 func getIncrementalPath(incrBackupsPath string, t time.Time) string {
 	return fmt.Sprintf("%s/%s", incrBackupsPath, formatTime(t))
 }
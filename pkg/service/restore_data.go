@@ -15,7 +15,7 @@ import (
 )
 
 var errBackendNotFound = errors.New("backend not found")
-var errBackupNotFound = errors.New("backup not found")
+var ErrBackupNotFound = errors.New("backup not found")
 
 // dataRestorer implements the RestoreManager interface.
 // Stores job information locally within a map.
@@ -57,7 +57,10 @@ func (r *dataRestorer) Restore(request *model.RestoreRequest) (model.RestoreJobI
 		slog.Info("Could not read backup metadata", slog.Any("err", err))
 	}
 
+	// the backup is not moved to the tiering storage while it is read
+	release := useBackupFolder(request.SourceStorage, request.BackupDataPath)
 	go func() {
+		defer release()
 		client, err := r.clientManager.GetClient(request.DestinationCuster)
 		if err != nil {
 			slog.Error("Failed to restore by path",
@@ -173,13 +176,16 @@ func (r *dataRestorer) restoreNamespace(
 	// Append incremental backups to allBackups
 	allBackups = append(allBackups, incrementalBackups...)
 
+	// the backups are not moved to the tiering storage while they are read
+	defer r.useBackupFolders(request.Routine, allBackups)()
+
 	for _, b := range allBackups {
 		r.restoreJobs.addTotalRecords(jobID, b.RecordCount)
 	}
 
 	// Now restore all backups in order
 	for _, b := range allBackups {
-		handler, err := r.restoreFromPath(ctx, client, request, b.Key)
+		handler, err := r.restoreFromPath(ctx, client, request, &b)
 		if err != nil {
 			return err
 		}
@@ -198,24 +204,78 @@ func (r *dataRestorer) restoreFromPath(
 	ctx context.Context,
 	client *backup.Client,
 	request *model.RestoreTimestampRequest,
-	backupPath string,
+	b *model.BackupDetails,
 ) (RestoreHandler, error) {
-	restoreRequest := r.toRestoreRequest(request)
-	restoreRequest.BackupDataPath = backupPath
+	restoreRequest := r.toRestoreRequest(request, r.sourceStorage(ctx, request.Routine, b))
+	restoreRequest.BackupDataPath = b.Key
 	handler, err := r.restoreService.Run(ctx, client, restoreRequest)
 	if err != nil {
-		return nil, fmt.Errorf("could not start restore from backup at %s: %w", backupPath, err)
+		return nil, fmt.Errorf("could not start restore from backup at %s: %w", b.Key, err)
 	}
 
 	return handler, nil
 }
 
-func (r *dataRestorer) toRestoreRequest(request *model.RestoreTimestampRequest) *model.RestoreRequest {
-	routine := r.config.BackupRoutines[request.Routine]
+// useBackupFolders marks the folders of the backups as in use, until the
+// returned function is called.
+func (r *dataRestorer) useBackupFolders(routineName string, backups []model.BackupDetails) func() {
+	releases := make([]func(), 0, len(backups))
+	for i := range backups {
+		s := backups[i].Storage
+		if s == nil {
+			s = r.config.BackupRoutines[routineName].Storage
+		}
+		releases = append(releases, useBackupFolder(s, backups[i].Key))
+	}
+
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+}
+
+// sourceStorage returns the storage to restore the backup from: the storage
+// the backup was listed from, or the first replica of the routine the backup
+// can be read from, if that storage is unreachable.
+func (r *dataRestorer) sourceStorage(ctx context.Context, routineName string, b *model.BackupDetails,
+) model.Storage {
+	routine := r.config.BackupRoutines[routineName]
+	primary := b.Storage
+	if primary == nil {
+		primary = routine.Storage
+	}
+	if len(routine.ReplicateTo) == 0 || backupReadable(ctx, primary, b.Key) {
+		return primary
+	}
+
+	for _, name := range replicaNames(routine.ReplicateTo) {
+		replica := routine.ReplicateTo[name]
+		if replica != primary && backupReadable(ctx, replica, b.Key) {
+			slog.Warn("Backup storage is unreachable, restoring from replica",
+				slog.String("routine", routineName),
+				slog.String("replica", name),
+				slog.String("path", b.Key))
+			return replica
+		}
+	}
+
+	return primary // fails with the error of the storage
+}
+
+// backupReadable returns true if the metadata of the namespace backup can be
+// read from the storage.
+func backupReadable(ctx context.Context, s model.Storage, path string) bool {
+	_, err := storage.ReadFile(ctx, s, filepath.Join(path, metadataFile))
+	return err == nil
+}
+
+func (r *dataRestorer) toRestoreRequest(request *model.RestoreTimestampRequest, source model.Storage,
+) *model.RestoreRequest {
 	return model.NewRestoreRequest(
 		request.DestinationCuster,
 		request.Policy,
-		routine.Storage,
+		source,
 		request.SecretAgent,
 	)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -48,6 +49,10 @@ func (b *BackendHolderMock) GetAllReaders() map[string]BackupListReader {
 	return nil
 }
 
+func (b *BackendHolderMock) GetAll() map[string]*BackupBackend {
+	return nil
+}
+
 func (b *BackendHolderMock) Init(_ *model.Config) {
 }
 
@@ -139,11 +144,11 @@ func (*BackendMock) FindLastFullBackup(t time.Time) ([]model.BackupDetails, erro
 		}}, nil
 	}
 
-	return nil, errBackupNotFound
+	return nil, ErrBackupNotFound
 }
 
 func (*BackendFailMock) FindLastFullBackup(_ time.Time) ([]model.BackupDetails, error) {
-	return nil, errBackupNotFound
+	return nil, ErrBackupNotFound
 }
 
 type BackendFailMock struct {
@@ -211,7 +216,7 @@ func TestLatestFullBackupBeforeTime(t *testing.T) {
 	if len(result) != 2 {
 		t.Errorf("Expected 2 backups")
 	}
-	if result[0] != backupList[1] {
+	if !reflect.DeepEqual(result[0], backupList[1]) {
 		t.Errorf("Expected the latest backup, but got %+v", result)
 	}
 }
@@ -277,8 +282,8 @@ func Test_RestoreByTimeFailNoTimestamp(t *testing.T) {
 	}
 
 	_, err := restoreService.RestoreByTime(request)
-	if err == nil || !errors.Is(err, errBackupNotFound) {
-		t.Errorf("Expected error %v, but got %v", errBackupNotFound, err)
+	if err == nil || !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("Expected error %v, but got %v", ErrBackupNotFound, err)
 	}
 }
 
@@ -289,8 +294,8 @@ func Test_RestoreByTimeFailNoBackup(t *testing.T) {
 	}
 
 	_, err := restoreService.RestoreByTime(request)
-	if err == nil || !errors.Is(err, errBackupNotFound) {
-		t.Errorf("Expected error %v, but got %v", errBackupNotFound, err)
+	if err == nil || !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("Expected error %v, but got %v", ErrBackupNotFound, err)
 	}
 }
 
@@ -394,6 +399,10 @@ func (m *MockClientManager) GetClient(_ *model.AerospikeCluster) (*backup.Client
 	return &backup.Client{}, nil
 }
 
+func (m *MockClientManager) GetRackAwareClient(_ *model.AerospikeCluster, _ []int) (*backup.Client, error) {
+	return &backup.Client{}, nil
+}
+
 func (m *MockClientManager) Close(*backup.Client) {
 }
 
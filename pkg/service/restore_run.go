@@ -32,6 +32,12 @@ func (r *RestoreRunner) Run(
 
 	config := makeRestoreConfig(request)
 
+	if request.Policy.VerifyChecksums != nil && *request.Policy.VerifyChecksums {
+		if err := verifyBackupChecksums(ctx, request.SourceStorage, request.BackupDataPath); err != nil {
+			return nil, fmt.Errorf("failed to verify backup checksums, %w", err)
+		}
+	}
+
 	reader, err := storage.CreateReader(ctx, request.SourceStorage, request.BackupDataPath, false, asb.NewValidator(), "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create backup reader, %w", err)
@@ -1,17 +1,62 @@
 package service
 
 import (
+	"errors"
 	"log/slog"
+	"math"
+	"math/rand"
+	"slices"
 	"sync"
 	"time"
+
+	as "github.com/aerospike/aerospike-client-go/v7"
+	"github.com/aerospike/aerospike-client-go/v7/types"
+	"github.com/aws/smithy-go"
 )
 
-// RetryService a service for retrying a function with a specified interval
-// and number of attempts.
+// retryJitter is the maximum fraction of the retry delay that is randomly
+// subtracted from it, so that the failed jobs do not retry at the same time.
+const retryJitter = 0.2
+
+// retryPolicy defines the delays between the attempts of a retried function.
+type retryPolicy struct {
+	// the delay before the first retry
+	delay time.Duration
+	// the maximum delay between the retries, not limited if zero
+	maxDelay time.Duration
+	// the delay is multiplied by the multiplier after each retry,
+	// it is fixed if the multiplier is less than or equal to one
+	multiplier float64
+	// the maximum number of retries
+	maxRetries int32
+}
+
+// backoff returns the delay before the given retry, starting from 1,
+// without the jitter.
+func (p retryPolicy) backoff(retry int32) time.Duration {
+	delay := float64(p.delay)
+	if p.multiplier > 1 {
+		delay *= math.Pow(p.multiplier, float64(retry-1))
+	}
+	if p.maxDelay > 0 && delay > float64(p.maxDelay) {
+		return p.maxDelay
+	}
+
+	return time.Duration(delay)
+}
+
+// RetryService a service for retrying a function with an exponential backoff
+// and a specified number of attempts.
 type RetryService struct {
 	label string
 	timer *time.Timer
 	mu    sync.Mutex
+
+	// the state of the current retries, guarded by stateMu,
+	// as mu is held while the function is running
+	stateMu   sync.Mutex
+	attempt   int32
+	nextRetry time.Time
 }
 
 // NewRetryService returns a new RetryService instance.
@@ -22,33 +67,68 @@ func NewRetryService(label string) *RetryService {
 	}
 }
 
-func (r *RetryService) retry(f func() error, retryInterval time.Duration, n int32) {
+// retry runs the function and retries it until it succeeds, fails with
+// a non-retryable error or the retries are exhausted.
+// A new call cancels the pending retry of the previous one.
+func (r *RetryService) retry(f func() error, policy retryPolicy) {
+	r.run(f, policy, 0)
+}
+
+func (r *RetryService) run(f func() error, policy retryPolicy, attempt int32) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	logger := slog.Default().With(slog.String("label", r.label))
 
 	r.clearTimer()
+	r.setState(attempt, time.Time{})
 	err := f()
 
 	if err == nil { // function executed successfully, no retry needed
+		r.setState(0, time.Time{})
+		return
+	}
+
+	if !isRetryable(err) {
+		logger.Warn("Execution failed with a non-retryable error",
+			slog.Any("err", err))
+		r.setState(0, time.Time{})
 		return
 	}
 
-	if n == 0 {
+	if attempt >= policy.maxRetries {
 		logger.Warn("Execution failed, no retry attempts left",
 			slog.Any("err", err))
+		r.setState(0, time.Time{})
 		return
 	}
 
+	retryInterval := withJitter(policy.backoff(attempt + 1))
 	logger.Info("Execution failed, retry scheduled",
 		slog.Any("retryInterval", retryInterval),
+		slog.Int("attempt", int(attempt+1)),
 		slog.Any("err", err))
 
+	r.setState(attempt, time.Now().Add(retryInterval))
 	r.timer = time.AfterFunc(retryInterval, func() {
-		r.retry(f, retryInterval, n-1)
+		r.run(f, policy, attempt+1)
 	})
 }
 
+// status returns the number of the current retry attempt, zero if the
+// function is not being retried, and the time of the pending retry.
+func (r *RetryService) status() (int32, time.Time) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+	return r.attempt, r.nextRetry
+}
+
+func (r *RetryService) setState(attempt int32, nextRetry time.Time) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+	r.attempt = attempt
+	r.nextRetry = nextRetry
+}
+
 func (r *RetryService) clearTimer() {
 	if r.timer != nil {
 		r.timer.Stop()
@@ -57,3 +137,82 @@ func (r *RetryService) clearTimer() {
 		}
 	}
 }
+
+// withJitter randomly shortens the delay by up to retryJitter of it.
+func withJitter(delay time.Duration) time.Duration {
+	// #nosec G404
+	return delay - time.Duration(rand.Float64()*retryJitter*float64(delay))
+}
+
+// permanentError is an error that a retry cannot fix, such as an invalid
+// backup configuration.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// permanent marks the error as non-retryable.
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// nonRetryableResultCodes are the Aerospike result codes of the authentication,
+// authorization and validation errors, which are not fixed by a retry.
+var nonRetryableResultCodes = []types.ResultCode{
+	types.INVALID_NAMESPACE,
+	types.NOT_AUTHENTICATED,
+	types.INVALID_USER,
+	types.INVALID_PASSWORD,
+	types.EXPIRED_PASSWORD,
+	types.FORBIDDEN_PASSWORD,
+	types.INVALID_CREDENTIAL,
+	types.INVALID_ROLE,
+	types.ROLE_VIOLATION,
+	types.NOT_WHITELISTED,
+	types.SECURITY_NOT_ENABLED,
+	types.PARAMETER_ERROR,
+}
+
+// nonRetryableStorageErrorCodes are the error codes of the object storage
+// authentication and configuration errors.
+var nonRetryableStorageErrorCodes = []string{
+	"AccessDenied",
+	"InvalidAccessKeyId",
+	"SignatureDoesNotMatch",
+	"NoSuchBucket",
+	"AuthorizationFailure",
+	"AuthenticationFailed",
+}
+
+// isRetryable classifies the error of a failed backup: the network errors,
+// timeouts and storage throttling are retried, as well as the unknown errors,
+// while the authentication, validation and namespace-not-found errors are not.
+func isRetryable(err error) bool {
+	var permanentErr *permanentError
+	if errors.As(err, &permanentErr) {
+		return false
+	}
+
+	var asErr as.Error
+	if errors.As(err, &asErr) {
+		return !asErr.Matches(nonRetryableResultCodes...)
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return !slices.Contains(nonRetryableStorageErrorCodes, apiErr.ErrorCode())
+	}
+
+	// network errors, timeouts, storage throttling and unknown errors
+	return true
+}
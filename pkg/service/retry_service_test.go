@@ -2,9 +2,16 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"sync"
 	"testing"
 	"time"
+
+	as "github.com/aerospike/aerospike-client-go/v7"
+	"github.com/aerospike/aerospike-client-go/v7/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
 )
 
 const timeout = 100 * time.Millisecond
@@ -21,7 +28,7 @@ func Test_timer(t *testing.T) {
 			return errors.New("mock error")
 		}
 		return nil
-	}, timeout, 3)
+	}, retryPolicy{delay: timeout, maxRetries: 3})
 
 	time.Sleep(1 * time.Second)
 	counterLock.Lock()
@@ -41,7 +48,7 @@ func Test_timer_expires(t *testing.T) {
 		defer counterLock.Unlock()
 		retryCounter++
 		return errors.New("mock error")
-	}, timeout, attempts-1)
+	}, retryPolicy{delay: timeout, maxRetries: attempts - 1})
 
 	time.Sleep(1 * time.Second)
 	counterLock.Lock()
@@ -64,8 +71,8 @@ func Test_timerRunTwice(t *testing.T) {
 		}
 		return nil
 	}
-	r.retry(f, timeout, 3)
-	r.retry(f, timeout, 3)
+	r.retry(f, retryPolicy{delay: timeout, maxRetries: 3})
+	r.retry(f, retryPolicy{delay: timeout, maxRetries: 3})
 
 	time.Sleep(1 * time.Second)
 	counterLock.Lock()
@@ -74,3 +81,89 @@ func Test_timerRunTwice(t *testing.T) {
 		t.Errorf("Expected retryCounter 0, got %d", retryCounter)
 	}
 }
+
+func Test_timerStopsOnPermanentError(t *testing.T) {
+	r := NewRetryService("test")
+	counterLock := sync.Mutex{}
+	retryCounter := 0
+	r.retry(func() error {
+		counterLock.Lock()
+		defer counterLock.Unlock()
+		retryCounter++
+		return permanent(errors.New("invalid config"))
+	}, retryPolicy{delay: timeout, maxRetries: 3})
+
+	time.Sleep(500 * time.Millisecond)
+	counterLock.Lock()
+	defer counterLock.Unlock()
+	assert.Equal(t, 1, retryCounter)
+	attempt, nextRetry := r.status()
+	assert.Zero(t, attempt)
+	assert.True(t, nextRetry.IsZero())
+}
+
+func Test_retryStatus(t *testing.T) {
+	r := NewRetryService("test")
+	start := time.Now()
+	r.retry(func() error {
+		return errors.New("mock error")
+	}, retryPolicy{delay: time.Hour, maxRetries: 3})
+	defer r.clearTimer()
+
+	attempt, nextRetry := r.status()
+	assert.Zero(t, attempt)
+	// the jitter shortens the delay by up to 20%
+	assert.WithinRange(t, nextRetry, start.Add(48*time.Minute), time.Now().Add(time.Hour))
+}
+
+func Test_retryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{
+		delay:      time.Second,
+		maxDelay:   10 * time.Second,
+		multiplier: 2,
+	}
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 8*time.Second, policy.backoff(4))
+	assert.Equal(t, 10*time.Second, policy.backoff(5))
+
+	fixed := retryPolicy{delay: time.Second, multiplier: 1}
+	assert.Equal(t, time.Second, fixed.backoff(5))
+}
+
+func Test_withJitter(t *testing.T) {
+	for range 100 {
+		delay := withJitter(time.Minute)
+		assert.LessOrEqual(t, delay, time.Minute)
+		assert.GreaterOrEqual(t, delay, 48*time.Second)
+	}
+}
+
+func Test_isRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unknown", errors.New("unknown"), true},
+		{"network", &net.OpError{Op: "dial", Err: errors.New("refused")}, true},
+		{"timeout", asError(types.TIMEOUT), true},
+		{"server not available", asError(types.SERVER_NOT_AVAILABLE), true},
+		{"invalid namespace", asError(types.INVALID_NAMESPACE), false},
+		{"not authenticated", asError(types.NOT_AUTHENTICATED), false},
+		{"wrapped invalid credential", fmt.Errorf("backup failed: %w", asError(types.INVALID_CREDENTIAL)), false},
+		{"permanent", permanent(errors.New("invalid config")), false},
+		{"wrapped permanent", fmt.Errorf("namespace test: %w", permanent(errors.New("invalid"))), false},
+		{"throttling", &smithy.GenericAPIError{Code: "SlowDown"}, true},
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDenied"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
+
+func asError(code types.ResultCode) error {
+	return &as.AerospikeError{ResultCode: code}
+}
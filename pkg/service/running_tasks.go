@@ -0,0 +1,38 @@
+package service
+
+import "sync"
+
+// runningTasks keeps track of the running background tasks by key, so that
+// the same task does not run twice at a time.
+type runningTasks struct {
+	sync.Mutex
+	running map[string]struct{}
+}
+
+func newRunningTasks() *runningTasks {
+	return &runningTasks{running: make(map[string]struct{})}
+}
+
+// start marks the task as running, returns false if it already is.
+func (t *runningTasks) start(key string) bool {
+	t.Lock()
+	defer t.Unlock()
+	if _, found := t.running[key]; found {
+		return false
+	}
+	t.running[key] = struct{}{}
+	return true
+}
+
+func (t *runningTasks) finish(key string) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.running, key)
+}
+
+func (t *runningTasks) isRunning(key string) bool {
+	t.Lock()
+	defer t.Unlock()
+	_, found := t.running[key]
+	return found
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 	"github.com/aerospike/backup-go"
@@ -16,6 +17,9 @@ type Accessor interface {
 	) (backup.StreamingReader, error)
 	createWriter(ctx context.Context, storage model.Storage, path string, isFile, isRemoveFiles, withNested bool,
 	) (backup.Writer, error)
+	// listFiles returns the paths of all files under the path, including
+	// the nested folders, relative to the storage root.
+	listFiles(ctx context.Context, storage model.Storage, path string) ([]string, error)
 }
 
 var accessors []Accessor
@@ -35,3 +39,21 @@ func getAccessor(storage model.Storage) Accessor {
 
 	panic(fmt.Sprintf("unsupported storage type %T", storage))
 }
+
+// objectPrefix returns the object name prefix of the folder at the path
+// of an object storage.
+func objectPrefix(path string) string {
+	if path == "/" || path == "." || path == "" {
+		return ""
+	}
+	return strings.TrimSuffix(path, "/") + "/"
+}
+
+// relativePath returns the object name relative to the storage root path.
+func relativePath(root, name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if root = strings.Trim(root, "/"); root != "" {
+		name = strings.TrimPrefix(name, root+"/")
+	}
+	return name
+}
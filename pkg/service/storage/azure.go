@@ -65,6 +65,35 @@ func (a *AzureStorageAccessor) createWriter(
 	return azure.NewWriter(ctx, client, azures.ContainerName, opts...)
 }
 
+func (a *AzureStorageAccessor) listFiles(
+	ctx context.Context, storage model.Storage, path string,
+) ([]string, error) {
+	azures := storage.(*model.AzureStorage)
+	client, err := getAzureClient(azures)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectPrefix(filepath.Join(azures.Path, path))
+	var files []string
+	pager := client.NewListBlobsFlatPager(azures.ContainerName, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs in %s: %w", prefix, err)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name != nil {
+				files = append(files, relativePath(azures.Path, *blob.Name))
+			}
+		}
+	}
+
+	return files, nil
+}
+
 func init() {
 	registerAccessor(&AzureStorageAccessor{})
 }
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
+)
+
+// probeFolder is the folder of the probe objects written by the storage check.
+const probeFolder = ".probe"
+
+// checkTimeout limits the time of the storage check, as the clients of
+// unreachable storages retry for long.
+const checkTimeout = time.Minute
+
+// Check writes, lists, reads and deletes a probe object in the storage, and
+// returns the latency of each operation and the error of the failed one.
+// The check stops at the first failed operation, but the probe object is
+// deleted once written.
+func Check(ctx context.Context, storage model.Storage) *model.StorageCheck {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	folder := filepath.Join(probeFolder, strconv.FormatInt(time.Now().UnixNano(), 10))
+	path := filepath.Join(folder, "probe")
+	content := []byte("aerospike-backup-service storage check")
+
+	check := &model.StorageCheck{Success: true}
+	run := func(operation model.StorageOperation, f func() error) bool {
+		start := time.Now()
+		err := f()
+		result := model.StorageOperationResult{Operation: operation, Latency: time.Since(start)}
+		if err != nil {
+			result.Error = err.Error()
+			if check.Success {
+				check.Success = false
+				check.FailedOperation = operation
+			}
+		}
+		check.Operations = append(check.Operations, result)
+		return err == nil
+	}
+
+	if !run(model.StorageWrite, func() error {
+		return WriteFile(ctx, storage, path, content)
+	}) {
+		return check
+	}
+
+	listed := run(model.StorageList, func() error {
+		files, err := ListFiles(ctx, storage, folder)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(files, path) {
+			return fmt.Errorf("probe object %s not listed", path)
+		}
+		return nil
+	})
+	if listed {
+		run(model.StorageRead, func() error {
+			data, err := ReadFile(ctx, storage, path)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(data, content) {
+				return errors.New("probe object content mismatch")
+			}
+			return nil
+		})
+	}
+
+	run(model.StorageDelete, func() error {
+		return DeleteFolder(ctx, storage, folder)
+	})
+
+	return check
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
 	"github.com/aerospike/backup-go"
 	gcp "github.com/aerospike/backup-go/io/gcp/storage"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -65,6 +67,33 @@ func (a *GcpStorageAccessor) createWriter(
 	return gcp.NewWriter(ctx, client, gcps.BucketName, opts...)
 }
 
+func (a *GcpStorageAccessor) listFiles(
+	ctx context.Context, s model.Storage, path string,
+) ([]string, error) {
+	gcps := s.(*model.GcpStorage)
+	client, err := getGcpClient(ctx, gcps)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	prefix := objectPrefix(filepath.Join(gcps.Path, path))
+	var files []string
+	it := client.Bucket(gcps.BucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		objAttrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects in %s: %w", prefix, err)
+		}
+		files = append(files, relativePath(gcps.Path, objAttrs.Name))
+	}
+
+	return files, nil
+}
+
 func init() {
 	registerAccessor(&GcpStorageAccessor{})
 }
@@ -2,6 +2,10 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
@@ -53,6 +57,57 @@ func (a *LocalStorageAccessor) createWriter(
 	return local.NewWriter(ctx, opts...)
 }
 
+func (a *LocalStorageAccessor) listFiles(
+	_ context.Context, storage model.Storage, path string,
+) ([]string, error) {
+	ls := storage.(*model.LocalStorage)
+	root := filepath.Join(ls.Path, path)
+	var files []string
+	err := filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			rel, err := filepath.Rel(ls.Path, file)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list files in %s: %w", root, err)
+	}
+
+	return files, nil
+}
+
 func init() {
 	registerAccessor(&LocalStorageAccessor{})
 }
+
+// replaceLocalFile writes the content to a temporary file in the target
+// directory and renames it to the target path.
+func replaceLocalFile(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temporary file %s: %w", tmp.Name(), err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file %s: %w", tmp.Name(), err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
 	"time"
 
 	"github.com/aerospike/aerospike-backup-service/v2/pkg/model"
@@ -99,10 +100,74 @@ func WriteFile(ctx context.Context, storage model.Storage, fileName string, cont
 	if err != nil {
 		return err
 	}
-	defer w.Close()
 
-	_, err = w.Write(content)
-	return err
+	if _, err = w.Write(content); err != nil {
+		_ = w.Close()
+		return err
+	}
+
+	// object storages upload the content on close
+	return w.Close()
+}
+
+// ReplaceFile writes the content to the file, replacing it if it already exists.
+// The file is never left partially written: object storages replace objects
+// atomically on upload, and for local storage the content is written to a
+// temporary file which is then renamed.
+func ReplaceFile(ctx context.Context, storage model.Storage, fileName string, content []byte) error {
+	if ls, ok := storage.(*model.LocalStorage); ok {
+		return replaceLocalFile(filepath.Join(ls.Path, fileName), content)
+	}
+
+	return WriteFile(ctx, storage, fileName, content)
+}
+
+// ListFiles returns the paths of all files under the path, including the
+// nested folders. The paths are relative to the storage root, as the given
+// path is. A missing path has no files.
+func ListFiles(ctx context.Context, storage model.Storage, path string) ([]string, error) {
+	return getAccessor(storage).listFiles(ctx, storage, path)
+}
+
+// CopyFile copies the file to the same path in the target storage,
+// replacing it if it already exists.
+func CopyFile(ctx context.Context, from, to model.Storage, path string) error {
+	reader, err := CreateReader(ctx, from, path, true, nil, "")
+	if err != nil {
+		return err
+	}
+
+	readersCh := make(chan io.ReadCloser, 1)
+	errorsCh := make(chan error, 1)
+	go reader.StreamFiles(ctx, readersCh, errorsCh)
+
+	var r io.ReadCloser
+	select {
+	case err := <-errorsCh:
+		return err
+	case r = <-readersCh:
+		defer r.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	writer, err := CreateWriter(ctx, to, path, true, false, false)
+	if err != nil {
+		return err
+	}
+
+	w, err := writer.NewWriter(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(w, r); err != nil {
+		_ = w.Close()
+		return err
+	}
+
+	// object storages upload the content on close
+	return w.Close()
 }
 
 func DeleteFolder(ctx context.Context, storage model.Storage, path string) error {
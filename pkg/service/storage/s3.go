@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"path/filepath"
 
@@ -65,6 +66,36 @@ func (a *S3StorageAccessor) createWriter(
 	return s3.NewWriter(ctx, client, s3s.Bucket, opts...)
 }
 
+func (a *S3StorageAccessor) listFiles(
+	ctx context.Context, storage model.Storage, path string,
+) ([]string, error) {
+	s3s := storage.(*model.S3Storage)
+	client, err := getS3Client(ctx, s3s)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectPrefix(filepath.Join(s3s.Path, path))
+	var files []string
+	paginator := awsS3.NewListObjectsV2Paginator(client, &awsS3.ListObjectsV2Input{
+		Bucket: &s3s.Bucket,
+		Prefix: &prefix,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects in %s: %w", prefix, err)
+		}
+		for _, object := range page.Contents {
+			if object.Key != nil {
+				files = append(files, relativePath(s3s.Path, *object.Key))
+			}
+		}
+	}
+
+	return files, nil
+}
+
 func init() {
 	registerAccessor(&S3StorageAccessor{})
 }
@@ -36,6 +36,8 @@ type RestoreHandler interface {
 type BackupHandler interface {
 	// GetStats returns the statistics of the backup job.
 	GetStats() *models.BackupStats
+	// GetFiles returns the manifest of the files written by the backup job.
+	GetFiles() []model.BackupFile
 	// Wait waits for the backup job to complete and returns an error if the
 	// job failed.
 	Wait(context.Context) error
@@ -16,6 +16,8 @@ type LoadingCache[K comparable, T any] struct {
 	ctx      context.Context
 	data     map[K]T
 	loadFunc LoadFunc[K, T]
+	// the per key locks, held while the value is loaded
+	loading map[K]*sync.Mutex
 }
 
 // NewLoadingCache returns a new LoadingCache instance.
@@ -25,6 +27,7 @@ func NewLoadingCache[K comparable, T any](ctx context.Context,
 		ctx:      ctx,
 		data:     make(map[K]T),
 		loadFunc: loadFunc,
+		loading:  make(map[K]*sync.Mutex),
 	}
 
 	go cache.startCleanup()
@@ -32,12 +35,20 @@ func NewLoadingCache[K comparable, T any](ctx context.Context,
 }
 
 // Get retrieves or loads the value for the specified key and stores
-// it in the cache.
+// it in the cache. The value is loaded under the lock of the key, so that
+// it is loaded once and loading does not block the other keys.
 func (c *LoadingCache[K, T]) Get(key K) (T, error) {
-	c.Lock()
-	defer c.Unlock()
+	val, found := c.get(key)
+	if found {
+		return val, nil
+	}
 
-	val, found := c.data[key]
+	keyLock := c.keyLock(key)
+	keyLock.Lock()
+	defer keyLock.Unlock()
+
+	// loaded while waiting for the key lock
+	val, found = c.get(key)
 	if found {
 		return val, nil
 	}
@@ -47,10 +58,30 @@ func (c *LoadingCache[K, T]) Get(key K) (T, error) {
 		return loadedValue, err
 	}
 
+	c.Lock()
 	c.data[key] = loadedValue
+	c.Unlock()
 	return loadedValue, nil
 }
 
+func (c *LoadingCache[K, T]) get(key K) (T, bool) {
+	c.Lock()
+	defer c.Unlock()
+	val, found := c.data[key]
+	return val, found
+}
+
+func (c *LoadingCache[K, T]) keyLock(key K) *sync.Mutex {
+	c.Lock()
+	defer c.Unlock()
+	keyLock, found := c.loading[key]
+	if !found {
+		keyLock = &sync.Mutex{}
+		c.loading[key] = keyLock
+	}
+	return keyLock
+}
+
 func (c *LoadingCache[T, K]) startCleanup() {
 	ticker := time.NewTicker(time.Hour)
 	defer ticker.Stop()
@@ -36,3 +36,31 @@ func TestLoadingCache_Error(t *testing.T) {
 		t.Error("Error must not be nil")
 	}
 }
+
+func TestLoadingCache_LoadOutsideLock(t *testing.T) {
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	cache := NewLoadingCache(context.Background(), func(key string) (int, error) {
+		if key == "slow" {
+			close(loading)
+			<-release
+		}
+		return len(key), nil
+	})
+
+	go func() {
+		_, _ = cache.Get("slow")
+	}()
+	<-loading
+
+	// the other keys are loaded while the slow key is loading
+	value, _ := cache.Get("fast")
+	if value != 4 {
+		t.Error("The value is expected to be 4")
+	}
+	close(release)
+	value, _ = cache.Get("slow")
+	if value != 4 {
+		t.Error("The value is expected to be 4")
+	}
+}
@@ -3,7 +3,9 @@ package util
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Ptr returns a pointer to the given object.
@@ -55,3 +57,24 @@ func ParseS3Path(s string) (bucket string, path string, err error) {
 
 	return parsed.Host, strings.TrimPrefix(parsed.Path, "/"), nil
 }
+
+// ParseDuration parses a duration string. In addition to the units supported
+// by [time.ParseDuration], it accepts whole days ("7d") and weeks ("2w").
+func ParseDuration(s string) (time.Duration, error) {
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		return time.ParseDuration(s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(s[:len(s)-1]))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return time.Duration(n) * unit, nil
+}
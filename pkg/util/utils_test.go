@@ -2,6 +2,7 @@ package util
 
 import (
 	"testing"
+	"time"
 )
 
 func TestPtr(t *testing.T) {
@@ -34,3 +35,20 @@ func TestValueOrZero(t *testing.T) {
 		t.Error("Expected 0")
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"90m": 90 * time.Minute,
+		"7d":  7 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+	}
+	for s, expected := range tests {
+		d, err := ParseDuration(s)
+		if err != nil || d != expected {
+			t.Errorf("Expected %s to be parsed as %v, got %v, %v", s, expected, d, err)
+		}
+	}
+	if _, err := ParseDuration("xd"); err == nil {
+		t.Error("Expected error")
+	}
+}